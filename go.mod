@@ -4,8 +4,12 @@ go 1.15
 
 require (
 	github.com/hashicorp/go-cty v1.4.1-0.20200414143053-d3edf31b6320
+	github.com/hashicorp/hcl/v2 v2.3.0
 	github.com/hashicorp/terraform-plugin-sdk/v2 v2.5.0
 	github.com/jeremmfr/go-netconf v0.3.1
 	github.com/jeremmfr/junosdecode v1.0.0
+	github.com/openconfig/gnmi v0.0.0-20210226144353-8eae1937bf84
+	github.com/zclconf/go-cty v1.2.1
 	golang.org/x/crypto v0.0.0-20210322153248-0c34fe9e7dc2
+	google.golang.org/grpc v1.34.0
 )
@@ -1,8 +1,10 @@
 package junos
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 )
@@ -19,25 +21,60 @@ type configProvider struct {
 	junosSSHKeyPEM           string
 	junosSSHKeyFile          string
 	junosKeyPass             string
+	junosTransport           string
+	junosOutboundSSHListen   string
+	junosOutboundSSHDeviceID string
+	junosOutboundSSHSecret   string
+	junosTLSCAFile           string
+	junosTLSCertFile         string
+	junosTLSKeyFile          string
 	junosGroupIntDel         string
 	junosFilePermission      string
 	junosDebugNetconfLogPath string
 	junosFakeCreateSetFile   string
+	junosSchemaCacheDir      string
+	junosOwnershipWorkspace  string
+	junosOwnershipImport     string
+	junosOwnershipMarker     bool
+	junosStrict              bool
+	junosCommandPrefixes     []string
+	junosCommitLogSuffix     string
 }
 
 // prepareSession : prepare information to connect to Junos Device and more.
 func (c *configProvider) prepareSession() (*Session, diag.Diagnostics) {
 	sess := &Session{
-		junosIP:             c.junosIP,
-		junosPort:           c.junosPort,
-		junosUserName:       c.junosUserName,
-		junosPassword:       c.junosPassword,
-		junosSSHKeyPEM:      c.junosSSHKeyPEM,
-		junosKeyPass:        c.junosKeyPass,
-		junosGroupIntDel:    c.junosGroupIntDel,
-		junosSleepLock:      c.junosCmdSleepLock,
-		junosSleepShort:     c.junosCmdSleepShort,
-		junosSleepSSHClosed: c.junosSSHSleepClosed,
+		junosIP:                 c.junosIP,
+		junosPort:               c.junosPort,
+		junosUserName:           c.junosUserName,
+		junosPassword:           c.junosPassword,
+		junosSSHKeyPEM:          c.junosSSHKeyPEM,
+		junosKeyPass:            c.junosKeyPass,
+		junosGroupIntDel:        c.junosGroupIntDel,
+		junosTransport:          c.junosTransport,
+		junosSleepLock:          c.junosCmdSleepLock,
+		junosSleepShort:         c.junosCmdSleepShort,
+		junosSleepSSHClosed:     c.junosSSHSleepClosed,
+		junosSysInfo:            &sysInfoCache{},
+		junosSchemaCache:        &configSchemaCache{},
+		junosOwnershipMarker:    c.junosOwnershipMarker,
+		junosOwnershipWorkspace: c.junosOwnershipWorkspace,
+		junosOwnershipImport:    c.junosOwnershipImport,
+		junosStrict:             c.junosStrict,
+		junosCommitLogSuffix:    c.junosCommitLogSuffix,
+	}
+	// junosCommandPrefixes
+	sess.junosCommandPrefixes = listOfCommandAllowedPrefixes()
+	if len(c.junosCommandPrefixes) > 0 {
+		sess.junosCommandPrefixes = make([]string, 0, len(c.junosCommandPrefixes))
+		for _, prefix := range c.junosCommandPrefixes {
+			prefix = strings.Join(strings.Fields(prefix), " ")
+			if prefix != "show" && !strings.HasPrefix(prefix, "show ") {
+				return sess, diag.FromErr(fmt.Errorf("prefix '%s' in command_allowed_prefixes "+
+					"need to be a 'show' command", prefix))
+			}
+			sess.junosCommandPrefixes = append(sess.junosCommandPrefixes, prefix)
+		}
 	}
 	// junosSSHKeyFile
 	sshKeyFile := c.junosSSHKeyFile
@@ -46,6 +83,44 @@ func (c *configProvider) prepareSession() (*Session, diag.Diagnostics) {
 	}
 	sess.junosSSHKeyFile = sshKeyFile
 
+	// junosOutboundSSH
+	if c.junosOutboundSSHListen != "" {
+		if c.junosTransport != transportNetconf {
+			return sess, diag.FromErr(fmt.Errorf("outbound_ssh_listen can only be used with %s transport", transportNetconf))
+		}
+		if c.junosOutboundSSHSecret == "" {
+			return sess, diag.FromErr(errors.New("outbound_ssh_secret need to be set with outbound_ssh_listen " +
+				"to authenticate the host key announced by device"))
+		}
+		sess.junosOutboundSSHListener = &outboundSSHListener{address: c.junosOutboundSSHListen}
+		sess.junosOutboundSSHDeviceID = c.junosOutboundSSHDeviceID
+		if sess.junosOutboundSSHDeviceID == "" {
+			sess.junosOutboundSSHDeviceID = c.junosIP
+		}
+		sess.junosOutboundSSHSecret = c.junosOutboundSSHSecret
+	}
+
+	// junosTLSCAFile
+	tlsCAFile := c.junosTLSCAFile
+	if err := replaceTildeToHomeDir(&tlsCAFile); err != nil {
+		return sess, diag.FromErr(err)
+	}
+	sess.junosTLSCAFile = tlsCAFile
+
+	// junosTLSCertFile
+	tlsCertFile := c.junosTLSCertFile
+	if err := replaceTildeToHomeDir(&tlsCertFile); err != nil {
+		return sess, diag.FromErr(err)
+	}
+	sess.junosTLSCertFile = tlsCertFile
+
+	// junosTLSKeyFile
+	tlsKeyFile := c.junosTLSKeyFile
+	if err := replaceTildeToHomeDir(&tlsKeyFile); err != nil {
+		return sess, diag.FromErr(err)
+	}
+	sess.junosTLSKeyFile = tlsKeyFile
+
 	// junosFilePermission
 	filePermission, err := strconv.ParseInt(c.junosFilePermission, 8, 64)
 	if err != nil {
@@ -68,5 +143,12 @@ func (c *configProvider) prepareSession() (*Session, diag.Diagnostics) {
 	}
 	sess.junosFakeCreateSetFile = junosFakeCreateSetFile
 
+	// junosSchemaCacheDir
+	junosSchemaCacheDir := c.junosSchemaCacheDir
+	if err := replaceTildeToHomeDir(&junosSchemaCacheDir); err != nil {
+		return sess, diag.FromErr(err)
+	}
+	sess.junosSchemaCacheDir = junosSchemaCacheDir
+
 	return sess, nil
 }
@@ -0,0 +1,174 @@
+package junos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	commandFormatText = "text"
+	commandFormatXML  = "xml"
+	commandFormatJSON = "json"
+)
+
+// listOfCommandPipes : pipe commands allowed after a show command in junos_command data source
+// (without pipes which write files or run other commands like save, request or compare).
+func listOfCommandPipes() []string {
+	return []string{"count", "display", "except", "find", "last", "match", "no-more", "resolve", "trim"}
+}
+
+// listOfCommandAllowedPrefixes : default prefixes of commands allowed in junos_command data source
+// (without commands which can display secrets like 'show configuration').
+func listOfCommandAllowedPrefixes() []string {
+	return []string{
+		"show arp", "show bfd", "show bgp", "show chassis", "show ethernet-switching", "show interfaces",
+		"show ipv6 neighbors", "show isis", "show lacp", "show ldp", "show lldp", "show mpls", "show ntp",
+		"show ospf", "show ospf3", "show route", "show rsvp", "show security", "show snmp", "show system",
+		"show version", "show vlans", "show vrrp",
+	}
+}
+
+func dataSourceCommand() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceCommandRead,
+
+		Schema: map[string]*schema.Schema{
+			"command": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"format": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      commandFormatText,
+				ValidateFunc: validation.StringInSlice([]string{commandFormatText, commandFormatXML, commandFormatJSON}, false),
+			},
+			"output": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceCommandRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	command := strings.Join(strings.Fields(d.Get("command").(string)), " ")
+	sess := m.(*Session)
+	if err := checkCommandAllowed(command, sess.junosCommandPrefixes); err != nil {
+		return diag.FromErr(err)
+	}
+	jnprSess, err := sess.startNewSession()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer sess.closeSession(jnprSess)
+	format := d.Get("format").(string)
+	reply, err := sess.commandXML(fmt.Sprintf(rpcCommandFormat, format, html.EscapeString(command)), jnprSess)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	output, err := readCommandOutput(reply, format)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("reply of command '%s' : %w", command, err))
+	}
+	d.SetId(command)
+	if tfErr := d.Set("output", output); tfErr != nil {
+		panic(tfErr)
+	}
+
+	return nil
+}
+
+// checkCommandAllowed only allow operational show commands starting with one of prefixes
+// and with pipes in listOfCommandPipes.
+func checkCommandAllowed(command string, prefixes []string) error {
+	if strings.ContainsAny(command, ";\n\r") {
+		return fmt.Errorf("command '%s' not allowed, only one command is allowed", command)
+	}
+	for i, part := range strings.Split(command, "|") {
+		words := strings.Fields(part)
+		if len(words) == 0 {
+			return fmt.Errorf("command '%s' not allowed, empty command or pipe", command)
+		}
+		if i == 0 && words[0] != "show" {
+			return fmt.Errorf("command '%s' not allowed, only 'show' commands are allowed "+
+				"(configuration mode or 'request' commands are refused)", command)
+		}
+		if i > 0 && !stringInSlice(words[0], listOfCommandPipes()) {
+			return fmt.Errorf("pipe '%s' in command '%s' not allowed (allowed pipes: %s)",
+				words[0], command, strings.Join(listOfCommandPipes(), ", "))
+		}
+	}
+	if !commandHasAllowedPrefix(strings.Fields(strings.Split(command, "|")[0]), prefixes) {
+		return fmt.Errorf("command '%s' not allowed, it doesn't start with an allowed prefix (%s)",
+			command, strings.Join(prefixes, ", "))
+	}
+
+	return nil
+}
+
+// commandHasAllowedPrefix check if the words of command start with all words of one of prefixes.
+func commandHasAllowedPrefix(words, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		prefixWords := strings.Fields(prefix)
+		if len(prefixWords) > len(words) {
+			continue
+		}
+		match := true
+		for i, word := range prefixWords {
+			if words[i] != word {
+				match = false
+
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+
+	return false
+}
+
+// readCommandOutput extract the output of command from reply for the format.
+func readCommandOutput(reply, format string) (string, error) {
+	switch format {
+	case commandFormatXML:
+		return strings.TrimSpace(reply), nil
+	case commandFormatJSON:
+		output := strings.TrimSpace(reply)
+		if !json.Valid([]byte(output)) {
+			// the JSON can be escaped in reply
+			output = html.UnescapeString(output)
+		}
+		if !json.Valid([]byte(output)) {
+			return "", fmt.Errorf("output isn't a valid JSON: %s", reply)
+		}
+
+		return output, nil
+	default:
+		nodes, err := parseXMLNodes(reply)
+		if err != nil {
+			return "", err
+		}
+		// text output is in <output> element, can be in other elements like <configuration-output>
+		texts := make([]string, 0)
+		for _, node := range nodes {
+			if len(node.children) == 0 {
+				texts = append(texts, node.text)
+			}
+			for _, child := range node.children {
+				texts = append(texts, child.text)
+			}
+		}
+
+		return strings.Join(texts, "\n"), nil
+	}
+}
@@ -0,0 +1,87 @@
+package junos
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestCheckCommandAllowed(t *testing.T) {
+	cases := []struct {
+		command  string
+		prefixes []string
+		wantErr  string
+	}{
+		{command: "show interfaces ge-0/0/0 terse", prefixes: listOfCommandAllowedPrefixes()},
+		{command: "show route 192.0.2.0/24 | match next-hop", prefixes: listOfCommandAllowedPrefixes()},
+		{command: "show configuration", prefixes: listOfCommandAllowedPrefixes(), wantErr: "allowed prefix"},
+		{command: "show configuration system | display set", prefixes: listOfCommandAllowedPrefixes(),
+			wantErr: "allowed prefix"},
+		{command: "show interface ge-0/0/0", prefixes: listOfCommandAllowedPrefixes(), wantErr: "allowed prefix"},
+		{command: "show route", prefixes: []string{"show route"}},
+		{command: "show routes", prefixes: []string{"show route"}, wantErr: "allowed prefix"},
+		{command: "show interfaces", prefixes: []string{"show route"}, wantErr: "allowed prefix"},
+		{command: "show configuration snmp", prefixes: []string{"show configuration snmp"}},
+		{command: "request system reboot", prefixes: []string{"show"}, wantErr: "only 'show' commands"},
+		{command: "show version | save /var/tmp/v", prefixes: []string{"show"}, wantErr: "pipe 'save'"},
+		{command: "show version; request system reboot", prefixes: []string{"show"}, wantErr: "only one command"},
+	}
+	for _, c := range cases {
+		err := checkCommandAllowed(c.command, c.prefixes)
+		switch {
+		case c.wantErr == "" && err != nil:
+			t.Errorf("checkCommandAllowed(%q, %q) error = %v", c.command, c.prefixes, err)
+		case c.wantErr != "" && err == nil:
+			t.Errorf("checkCommandAllowed(%q, %q) without error", c.command, c.prefixes)
+		case c.wantErr != "" && !strings.Contains(err.Error(), c.wantErr):
+			t.Errorf("checkCommandAllowed(%q, %q) error = %v, want %q", c.command, c.prefixes, err, c.wantErr)
+		}
+	}
+}
+
+func TestDataSourceCommandRefused(t *testing.T) {
+	fake, err := StartFakeDevice("vsrx", "20.2R1.10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fake.Close()
+	sess := newFakeDeviceSession(t, fake)
+	sess.junosCommandPrefixes = listOfCommandAllowedPrefixes()
+	fake.SetCommitted([]string{"set system root-authentication encrypted-password \"$6$secret\""})
+	r := Provider().DataSourcesMap["junos_command"]
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"command": "show configuration system root-authentication",
+	})
+	diags := r.ReadContext(context.Background(), d, sess)
+	if !diags.HasError() {
+		t.Fatalf("read of command without allowed prefix without error, output = %q", d.Get("output"))
+	}
+	if !strings.Contains(diags[0].Summary, "allowed prefix") {
+		t.Errorf("read of command without allowed prefix error = %v", diags)
+	}
+	if d.Get("output").(string) != "" {
+		t.Errorf("output of refused command = %q", d.Get("output"))
+	}
+}
+
+func TestProviderCommandAllowedPrefixes(t *testing.T) {
+	c := configProvider{junosFilePermission: "0644", junosCommandPrefixes: []string{"show  route", "show bgp summary"}}
+	sess, diags := c.prepareSession()
+	if diags.HasError() {
+		t.Fatalf("prepareSession error = %v", diags)
+	}
+	if want := []string{"show route", "show bgp summary"}; strings.Join(sess.junosCommandPrefixes, ",") !=
+		strings.Join(want, ",") {
+		t.Errorf("prefixes = %q, want %q", sess.junosCommandPrefixes, want)
+	}
+	c = configProvider{junosFilePermission: "0644", junosCommandPrefixes: []string{"request system"}}
+	if _, diags := c.prepareSession(); !diags.HasError() {
+		t.Error("prepareSession with a prefix not a show command without error")
+	}
+	c = configProvider{junosFilePermission: "0644"}
+	if sess, _ := c.prepareSession(); len(sess.junosCommandPrefixes) != len(listOfCommandAllowedPrefixes()) {
+		t.Errorf("default prefixes = %q", sess.junosCommandPrefixes)
+	}
+}
@@ -0,0 +1,93 @@
+package junos_test
+
+import (
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// export TESTACC_INTERFACE=<inteface> for choose interface available else it's ge-0/0/3.
+func TestAccDataSourceCommand_basic(t *testing.T) {
+	var testaccInterface string
+	if os.Getenv("TESTACC_INTERFACE") != "" {
+		testaccInterface = os.Getenv("TESTACC_INTERFACE")
+	} else {
+		testaccInterface = defaultInterfaceTestAcc
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceCommandConfigCreate(testaccInterface),
+			},
+			{
+				Config: testAccDataSourceCommandConfigData(testaccInterface),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.junos_command.testacc_command_text",
+						"id", "show interfaces "+testaccInterface+" terse"),
+					resource.TestMatchResourceAttr("data.junos_command.testacc_command_text",
+						"output", regexp.MustCompile(regexp.QuoteMeta(testaccInterface)+`\s+up\s+up`)),
+					resource.TestMatchResourceAttr("data.junos_command.testacc_command_xml",
+						"output", regexp.MustCompile(`<name>`+regexp.QuoteMeta(testaccInterface)+`</name>`)),
+					resource.TestCheckOutput("testacc_command_status", "up"),
+				),
+			},
+			{
+				Config: testAccDataSourceCommandConfigCreate(testaccInterface) + `
+data junos_command testacc_command_request {
+  command = "request system reboot"
+}
+`,
+				ExpectError: regexp.MustCompile("only 'show' commands are allowed"),
+			},
+			{
+				Config: testAccDataSourceCommandConfigCreate(testaccInterface) + `
+data junos_command testacc_command_save {
+  command = "show configuration | save /var/tmp/testacc"
+}
+`,
+				ExpectError: regexp.MustCompile("pipe 'save' in command"),
+			},
+			{
+				Config: testAccDataSourceCommandConfigCreate(testaccInterface) + `
+data junos_command testacc_command_configuration {
+  command = "show configuration system"
+}
+`,
+				ExpectError: regexp.MustCompile("doesn't start with an allowed prefix"),
+			},
+		},
+	})
+}
+
+func testAccDataSourceCommandConfigCreate(interFace string) string {
+	return `
+resource junos_interface_physical testacc_command {
+  name        = "` + interFace + `"
+  description = "testacc_command"
+}
+`
+}
+
+func testAccDataSourceCommandConfigData(interFace string) string {
+	return testAccDataSourceCommandConfigCreate(interFace) + `
+data junos_command testacc_command_text {
+  command = "show interfaces ` + interFace + ` terse"
+}
+data junos_command testacc_command_xml {
+  command = "show interfaces ` + interFace + ` terse"
+  format  = "xml"
+}
+data junos_command testacc_command_json {
+  command = "show interfaces ` + interFace + ` terse"
+  format  = "json"
+}
+output testacc_command_status {
+  value = jsondecode(data.junos_command.testacc_command_json.output)["interface-information"][0][
+    "physical-interface"][0]["oper-status"][0]["data"]
+}
+`
+}
@@ -0,0 +1,126 @@
+package junos
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const rpcGetCommitInformation = "<get-commit-information/>"
+
+type commitHistory struct {
+	sequence int
+	user     string
+	client   string
+	time     string
+	log      string
+}
+
+func dataSourceCommitHistory() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceCommitHistoryRead,
+
+		Schema: map[string]*schema.Schema{
+			"commits": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"sequence": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"user": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"client": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"time": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"log": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceCommitHistoryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sess := m.(*Session)
+	jnprSess, err := sess.startNewSession()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer sess.closeSession(jnprSess)
+	commits, err := readCommitHistory(m, jnprSess)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("commit_history")
+	commitsList := make([]map[string]interface{}, 0, len(commits))
+	for _, commit := range commits {
+		commitsList = append(commitsList, map[string]interface{}{
+			"sequence": commit.sequence,
+			"user":     commit.user,
+			"client":   commit.client,
+			"time":     commit.time,
+			"log":      commit.log,
+		})
+	}
+	if tfErr := d.Set("commits", commitsList); tfErr != nil {
+		panic(tfErr)
+	}
+
+	return nil
+}
+
+// readCommitHistory read the commits on device from the most recent (sequence 0 is the rollback 0).
+func readCommitHistory(m interface{}, jnprSess *NetconfObject) ([]commitHistory, error) {
+	sess := m.(*Session)
+	reply, err := sess.commandXML(rpcGetCommitInformation, jnprSess)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := parseXMLNodes(reply)
+	if err != nil {
+		return nil, fmt.Errorf("reply of commit information : %w", err)
+	}
+	commits := make([]commitHistory, 0)
+	for _, node := range xmlDescendants(&xmlNode{children: nodes}) {
+		if node.name != "commit-history" {
+			continue
+		}
+		var commit commitHistory
+		for _, child := range node.children {
+			switch child.name {
+			case "sequence-number":
+				commit.sequence, err = strconv.Atoi(child.text)
+				if err != nil {
+					return nil, fmt.Errorf("failed to convert value from '%s' to integer : %w", child.text, err)
+				}
+			case "user":
+				commit.user = child.text
+			case "client":
+				commit.client = child.text
+			case "date-time":
+				commit.time = child.text
+			case "log":
+				commit.log = child.text
+			}
+		}
+		commits = append(commits, commit)
+	}
+
+	return commits, nil
+}
@@ -0,0 +1,56 @@
+package junos_test
+
+import (
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// export TESTACC_INTERFACE=<inteface> for choose interface available else it's ge-0/0/3.
+func TestAccDataSourceCommitHistory_basic(t *testing.T) {
+	var testaccInterface string
+	if os.Getenv("TESTACC_INTERFACE") != "" {
+		testaccInterface = os.Getenv("TESTACC_INTERFACE")
+	} else {
+		testaccInterface = defaultInterfaceTestAcc
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceCommitHistoryConfigCreate(testaccInterface),
+			},
+			{
+				Config: testAccDataSourceCommitHistoryConfigData(testaccInterface),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.junos_commit_history.testacc_commit_history",
+						"commits.0.sequence", "0"),
+					resource.TestCheckResourceAttr("data.junos_commit_history.testacc_commit_history",
+						"commits.0.log", "create resource junos_interface_physical"),
+					resource.TestCheckResourceAttrSet("data.junos_commit_history.testacc_commit_history",
+						"commits.0.user"),
+					resource.TestMatchResourceAttr("data.junos_commit_history.testacc_commit_history",
+						"commits.0.time", regexp.MustCompile(`^\d{4}-\d{2}-\d{2} `)),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceCommitHistoryConfigCreate(interFace string) string {
+	return `
+resource junos_interface_physical testacc_commit_history {
+  name        = "` + interFace + `"
+  description = "testacc_commit_history"
+}
+`
+}
+
+func testAccDataSourceCommitHistoryConfigData(interFace string) string {
+	return testAccDataSourceCommitHistoryConfigCreate(interFace) + `
+data junos_commit_history testacc_commit_history {}
+`
+}
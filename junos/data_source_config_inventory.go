@@ -0,0 +1,115 @@
+package junos
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceConfigInventory() *schema.Resource {
+	resourceTypes := make([]string, 0)
+	for _, objectType := range configObjectTypes() {
+		resourceTypes = append(resourceTypes, objectType.resource)
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceConfigInventoryRead,
+
+		Schema: map[string]*schema.Schema{
+			"resource_types": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(resourceTypes, false),
+				},
+			},
+			"objects": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"resource_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceConfigInventoryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sess := m.(*Session)
+	jnprSess, err := sess.startNewSession()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer sess.closeSession(jnprSess)
+	resourceTypes := make([]string, 0)
+	for _, v := range d.Get("resource_types").([]interface{}) {
+		resourceTypes = append(resourceTypes, v.(string))
+	}
+	mutex.Lock()
+	objects, err := readConfigInventory(resourceTypes, m, jnprSess)
+	mutex.Unlock()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(resourceTypes) == 0 {
+		d.SetId("all")
+	} else {
+		d.SetId(strings.Join(resourceTypes, idSeparator))
+	}
+	if tfErr := d.Set("objects", objects); tfErr != nil {
+		panic(tfErr)
+	}
+
+	return nil
+}
+
+// readConfigInventory read the objects of resources in configuration of device
+// with the id in format of the resource importer (objects of all resources if resourceTypes is empty).
+func readConfigInventory(resourceTypes []string, m interface{},
+	jnprSess *NetconfObject) ([]map[string]interface{}, error) {
+	sess := m.(*Session)
+	showConfig, err := sess.command(showConfigurationWords+" "+displaySetWords, jnprSess)
+	if err != nil {
+		return nil, err
+	}
+	lines := make([]string, 0)
+	for _, item := range strings.Split(showConfig, "\n") {
+		if strings.Contains(item, "<configuration-output>") {
+			continue
+		}
+		if strings.Contains(item, "</configuration-output>") {
+			break
+		}
+		if !strings.HasPrefix(item, setLineStart) {
+			continue
+		}
+		lines = append(lines, item)
+	}
+	// lines are matched with all types, like the generator, to have the same objects
+	objects, _ := configObjectsFromLines(lines, configObjectTypes())
+	result := make([]map[string]interface{}, 0, len(objects))
+	for _, object := range objects {
+		if len(resourceTypes) > 0 && !stringInSlice(object.resource, resourceTypes) {
+			continue
+		}
+		result = append(result, map[string]interface{}{
+			"resource_type": object.resource,
+			"id":            object.id,
+		})
+	}
+
+	return result, nil
+}
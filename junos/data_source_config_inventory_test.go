@@ -0,0 +1,67 @@
+package junos_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceConfigInventory_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceConfigInventoryConfigCreate(),
+			},
+			{
+				Config: testAccDataSourceConfigInventoryConfigData(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.junos_config_inventory.testacc_inventory",
+						"id", "junos_policyoptions_prefix_list_-_junos_static_route"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.junos_config_inventory.testacc_inventory",
+						"objects.*", map[string]string{
+							"resource_type": "junos_static_route",
+							"id":            "192.0.2.0/25_-_testacc_inventory",
+						}),
+					resource.TestCheckTypeSetElemNestedAttrs("data.junos_config_inventory.testacc_inventory",
+						"objects.*", map[string]string{
+							"resource_type": "junos_policyoptions_prefix_list",
+							"id":            "testacc_inventory",
+						}),
+					resource.TestCheckTypeSetElemNestedAttrs("data.junos_config_inventory.testacc_inventory_all",
+						"objects.*", map[string]string{
+							"resource_type": "junos_routing_instance",
+							"id":            "testacc_inventory",
+						}),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceConfigInventoryConfigCreate() string {
+	return `
+resource junos_routing_instance testacc_inventory {
+  name = "testacc_inventory"
+}
+resource junos_static_route testacc_inventory {
+  destination      = "192.0.2.0/25"
+  routing_instance = junos_routing_instance.testacc_inventory.name
+  discard          = true
+}
+resource junos_policyoptions_prefix_list testacc_inventory {
+  name   = "testacc_inventory"
+  prefix = ["192.0.2.0/25"]
+}
+`
+}
+
+func testAccDataSourceConfigInventoryConfigData() string {
+	return testAccDataSourceConfigInventoryConfigCreate() + `
+data junos_config_inventory testacc_inventory {
+  resource_types = ["junos_policyoptions_prefix_list", "junos_static_route"]
+}
+data junos_config_inventory testacc_inventory_all {}
+`
+}
@@ -0,0 +1,140 @@
+package junos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// listOfRPCReadOnly : rpc accepted by junos_rpc data source in addition to the get-* rpc,
+// they don't change configuration or state of device.
+func listOfRPCReadOnly() []string {
+	return []string{
+		"file-checksum-md5",
+		"file-checksum-sha-256",
+		"file-checksum-sha1",
+		"file-list",
+		"file-show",
+	}
+}
+
+// rpcIsReadOnly check a rpc is a get-* rpc or in listOfRPCReadOnly.
+func rpcIsReadOnly(name string) bool {
+	return strings.HasPrefix(name, "get-") || stringInSlice(name, listOfRPCReadOnly())
+}
+
+func dataSourceRPC() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRPCRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"name", "xml"},
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]*$`),
+					"must be the name of a rpc like get-interface-information"),
+			},
+			"arguments": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				RequiredWith: []string{"name"},
+			},
+			"xml": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"name", "xml"},
+			},
+			"select": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"output_xml": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"output_json": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"selected": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceRPCRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	rpc := d.Get("xml").(string)
+	if rpc == "" {
+		arguments := make(map[string]string)
+		for k, v := range d.Get("arguments").(map[string]interface{}) {
+			arguments[k] = v.(string)
+		}
+		rpc = rpcXML(d.Get("name").(string), arguments)
+	}
+	nodes, err := parseXMLNodes(rpc)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("rpc invalid : %w", err))
+	}
+	if len(nodes) != 1 {
+		return diag.FromErr(fmt.Errorf("rpc need to have one root element, got %d", len(nodes)))
+	}
+	if nodes[0].name == "command" {
+		// any cli command (with configuration mode) can be run with this rpc
+		return diag.FromErr(errors.New("rpc command not allowed in junos_rpc data source, " +
+			"use the junos_command data source"))
+	}
+	if !rpcIsReadOnly(nodes[0].name) {
+		return diag.FromErr(fmt.Errorf("rpc %s not allowed in junos_rpc data source, "+
+			"only get-* and read-only file-* rpc are accepted", nodes[0].name))
+	}
+	sess := m.(*Session)
+	jnprSess, err := sess.startNewSession()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer sess.closeSession(jnprSess)
+	output, err := sess.commandXML(rpc, jnprSess)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(nodes[0].name)
+	if tfErr := d.Set("output_xml", output); tfErr != nil {
+		panic(tfErr)
+	}
+	outputNodes, err := parseXMLNodes(output)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("reply of rpc %s : %w", nodes[0].name, err))
+	}
+	outputJSON, err := xmlNodesToJSON(outputNodes)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if tfErr := d.Set("output_json", outputJSON); tfErr != nil {
+		panic(tfErr)
+	}
+	selected := make([]string, 0)
+	for _, v := range d.Get("select").([]interface{}) {
+		value, _, err := xmlSelect(outputNodes, v.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		selected = append(selected, value)
+	}
+	if tfErr := d.Set("selected", selected); tfErr != nil {
+		panic(tfErr)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package junos_test
+
+import (
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// export TESTACC_INTERFACE=<inteface> for choose interface available else it's ge-0/0/3.
+func TestAccDataSourceRPC_basic(t *testing.T) {
+	var testaccInterface string
+	if os.Getenv("TESTACC_INTERFACE") != "" {
+		testaccInterface = os.Getenv("TESTACC_INTERFACE")
+	} else {
+		testaccInterface = defaultInterfaceTestAcc
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceRPCConfig(testaccInterface),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.junos_rpc.testacc_rpc",
+						"id", "get-interface-information"),
+					resource.TestCheckResourceAttr("data.junos_rpc.testacc_rpc",
+						"selected.#", "2"),
+					resource.TestCheckResourceAttr("data.junos_rpc.testacc_rpc",
+						"selected.0", testaccInterface),
+					resource.TestCheckResourceAttr("data.junos_rpc.testacc_rpc",
+						"selected.1", ""),
+					resource.TestMatchResourceAttr("data.junos_rpc.testacc_rpc",
+						"output_json", regexp.MustCompile(`^\{"interface-information":`)),
+					resource.TestCheckResourceAttrSet("data.junos_rpc.testacc_rpc_xml",
+						"output_xml"),
+				),
+			},
+			{
+				Config: `
+data junos_rpc testacc_rpc {
+  name = "load-configuration"
+}
+`,
+				ExpectError: regexp.MustCompile("not allowed in junos_rpc data source"),
+			},
+			{
+				Config: `
+data junos_rpc testacc_rpc {
+  name = "clear-arp-table"
+}
+`,
+				ExpectError: regexp.MustCompile("rpc clear-arp-table not allowed in junos_rpc data source"),
+			},
+			{
+				Config: `
+data junos_rpc testacc_rpc {
+  xml = "<command>configure private</command>"
+}
+`,
+				ExpectError: regexp.MustCompile("rpc command not allowed in junos_rpc data source"),
+			},
+		},
+	})
+}
+
+func testAccDataSourceRPCConfig(interFace string) string {
+	return `
+data junos_rpc testacc_rpc {
+  name = "get-interface-information"
+  arguments = {
+    interface-name = "` + interFace + `"
+  }
+  select = [
+    "//physical-interface/name",
+    "//physical-interface/unknown-element",
+  ]
+}
+data junos_rpc testacc_rpc_xml {
+  xml = "<get-system-information/>"
+}
+`
+}
@@ -0,0 +1,1221 @@
+package junos
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	fakeDeviceUsername = "netconf"
+	fakeDevicePassword = "fake"
+	fakeDeviceEOM      = "]]>]]>"
+)
+
+// FakeDevice : in-process Junos device for tests, a ssh server with netconf subsystem
+// which keeps the candidate and committed configuration as set lines.
+// The set lines are stored as loaded (no validation, no replacement of a leaf value with a new value)
+// so the resources have to delete their configuration before set it again, like the provider does.
+type FakeDevice struct {
+	mutex     sync.Mutex
+	listener  net.Listener
+	sshConfig *ssh.ServerConfig
+	model     string
+	version   string
+	hostName  string
+	candidate []string
+	committed []string
+	loaded    []string
+	rescue    []string
+	rollbacks [][]string
+	commits   []FakeDeviceCommit
+	lockedBy  int
+	sessionID int
+}
+
+// FakeDeviceCommit : a commit received by FakeDevice.
+type FakeDeviceCommit struct {
+	Log  string
+	Time time.Time
+}
+
+var fakeDeviceEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;") // nolint: gochecknoglobals
+
+type fakeDeviceRPC struct {
+	MessageID string `xml:"message-id,attr"`
+	Inner     []byte `xml:",innerxml"`
+}
+
+// StartFakeDevice start a FakeDevice listening on a random port of localhost
+// with the hardware model and Junos version returned by get-system-information.
+func StartFakeDevice(model, version string) (*FakeDevice, error) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate host key : %w", err)
+	}
+	signer, err := ssh.NewSignerFromKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signer of host key : %w", err)
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen : %w", err)
+	}
+	fake := &FakeDevice{
+		listener: listener,
+		model:    model,
+		version:  version,
+		hostName: "fake",
+		// rollback 0 is the committed configuration
+		rollbacks: [][]string{nil},
+		sshConfig: &ssh.ServerConfig{
+			PasswordCallback: func(c ssh.ConnMetadata, pass []byte) (*ssh.Permissions, error) {
+				if c.User() == fakeDeviceUsername && string(pass) == fakeDevicePassword {
+					return &ssh.Permissions{}, nil
+				}
+
+				return nil, errors.New("bad credentials")
+			},
+		},
+	}
+	fake.sshConfig.AddHostKey(signer)
+	go fake.serve()
+
+	return fake, nil
+}
+
+// Host return the address of FakeDevice.
+func (f *FakeDevice) Host() string {
+	return f.listener.Addr().(*net.TCPAddr).IP.String()
+}
+
+// Port return the port of FakeDevice.
+func (f *FakeDevice) Port() int {
+	return f.listener.Addr().(*net.TCPAddr).Port
+}
+
+// Username return the username accepted by FakeDevice.
+func (f *FakeDevice) Username() string {
+	return fakeDeviceUsername
+}
+
+// Password return the password accepted by FakeDevice.
+func (f *FakeDevice) Password() string {
+	return fakeDevicePassword
+}
+
+// Close stop listening for new connections.
+func (f *FakeDevice) Close() error {
+	return f.listener.Close()
+}
+
+// Committed return a copy of committed configuration.
+func (f *FakeDevice) Committed() []string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return append([]string{}, f.committed...)
+}
+
+// SetCommitted replace the committed and candidate configuration.
+func (f *FakeDevice) SetCommitted(lines []string) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.committed = append([]string{}, lines...)
+	f.candidate = append([]string{}, lines...)
+	f.rollbacks = [][]string{append([]string{}, lines...)}
+}
+
+// Rescue return the set lines of rescue configuration (nil if not saved).
+func (f *FakeDevice) Rescue() []string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.rescue == nil {
+		return nil
+	}
+
+	return append([]string{}, f.rescue...)
+}
+
+// Loaded return the lines loaded in candidate configuration since the start.
+func (f *FakeDevice) Loaded() []string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return append([]string{}, f.loaded...)
+}
+
+// Commits return the commits received.
+func (f *FakeDevice) Commits() []FakeDeviceCommit {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return append([]FakeDeviceCommit{}, f.commits...)
+}
+
+func (f *FakeDevice) serve() {
+	for {
+		conn, err := f.listener.Accept()
+		if err != nil {
+			return
+		}
+		go f.serveConn(conn)
+	}
+}
+
+func (f *FakeDevice) serveConn(conn net.Conn) {
+	defer conn.Close()
+	_, chans, reqs, err := ssh.NewServerConn(conn, f.sshConfig)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "session" {
+			_ = newChannel.Reject(ssh.UnknownChannelType, "unknown channel type")
+
+			continue
+		}
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			continue
+		}
+		go func(channel ssh.Channel, requests <-chan *ssh.Request) {
+			for req := range requests {
+				if req.Type != "subsystem" || !bytes.HasSuffix(req.Payload, []byte("netconf")) {
+					_ = req.Reply(false, nil)
+
+					continue
+				}
+				_ = req.Reply(true, nil)
+				go f.serveNetconf(channel)
+			}
+		}(channel, requests)
+	}
+}
+
+// serveNetconf exchange hello messages and reply to each rpc.
+func (f *FakeDevice) serveNetconf(channel ssh.Channel) {
+	defer channel.Close()
+	f.mutex.Lock()
+	f.sessionID++
+	sessionID := f.sessionID
+	f.mutex.Unlock()
+	defer f.unlock(sessionID)
+	fmt.Fprintf(channel, "<hello xmlns=\"urn:ietf:params:xml:ns:netconf:base:1.0\"><capabilities>"+
+		"<capability>urn:ietf:params:netconf:base:1.0</capability></capabilities>"+
+		"<session-id>%d</session-id></hello>"+fakeDeviceEOM, sessionID)
+	if _, err := readFakeDeviceMessage(channel); err != nil {
+		return
+	}
+	for {
+		message, err := readFakeDeviceMessage(channel)
+		if err != nil {
+			return
+		}
+		var rpc fakeDeviceRPC
+		if err := xml.Unmarshal(message, &rpc); err != nil {
+			return
+		}
+		reply, closeSession := f.reply(sessionID, rpc.Inner)
+		fmt.Fprintf(channel, "<rpc-reply message-id=\"%s\">%s</rpc-reply>"+fakeDeviceEOM, rpc.MessageID, reply)
+		if closeSession {
+			return
+		}
+	}
+}
+
+func readFakeDeviceMessage(r io.Reader) ([]byte, error) {
+	var message bytes.Buffer
+	b := make([]byte, 1)
+	for {
+		if _, err := r.Read(b); err != nil {
+			return nil, err
+		}
+		message.WriteByte(b[0])
+		if bytes.HasSuffix(message.Bytes(), []byte(fakeDeviceEOM)) {
+			return bytes.TrimSuffix(message.Bytes(), []byte(fakeDeviceEOM)), nil
+		}
+	}
+}
+
+func fakeDeviceError(message string) string {
+	return "<rpc-error><error-severity>error</error-severity><error-message>" +
+		html.EscapeString(message) + "</error-message></rpc-error>"
+}
+
+// reply generate the reply of rpc and if the session need to be closed.
+func (f *FakeDevice) reply(sessionID int, inner []byte) (string, bool) {
+	decoder := xml.NewDecoder(bytes.NewReader(inner))
+	var start xml.StartElement
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return fakeDeviceError("empty rpc"), false
+		}
+		if s, ok := token.(xml.StartElement); ok {
+			start = s
+
+			break
+		}
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	switch start.Name.Local {
+	case "get-system-information":
+		return fmt.Sprintf("<system-information><hardware-model>%s</hardware-model><os-name>junos</os-name>"+
+			"<os-version>%s</os-version><serial-number>FAKE0001</serial-number><host-name>%s</host-name>"+
+			"</system-information>",
+			html.EscapeString(f.model), html.EscapeString(f.version), html.EscapeString(f.hostName)), false
+	case "command":
+		var cmd string
+		if err := decoder.DecodeElement(&cmd, &start); err != nil {
+			return fakeDeviceError(err.Error()), false
+		}
+		cmd = strings.TrimSpace(cmd)
+		for _, attr := range start.Attr {
+			if attr.Name.Local == "format" && attr.Value != "text" {
+				return f.commandFormat(cmd, attr.Value), false
+			}
+		}
+		output, err := f.command(cmd)
+		if err != nil {
+			return fakeDeviceError(err.Error()), false
+		}
+		if output == "" {
+			return "\n", false
+		}
+		if strings.HasPrefix(cmd, showConfigurationWords) {
+			return "<configuration-information><configuration-output>" + fakeDeviceEscaper.Replace(output) +
+				"</configuration-output></configuration-information>", false
+		}
+
+		return "<output>" + fakeDeviceEscaper.Replace(output) + "</output>", false
+	case "get-interface-information":
+		var info struct {
+			Name string `xml:"interface-name"`
+		}
+		if err := decoder.DecodeElement(&info, &start); err != nil {
+			return fakeDeviceError(err.Error()), false
+		}
+		if !f.interfaceExists(strings.TrimSpace(info.Name)) {
+			return "<output>\nerror: device " + html.EscapeString(info.Name) + " not found\n</output>", false
+		}
+
+		return "<interface-information><physical-interface><name>" + html.EscapeString(info.Name) +
+			"</name></physical-interface></interface-information>", false
+	case "lock":
+		if f.lockedBy != 0 && f.lockedBy != sessionID {
+			return fakeDeviceError(fmt.Sprintf("configuration database locked by session %d", f.lockedBy)), false
+		}
+		f.lockedBy = sessionID
+
+		return "<ok/>", false
+	case "unlock":
+		if f.lockedBy != sessionID {
+			return fakeDeviceError("configuration database not locked by this session"), false
+		}
+		f.lockedBy = 0
+
+		return "<ok/>", false
+	case "load-configuration":
+		var load struct {
+			Rollback string `xml:"rollback,attr"`
+			Set      string `xml:"configuration-set"`
+		}
+		if err := decoder.DecodeElement(&load, &start); err != nil {
+			return fakeDeviceError(err.Error()), false
+		}
+		if f.lockedBy != 0 && f.lockedBy != sessionID {
+			return fakeDeviceError("configuration database locked by another session"), false
+		}
+		if load.Rollback != "" {
+			rollback, err := f.rollback(load.Rollback)
+			if err != nil {
+				return fakeDeviceError(err.Error()), false
+			}
+			f.candidate = rollback
+
+			return "<load-configuration-results><ok/></load-configuration-results>", false
+		}
+		for _, line := range strings.Split(load.Set, "\n") {
+			if strings.TrimSpace(line) != "" {
+				f.loaded = append(f.loaded, strings.TrimSpace(line))
+			}
+			if err := f.load(strings.TrimSpace(line)); err != nil {
+				return fakeDeviceError(err.Error()), false
+			}
+		}
+
+		return "<load-configuration-results><ok/></load-configuration-results>", false
+	case "commit-configuration":
+		var commit struct {
+			Log string `xml:"log"`
+		}
+		if err := decoder.DecodeElement(&commit, &start); err != nil {
+			return fakeDeviceError(err.Error()), false
+		}
+		f.committed = append([]string{}, f.candidate...)
+		f.rollbacks = append([][]string{append([]string{}, f.committed...)}, f.rollbacks...)
+		f.commits = append(f.commits, FakeDeviceCommit{Log: commit.Log, Time: time.Now()})
+
+		return "<commit-results></commit-results>", false
+	case "get-commit-information":
+		var output strings.Builder
+		output.WriteString("<commit-information>")
+		for i := len(f.commits) - 1; i >= 0; i-- {
+			output.WriteString(fmt.Sprintf("<commit-history><sequence-number>%d</sequence-number>"+
+				"<user>%s</user><client>netconf</client><date-time>%s</date-time><log>%s</log></commit-history>",
+				len(f.commits)-1-i, fakeDeviceUsername, f.commits[i].Time.UTC().Format("2006-01-02 15:04:05 MST"),
+				html.EscapeString(f.commits[i].Log)))
+		}
+		output.WriteString("</commit-information>")
+
+		return output.String(), false
+	case "get-rollback-information":
+		var info struct {
+			Rollback string `xml:"rollback"`
+			Compare  string `xml:"compare"`
+		}
+		if err := decoder.DecodeElement(&info, &start); err != nil {
+			return fakeDeviceError(err.Error()), false
+		}
+		rollback, err := f.rollback(info.Rollback)
+		if err != nil {
+			return fakeDeviceError(err.Error()), false
+		}
+		compare, err := f.rollback(info.Compare)
+		if err != nil {
+			return fakeDeviceError(err.Error()), false
+		}
+		diff := make([]string, 0)
+		for _, line := range compare {
+			if !stringInSlice(line, rollback) {
+				diff = append(diff, "- "+line)
+			}
+		}
+		for _, line := range rollback {
+			if !stringInSlice(line, compare) {
+				diff = append(diff, "+ "+line)
+			}
+		}
+
+		return "<rollback-information><configuration-information><configuration-output>" +
+			fakeDeviceEscaper.Replace(strings.Join(diff, "\n")) +
+			"</configuration-output></configuration-information></rollback-information>", false
+	case "delete-config":
+		f.candidate = append([]string{}, f.committed...)
+
+		return "<ok/>", false
+	case "close-session":
+		return "<ok/>", true
+	default:
+		return fakeDeviceError(fmt.Sprintf("syntax error, expecting <rpc> : %s", start.Name.Local)), false
+	}
+}
+
+// rollback return a copy of a previous committed configuration (0 is the current).
+func (f *FakeDevice) rollback(index string) ([]string, error) {
+	rollback, err := strconv.Atoi(index)
+	if err != nil {
+		return nil, fmt.Errorf("rollback %s invalid : %w", index, err)
+	}
+	if rollback < 0 || rollback >= len(f.rollbacks) {
+		return nil, fmt.Errorf("rollback %d not found", rollback)
+	}
+
+	return append([]string{}, f.rollbacks[rollback]...), nil
+}
+
+func (f *FakeDevice) unlock(sessionID int) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.lockedBy == sessionID {
+		f.lockedBy = 0
+		f.candidate = append([]string{}, f.committed...)
+	}
+}
+
+// command reply to 'show configuration ... | display set', 'show interfaces [...] terse'
+// and 'request system configuration rescue save|delete', 'show system configuration rescue | display set'.
+func (f *FakeDevice) command(cmd string) (string, error) {
+	switch cmd {
+	case "request system configuration rescue save":
+		f.rescue = append([]string{}, f.committed...)
+
+		return "", nil
+	case "request system configuration rescue delete":
+		f.rescue = nil
+
+		return "", nil
+	case "show system configuration rescue | display set":
+		filter, err := parseShowConfigurationCommand(showConfigurationWords + " " + displaySetWords)
+		if err != nil {
+			return "", err
+		}
+		if output := filter.apply(fakeDeviceDisplayOrder(f.rescue)); output != emptyWord {
+			return output, nil
+		}
+
+		return "", nil
+	}
+	if strings.HasPrefix(cmd, "show interfaces") && strings.HasSuffix(cmd, "terse") {
+		return f.showInterfacesTerse(strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(cmd, "show interfaces"),
+			"terse"))), nil
+	}
+	filter, err := parseShowConfigurationCommand(cmd)
+	if err != nil {
+		return "", fmt.Errorf("syntax error : %w", err)
+	}
+	filter.hierarchy = fakeDeviceExpandUnit(append([]string{""}, filter.hierarchy...))[1:]
+	output := filter.apply(fakeDeviceDisplayOrder(f.committed))
+	if output == emptyWord {
+		return "", nil
+	}
+	// like Junos, values of the apply-groups leaf-list end with a space in relative mode
+	if filter.relative && len(filter.hierarchy) == 1 && filter.hierarchy[0] == "apply-groups" {
+		output = strings.ReplaceAll(output, "\n", " \n")[1:]
+	}
+
+	return output, nil
+}
+
+// commandFormat reply to a command with xml or json format, only 'show interfaces terse' is supported.
+func (f *FakeDevice) commandFormat(cmd, format string) string {
+	if !strings.HasPrefix(cmd, "show interfaces") || !strings.HasSuffix(cmd, "terse") {
+		return fakeDeviceError(fmt.Sprintf("format %s not supported for command '%s'", format, cmd))
+	}
+	interfaces := f.terseInterfaces(strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(cmd, "show interfaces"),
+		"terse")))
+	switch format {
+	case "json":
+		physical := make([]string, 0, len(interfaces))
+		for _, inter := range interfaces {
+			physical = append(physical, `{"name":[{"data":"`+inter+`"}],"oper-status":[{"data":"up"}]}`)
+		}
+
+		return `{"interface-information":[{"physical-interface":[` + strings.Join(physical, ",") + `]}]}`
+	case "xml":
+		var output strings.Builder
+		output.WriteString("<interface-information>")
+		for _, inter := range interfaces {
+			output.WriteString("<physical-interface><name>" + html.EscapeString(inter) + "</name>" +
+				"<oper-status>up</oper-status></physical-interface>")
+		}
+		output.WriteString("</interface-information>")
+
+		return output.String()
+	default:
+		return fakeDeviceError(fmt.Sprintf("format %s unknown", format))
+	}
+}
+
+// fakeDeviceNode : a word of configuration with its children in order of insertion.
+type fakeDeviceNode struct {
+	word     string
+	line     string
+	children []*fakeDeviceNode
+}
+
+func (n *fakeDeviceNode) child(word string) *fakeDeviceNode {
+	for _, c := range n.children {
+		if c.word == word {
+			return c
+		}
+	}
+	c := &fakeDeviceNode{word: word}
+	if word == "description" {
+		// like the schema of most hierarchies, description is displayed first
+		n.children = append([]*fakeDeviceNode{c}, n.children...)
+	} else {
+		n.children = append(n.children, c)
+	}
+
+	return c
+}
+
+func (n *fakeDeviceNode) walk(lines *[]string) {
+	if n.line != "" && len(n.children) == 0 {
+		*lines = append(*lines, n.line)
+	}
+	for _, c := range n.children {
+		c.walk(lines)
+	}
+}
+
+// fakeDeviceDisplayOrder order the set lines like the device displays them,
+// the lines of the same node together, without the lines which are the beginning of another line
+// (then the other lines like deactivate).
+func fakeDeviceDisplayOrder(lines []string) []string {
+	root := &fakeDeviceNode{}
+	others := make([]string, 0)
+	for _, line := range lines {
+		words := splitSetLineWords(line)
+		if words[0] != "set" {
+			others = append(others, line)
+
+			continue
+		}
+		node := root
+		for _, word := range words[1:] {
+			node = node.child(word)
+		}
+		node.line = line
+	}
+	result := make([]string, 0, len(lines))
+	root.walk(&result)
+
+	return append(result, others...)
+}
+
+// interfaceExists return if interface is a physical interface of device (ge-0/0/x, xe-0/0/x, et-0/0/x, ae)
+// or a logical interface with a unit in configuration.
+func (f *FakeDevice) interfaceExists(name string) bool {
+	if !strings.Contains(name, ".") {
+		for _, prefix := range []string{"ge-0/0/", "xe-0/0/", "et-0/0/", "ae", "st0", "lo0", "irb", "fxp0"} {
+			if strings.HasPrefix(name, prefix) {
+				return true
+			}
+		}
+	}
+	nameSplit := strings.Split(name, ".")
+	for _, line := range f.committed {
+		if strings.HasPrefix(line, "set interfaces "+nameSplit[0]+" unit "+strings.Join(nameSplit[1:], ".")+" ") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// showInterfacesTerse list the interfaces and units configured.
+func (f *FakeDevice) showInterfacesTerse(prefix string) string {
+	output := []string{"Interface               Admin Link Proto    Local                 Remote"}
+	for _, inter := range f.terseInterfaces(prefix) {
+		output = append(output, fmt.Sprintf("%-23s up    up", inter))
+	}
+
+	return "\n" + strings.Join(output, "\n") + "\n"
+}
+
+// terseInterfaces list the interfaces in configuration (all or only prefix and its units).
+func (f *FakeDevice) terseInterfaces(prefix string) []string {
+	interfaces := make([]string, 0)
+	for _, line := range f.committed {
+		words := strings.Fields(line)
+		if len(words) < 3 || words[0] != "set" || words[1] != "interfaces" {
+			continue
+		}
+		if !stringInSlice(words[2], interfaces) {
+			interfaces = append(interfaces, words[2])
+		}
+		if len(words) >= 5 && words[3] == "unit" && !stringInSlice(words[2]+"."+words[4], interfaces) {
+			interfaces = append(interfaces, words[2]+"."+words[4])
+		}
+	}
+	sort.Strings(interfaces)
+	result := make([]string, 0, len(interfaces))
+	for _, inter := range interfaces {
+		if prefix == "" || inter == prefix || strings.HasPrefix(inter, prefix+".") {
+			result = append(result, inter)
+		}
+	}
+
+	return result
+}
+
+// load apply a set/delete/activate/deactivate line on candidate configuration.
+func (f *FakeDevice) load(line string) error {
+	if line == "" {
+		return nil
+	}
+	words := fakeDeviceExpandUnit(splitSetLineWords(line))
+	if len(words) < 2 {
+		return fmt.Errorf("syntax error : %s", line)
+	}
+	target := strings.Join(words[1:], " ")
+	switch words[0] {
+	case "set":
+		// flag followed by another statement in the same line
+		if len(words) > 3 && words[len(words)-3] == "disable" {
+			prefix := strings.Join(words[:len(words)-3], " ")
+			if err := f.load(prefix + " disable"); err != nil {
+				return err
+			}
+
+			return f.load(prefix + " " + strings.Join(words[len(words)-2:], " "))
+		}
+		targetWords := fakeDeviceNormalizeQuotes(fakeDeviceEncryptSecrets(words[1:]))
+		target = strings.Join(targetWords, " ")
+		if fakeDeviceSingleValue(targetWords) {
+			f.candidate = fakeDeviceRemoveValue(f.candidate, targetWords)
+		}
+		if !stringInSlice("set "+target, f.candidate) {
+			f.candidate = append(f.candidate, "set "+target)
+		}
+	case "delete":
+		f.candidate = fakeDeviceRemoveUnder(f.candidate, words[1:], "")
+	case "deactivate":
+		if !stringInSlice("deactivate "+target, f.candidate) {
+			f.candidate = append(f.candidate, "deactivate "+target)
+		}
+	case "activate":
+		f.candidate = fakeDeviceRemoveUnder(f.candidate, words[1:], "deactivate")
+	case "protect":
+		if !stringInSlice("protect "+target, f.candidate) {
+			f.candidate = append(f.candidate, "protect "+target)
+		}
+	case "unprotect":
+		f.candidate = fakeDeviceRemoveUnder(f.candidate, words[1:], "protect")
+	case "insert":
+		return f.insert(words)
+	default:
+		return fmt.Errorf("syntax error, expecting command : %s", words[0])
+	}
+
+	return nil
+}
+
+// insert move the lines of an entry of list before or after another entry
+// (insert <hierarchy> <entry> before|after <keyword> <reference>).
+func (f *FakeDevice) insert(words []string) error {
+	n := len(words)
+	if n < 6 || (words[n-3] != "before" && words[n-3] != "after") || words[n-2] != words[n-5] {
+		return fmt.Errorf("syntax error : %s", strings.Join(words, " "))
+	}
+	entry := words[1 : n-3]
+	reference := append(append([]string{}, entry[:len(entry)-1]...), words[n-1])
+	moved := make([]string, 0)
+	others := make([]string, 0, len(f.candidate))
+	for _, line := range f.candidate {
+		if fakeDeviceLineUnder(line, entry) {
+			moved = append(moved, line)
+		} else {
+			others = append(others, line)
+		}
+	}
+	if len(moved) == 0 {
+		return fmt.Errorf("statement not found : %s", strings.Join(entry, " "))
+	}
+	index := -1
+	for i, line := range others {
+		if fakeDeviceLineUnder(line, reference) {
+			if words[n-3] == "after" || index == -1 {
+				index = i
+			}
+		}
+	}
+	if index == -1 {
+		return fmt.Errorf("statement not found : %s", strings.Join(reference, " "))
+	}
+	if words[n-3] == "after" {
+		index++
+	}
+	f.candidate = append(append(append(make([]string, 0, len(f.candidate)), others[:index]...), moved...),
+		others[index:]...)
+
+	return nil
+}
+
+// fakeDeviceExpandUnit expand the short name of logical interface after the first word 'interfaces'
+// (interfaces ge-0/0/3.100 => interfaces ge-0/0/3 unit 100) like the device.
+func fakeDeviceExpandUnit(words []string) []string {
+	if len(words) < 3 || words[1] != "interfaces" || !strings.Contains(words[2], ".") {
+		return words
+	}
+	nameSplit := strings.SplitN(words[2], ".", 2)
+	result := append([]string{}, words[:2]...)
+	result = append(result, nameSplit[0], "unit", nameSplit[1])
+
+	return append(result, words[3:]...)
+}
+
+// fakeDeviceNormalizeQuotes remove the double quotes around words without special characters like the device.
+func fakeDeviceNormalizeQuotes(words []string) []string {
+	result := make([]string, len(words))
+	for i, word := range words {
+		result[i] = word
+		value := strings.TrimSuffix(strings.TrimPrefix(word, "\""), "\"")
+		if len(word) < 2 || !strings.HasPrefix(word, "\"") || value == "" {
+			continue
+		}
+		special := false
+		for _, c := range value {
+			if !(c >= 'a' && c <= 'z') && !(c >= 'A' && c <= 'Z') && !(c >= '0' && c <= '9') &&
+				!strings.ContainsRune("-_./:@+", c) {
+				special = true
+
+				break
+			}
+		}
+		if !special {
+			result[i] = value
+		}
+	}
+
+	return result
+}
+
+// fakeDeviceEncryptSecrets encrypt the values of secret statements with $9$ like the device.
+func fakeDeviceEncryptSecrets(words []string) []string {
+	result := append([]string{}, words...)
+	for i := 0; i < len(result)-1; i++ {
+		secret := false
+		switch result[i] {
+		case "authentication-key":
+			// 'system ntp authentication-key' is followed by the id of key
+			secret = i == 0 || result[i-1] != "ntp"
+		case "secret", "preauthentication-secret", "client-secret", "url-parameter":
+			secret = true
+		case "value":
+			secret = i >= 4 && result[i-4] == "authentication-key"
+		case "password":
+			// 'system login password' is a block of options
+			secret = i == 0 || result[i-1] != "login"
+		case "ascii-text", "hexadecimal":
+			secret = i > 0 && result[i-1] == "pre-shared-key"
+		}
+		value := strings.Trim(result[i+1], "\"")
+		if secret && !strings.HasPrefix(value, "$9$") {
+			result[i+1] = "\"" + jencodeSecret(value) + "\""
+			i++
+		}
+	}
+
+	return result
+}
+
+// fakeDeviceSingleValueLeaves : statements with a single value, a new value replace the old one like the device
+// (the fake device doesn't have the configuration schema, the other statements can have several values).
+func fakeDeviceSingleValueLeaves() []string {
+	return []string{
+		"authentication-key", "description", "domain-name", "encrypted-password", "full-name",
+		"hold-time", "host-name", "inactivity-timeout", "instance-type", "local-preference", "log-prefix",
+		"metric", "mtu", "native-vlan-id", "peer-as", "preference", "priority", "retry",
+		"route-distinguisher", "secret", "time-zone", "timeout", "uid", "vlan-id",
+	}
+}
+
+// fakeDeviceSingleValue check if the last word of set line (without first word) is the value of
+// a single value statement.
+func fakeDeviceSingleValue(words []string) bool {
+	return len(words) >= 2 && stringInSlice(words[len(words)-2], fakeDeviceSingleValueLeaves())
+}
+
+// fakeDeviceRemoveValue remove the set lines with the same statement as words but another value.
+func fakeDeviceRemoveValue(lines, words []string) []string {
+	result := make([]string, 0, len(lines))
+	for _, line := range lines {
+		lineWords := splitSetLineWords(line)
+		if len(lineWords) == len(words)+1 && lineWords[0] == "set" &&
+			fakeDeviceLineUnder(line, words[:len(words)-1]) {
+			continue
+		}
+		result = append(result, line)
+	}
+
+	return result
+}
+
+// fakeDeviceRemoveUnder remove the lines under hierarchy (only the lines beginning with firstWord if not empty).
+func fakeDeviceRemoveUnder(lines, hierarchy []string, firstWord string) []string {
+	result := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if firstWord != "" && !strings.HasPrefix(line, firstWord+" ") {
+			result = append(result, line)
+
+			continue
+		}
+		if !fakeDeviceLineUnder(line, hierarchy) {
+			result = append(result, line)
+		}
+	}
+
+	return result
+}
+
+// fakeDeviceLineUnder check if the line (after its first word) is in hierarchy.
+func fakeDeviceLineUnder(line string, hierarchy []string) bool {
+	words := splitSetLineWords(line)
+	if len(words) <= len(hierarchy) {
+		return false
+	}
+	for i, word := range hierarchy {
+		if strings.Trim(words[i+1], "\"") != strings.Trim(word, "\"") {
+			return false
+		}
+	}
+
+	return true
+}
+
+// updateFakeDeviceResource apply an update of resource with a new configuration like Terraform
+// (diff between state of d and config) and return the new resource data.
+func updateFakeDeviceResource(t *testing.T, r *schema.Resource, d *schema.ResourceData,
+	config map[string]interface{}, sess *Session) *schema.ResourceData {
+	t.Helper()
+	ctx := context.Background()
+	state := d.State()
+	diff, err := r.SimpleDiff(ctx, state, terraform.NewResourceConfigRaw(config), sess)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dUpdate, err := schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diags := r.UpdateContext(ctx, dUpdate, sess); diags.HasError() {
+		t.Fatalf("update error = %v", diags)
+	}
+
+	return dUpdate
+}
+
+// newFakeDeviceSession prepare a Session of provider to connect to FakeDevice.
+func newFakeDeviceSession(t *testing.T, fake *FakeDevice) *Session {
+	t.Helper()
+	c := configProvider{
+		junosIP:             fake.Host(),
+		junosPort:           fake.Port(),
+		junosUserName:       fake.Username(),
+		junosPassword:       fake.Password(),
+		junosTransport:      transportNetconf,
+		junosFilePermission: "0644",
+	}
+	sess, diags := c.prepareSession()
+	if diags.HasError() {
+		t.Fatalf("prepareSession() error = %v", diags)
+	}
+
+	return sess
+}
+
+func TestFakeDevice(t *testing.T) {
+	fake, err := StartFakeDevice("vsrx", "20.2R1.10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fake.Close()
+	sess := newFakeDeviceSession(t, fake)
+
+	jnprSess, err := sess.startNewSession()
+	if err != nil {
+		t.Fatalf("startNewSession() error = %v", err)
+	}
+	if jnprSess.SystemInformation.HardwareModel != "vsrx" || jnprSess.SystemInformation.OsVersion != "20.2R1.10" {
+		t.Errorf("startNewSession() SystemInformation = %+v", jnprSess.SystemInformation)
+	}
+	if out, err := sess.command("show configuration routing-options | display set", jnprSess); err != nil ||
+		out != emptyWord {
+		t.Errorf("command() on empty configuration = %q, %v", out, err)
+	}
+	jnprSess2, err := sess.startNewSession()
+	if err != nil {
+		t.Fatalf("startNewSession() error = %v", err)
+	}
+	if !jnprSess.netconfConfigLock() {
+		t.Fatal("netconfConfigLock() = false")
+	}
+	if jnprSess2.netconfConfigLock() {
+		t.Error("netconfConfigLock() on second session = true, want false")
+	}
+	if err := sess.configSet([]string{
+		"set interfaces st0 unit 1 family inet",
+		"set routing-options static route 192.0.2.0/24 next-hop st0.1",
+		"set routing-options static route 192.0.2.0/24 preference 100",
+		"set routing-options static route 198.51.100.0/24 discard",
+		"delete routing-options static route 192.0.2.0/24 preference",
+		"deactivate routing-options static route 198.51.100.0/24",
+		"set system host-name vsrx1",
+		"set system host-name vsrx2",
+		"set system location building \"A&B <1>\"",
+	}, jnprSess); err != nil {
+		t.Fatalf("configSet() error = %v", err)
+	}
+	if _, err := jnprSess.netconfCommit("fake commit"); err != nil {
+		t.Fatalf("netconfCommit() error = %v", err)
+	}
+	_ = jnprSess.netconfConfigUnlock()
+	out, err := sess.command("show configuration routing-options static route 192.0.2.0/24 | display set relative",
+		jnprSess2)
+	if err != nil {
+		t.Fatalf("command() error = %v", err)
+	}
+	if out != "<configuration-output>\nset next-hop st0.1\n</configuration-output>" {
+		t.Errorf("command() relative = %q", out)
+	}
+	out, err = sess.command("show configuration routing-options | display set", jnprSess2)
+	if err != nil {
+		t.Fatalf("command() error = %v", err)
+	}
+	if !strings.Contains(out, "\ndeactivate routing-options static route 198.51.100.0/24\n") {
+		t.Errorf("command() = %q", out)
+	}
+	if out, err := sess.command("show configuration system | display set", jnprSess2); err != nil ||
+		out != "<configuration-output>\nset system host-name vsrx2\nset system location building \"A&B <1>\"\n"+
+			"</configuration-output>" {
+		t.Errorf("command() after set of a new value = %q, %v", out, err)
+	}
+	if out, err := sess.command("show interfaces st0 terse", jnprSess2); err != nil ||
+		!strings.Contains(out, "\nst0.1 ") {
+		t.Errorf("command() show interfaces = %q, %v", out, err)
+	}
+	if commits := fake.Commits(); len(commits) != 1 || commits[0].Log != "fake commit" {
+		t.Errorf("Commits() = %v", commits)
+	}
+	sess.closeSession(jnprSess)
+	sess.closeSession(jnprSess2)
+}
+
+func TestFakeDeviceResource(t *testing.T) {
+	fake, err := StartFakeDevice("vsrx", "20.2R1.10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fake.Close()
+	sess := newFakeDeviceSession(t, fake)
+	ctx := context.Background()
+
+	d := schema.TestResourceDataRaw(t, resourceStaticRoute().Schema, map[string]interface{}{
+		"destination": "192.0.2.0/24",
+		"next_hop":    []interface{}{"192.0.2.254"},
+		"preference":  100,
+	})
+	if diags := resourceStaticRouteCreate(ctx, d, sess); diags.HasError() {
+		t.Fatalf("resourceStaticRouteCreate() error = %v", diags)
+	}
+	if !stringInSlice("set routing-options static route 192.0.2.0/24 next-hop 192.0.2.254", fake.Committed()) {
+		t.Errorf("configuration after create = %v", fake.Committed())
+	}
+	imported, err := resourceStaticRouteImport(
+		schema.TestResourceDataRaw(t, resourceStaticRoute().Schema, map[string]interface{}{}), sess)
+	if err == nil || len(imported) != 0 {
+		t.Errorf("resourceStaticRouteImport() with bad id = %v, %v", imported, err)
+	}
+	dImport := resourceStaticRoute().Data(nil)
+	dImport.SetId(d.Id())
+	imported, err = resourceStaticRouteImport(dImport, sess)
+	if err != nil {
+		t.Fatalf("resourceStaticRouteImport() error = %v", err)
+	}
+	if imported[0].Get("preference").(int) != 100 || imported[0].Get("next_hop.0").(string) != "192.0.2.254" {
+		t.Errorf("resourceStaticRouteImport() preference = %v, next_hop = %v",
+			imported[0].Get("preference"), imported[0].Get("next_hop"))
+	}
+	if diags := resourceStaticRouteDelete(ctx, d, sess); diags.HasError() {
+		t.Fatalf("resourceStaticRouteDelete() error = %v", diags)
+	}
+	if len(fake.Committed()) != 0 {
+		t.Errorf("configuration after delete = %v", fake.Committed())
+	}
+}
+
+func TestFakeDeviceCommitLogSuffix(t *testing.T) {
+	fake, err := StartFakeDevice("vsrx", "20.2R1.10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fake.Close()
+	sess := newFakeDeviceSession(t, fake)
+	sess.junosCommitLogSuffix = "[run 42]"
+	ctx := context.Background()
+
+	d := schema.TestResourceDataRaw(t, resourceStaticRoute().Schema, map[string]interface{}{
+		"destination": "192.0.2.0/24",
+		"next_hop":    []interface{}{"192.0.2.254"},
+	})
+	if diags := resourceStaticRouteCreate(ctx, d, sess); diags.HasError() {
+		t.Fatalf("resourceStaticRouteCreate() error = %v", diags)
+	}
+	if commits := fake.Commits(); len(commits) != 1 ||
+		commits[0].Log != "create resource junos_static_route [run 42]" {
+		t.Errorf("Commits() = %v", commits)
+	}
+	sess.junosCommitLogSuffix = "[run 43]"
+	if diags := resourceStaticRouteDelete(ctx, d, sess); diags.HasError() {
+		t.Fatalf("resourceStaticRouteDelete() error = %v", diags)
+	}
+
+	r := Provider().ResourcesMap["junos_rollback"]
+	dRollback := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"log_match":    `\[run 42\]$`,
+		"compare_only": true,
+	})
+	if diags := r.CreateContext(ctx, dRollback, sess); diags.HasError() {
+		t.Fatalf("create of junos_rollback error = %v", diags)
+	}
+	if index := dRollback.Get("rollback_index").(int); index != 1 {
+		t.Errorf("rollback_index = %d, want the commit of run 42", index)
+	}
+}
+
+func TestFakeDeviceRescueConfigurationDrift(t *testing.T) {
+	fake, err := StartFakeDevice("vsrx", "20.2R1.10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fake.Close()
+	sess := newFakeDeviceSession(t, fake)
+	ctx := context.Background()
+	r := Provider().ResourcesMap["junos_system_rescue_configuration"]
+	fake.SetCommitted([]string{"set system host-name vsrx1"})
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	if diags := r.CreateContext(ctx, d, sess); diags.HasError() {
+		t.Fatalf("create error = %v", diags)
+	}
+	fake.SetCommitted([]string{"set system host-name vsrx2"})
+	dRead := r.Data(d.State())
+	if diags := r.ReadContext(ctx, dRead, sess); diags.HasError() {
+		t.Fatalf("read error = %v", diags)
+	}
+	if dRead.Id() == "" || dRead.Get("hash").(string) == dRead.Get("active_hash").(string) {
+		t.Fatalf("read after drift: id = %q, hash = %q, active_hash = %q",
+			dRead.Id(), dRead.Get("hash"), dRead.Get("active_hash"))
+	}
+	state := dRead.State()
+	diff, err := r.Diff(ctx, state, terraform.NewResourceConfigRaw(map[string]interface{}{}), sess)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff == nil || diff.Attributes["hash"] == nil || !diff.Attributes["hash"].NewComputed {
+		t.Fatalf("diff after drift = %v, want hash computed", diff)
+	}
+	dUpdate, err := schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diags := r.UpdateContext(ctx, dUpdate, sess); diags.HasError() {
+		t.Fatalf("update error = %v", diags)
+	}
+	if rescue := fake.Rescue(); len(rescue) != 1 || rescue[0] != "set system host-name vsrx2" {
+		t.Errorf("rescue after update = %q, want saved again", rescue)
+	}
+	if dUpdate.Get("hash").(string) != dUpdate.Get("active_hash").(string) {
+		t.Errorf("hash after update = %q, want active_hash %q", dUpdate.Get("hash"), dUpdate.Get("active_hash"))
+	}
+}
+
+// TestFakeDeviceResourcesLifecycle run create, read, plan, update, import and delete of resources
+// against the fake device like Terraform, without TF_ACC and terraform binary.
+func TestFakeDeviceResourcesLifecycle(t *testing.T) {
+	fake, err := StartFakeDevice("vsrx", "20.2R1.10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fake.Close()
+	sess := newFakeDeviceSession(t, fake)
+	ctx := context.Background()
+	provider := Provider()
+
+	cases := []struct {
+		resource string
+		create   map[string]interface{}
+		update   map[string]interface{}
+	}{
+		{
+			resource: "junos_application",
+			create:   map[string]interface{}{"name": "app1", "protocol": "tcp", "destination_port": "8080"},
+			update:   map[string]interface{}{"name": "app1", "protocol": "udp", "destination_port": "8081"},
+		},
+		{
+			resource: "junos_policyoptions_as_path",
+			create:   map[string]interface{}{"name": "path1", "path": "5|12|18"},
+			update:   map[string]interface{}{"name": "path1", "path": "5|12"},
+		},
+		{
+			resource: "junos_policyoptions_prefix_list",
+			create:   map[string]interface{}{"name": "list1", "prefix": []interface{}{"192.0.2.0/25"}},
+			update: map[string]interface{}{
+				"name": "list1", "prefix": []interface{}{"192.0.2.0/25", "192.0.2.128/25"},
+			},
+		},
+		{
+			resource: "junos_routing_instance",
+			create:   map[string]interface{}{"name": "instance1"},
+			update:   map[string]interface{}{"name": "instance1", "as": "65000"},
+		},
+		{
+			resource: "junos_snmp_community",
+			create:   map[string]interface{}{"name": "public1", "authorization_read_only": true},
+			update: map[string]interface{}{
+				"name": "public1", "authorization_read_write": true, "clients": []interface{}{"192.0.2.0/24"},
+			},
+		},
+		{
+			resource: "junos_static_route",
+			create: map[string]interface{}{
+				"destination": "192.0.2.0/24", "next_hop": []interface{}{"192.0.2.254"}, "preference": 100,
+			},
+			update: map[string]interface{}{
+				"destination": "192.0.2.0/24", "next_hop": []interface{}{"192.0.2.253"}, "preference": 101,
+			},
+		},
+		{
+			resource: "junos_system_ntp_server",
+			create:   map[string]interface{}{"address": "192.0.2.1"},
+			update:   map[string]interface{}{"address": "192.0.2.1", "prefer": true, "version": 4},
+		},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(c.resource, func(t *testing.T) {
+			fake.SetCommitted([]string{"set system host-name vsrx1"})
+			r := provider.ResourcesMap[c.resource]
+			planEmpty := func(d *schema.ResourceData, config map[string]interface{}) {
+				t.Helper()
+				dRead := r.Data(d.State())
+				if diags := r.ReadContext(ctx, dRead, sess); diags.HasError() {
+					t.Fatalf("read error = %v", diags)
+				}
+				if dRead.Id() == "" {
+					t.Fatalf("read doesn't find resource in %v", fake.Committed())
+				}
+				diff, err := r.Diff(ctx, dRead.State(), terraform.NewResourceConfigRaw(config), sess)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff != nil && !diff.Empty() {
+					t.Errorf("plan after apply not empty = %v", diff)
+				}
+			}
+
+			d := schema.TestResourceDataRaw(t, r.Schema, c.create)
+			if diags := r.CreateContext(ctx, d, sess); diags.HasError() {
+				t.Fatalf("create error = %v", diags)
+			}
+			planEmpty(d, c.create)
+			d = updateFakeDeviceResource(t, r, d, c.update, sess)
+			planEmpty(d, c.update)
+			dImport := r.Data(nil)
+			dImport.SetId(d.Id())
+			imported, err := r.Importer.State(dImport, sess)
+			if err != nil || len(imported) != 1 {
+				t.Fatalf("import = %v, %v", imported, err)
+			}
+			if diags := r.DeleteContext(ctx, d, sess); diags.HasError() {
+				t.Fatalf("delete error = %v", diags)
+			}
+			if committed := fake.Committed(); len(committed) != 1 || committed[0] != "set system host-name vsrx1" {
+				t.Errorf("configuration after delete = %v", committed)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package junos
 
 import (
+	"errors"
 	"fmt"
 	"net"
 	"os"
@@ -10,6 +11,7 @@ import (
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	jdecode "github.com/jeremmfr/junosdecode"
 )
 
 type formatName int
@@ -275,18 +277,16 @@ func copyAndRemoveItemMapList(identifier string,
 	return list
 }
 
-func checkCompatibilitySecurity(jnprSess *NetconfObject) bool {
-	if strings.HasPrefix(strings.ToLower(jnprSess.SystemInformation.HardwareModel), "srx") {
-		return true
-	}
-	if strings.HasPrefix(strings.ToLower(jnprSess.SystemInformation.HardwareModel), "vsrx") {
-		return true
-	}
-	if strings.HasPrefix(strings.ToLower(jnprSess.SystemInformation.HardwareModel), "j") {
-		return true
+// listOfResourcesWithoutConfig : resources which don't generate configuration of device
+// (run commands on device or write files).
+func listOfResourcesWithoutConfig() []string {
+	return []string{
+		"junos_config_export",
+		"junos_null_commit_file",
+		"junos_request",
+		"junos_rollback",
+		"junos_system_rescue_configuration",
 	}
-
-	return false
 }
 
 func listOfSyslogSeverity() []string {
@@ -358,3 +358,47 @@ func replaceTildeToHomeDir(path *string) error {
 
 	return nil
 }
+
+// jdecodeSecret decode a '$9$' value read on device, the decoder panic with a malformed value
+// so the panic is converted to an error.
+func jdecodeSecret(value string) (_decoded string, _err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			_decoded = ""
+			_err = errors.New("malformed '$9$' value")
+		}
+	}()
+
+	return jdecode.Decode(value)
+}
+
+// jencodeSecret encode a value with the '$9$' algorithm of device (with a fixed salt),
+// the device and jdecodeSecret decode it to the same value.
+func jencodeSecret(value string) string {
+	family := []string{"QzF3n6/9CAtpu0O", "B1IREhcSyrleKvMW8LXx", "7N-dVbwsY2g4oaJZGUDj", "iHkq.mPf5T"}
+	encoding := [][]int{{1, 4, 32}, {1, 16, 32}, {1, 8, 32}, {1, 64}, {1, 32}, {1, 4, 16, 128}, {1, 32, 64}}
+	numAlpha := []rune(strings.Join(family, ""))
+	alphaNum := make(map[rune]int)
+	for i, r := range numAlpha {
+		alphaNum[r] = i
+	}
+	// salt 'Q' is in the first family, followed by 3 random characters
+	encoded := []rune("$9$QzF3")
+	prev := 'Q'
+	for i, c := range []byte(value) {
+		enc := encoding[i%len(encoding)]
+		gaps := make([]int, len(enc))
+		ord := int(c)
+		for j := len(enc) - 1; j >= 0; j-- {
+			gaps[j] = ord / enc[j]
+			ord %= enc[j]
+		}
+		for _, gap := range gaps {
+			r := numAlpha[(gap+alphaNum[prev]+1)%len(numAlpha)]
+			encoded = append(encoded, r)
+			prev = r
+		}
+	}
+
+	return string(encoded)
+}
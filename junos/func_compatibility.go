@@ -0,0 +1,347 @@
+package junos
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type platformFamily int
+
+const (
+	platformUnknown platformFamily = iota
+	platformSRX
+	platformEX
+	platformQFX
+	platformMX
+)
+
+func (p platformFamily) String() string {
+	switch p {
+	case platformSRX:
+		return "SRX"
+	case platformEX:
+		return "EX"
+	case platformQFX:
+		return "QFX"
+	case platformMX:
+		return "MX"
+	case platformUnknown:
+		return "unknown"
+	}
+
+	return "unknown"
+}
+
+// platformFamilyOfModel return family of device from hardware model (read with <get-system-information/>).
+func platformFamilyOfModel(hardwareModel string) platformFamily {
+	model := strings.ToLower(hardwareModel)
+	switch {
+	case strings.HasPrefix(model, "srx"),
+		strings.HasPrefix(model, "vsrx"),
+		strings.HasPrefix(model, "j"):
+		return platformSRX
+	case strings.HasPrefix(model, "ex"):
+		return platformEX
+	case strings.HasPrefix(model, "qfx"):
+		return platformQFX
+	case strings.HasPrefix(model, "mx"),
+		strings.HasPrefix(model, "vmx"):
+		return platformMX
+	}
+
+	return platformUnknown
+}
+
+// junosVersion : release of Junos with its type, service release and build
+// (18.4R3-S2.1 => 18.4, type R, release 3, service release S2 and build 1).
+type junosVersion struct {
+	major       int
+	minor       int
+	releaseType string
+	release     int
+	serviceType string
+	service     int
+	build       int
+}
+
+func parseJunosVersion(version string) (junosVersion, error) {
+	var v junosVersion
+	match := regexp.MustCompile(`^(\d+)\.(\d+)([A-Z])(\d+)(?:-([A-Z])(\d+))?(?:\.(\d+))?`).FindStringSubmatch(version)
+	if len(match) != 8 {
+		return v, fmt.Errorf("failed to parse Junos version '%s'", version)
+	}
+	var err error
+	v.major, err = strconv.Atoi(match[1])
+	if err != nil {
+		return v, fmt.Errorf("failed to convert value from '%s' to integer : %w", match[1], err)
+	}
+	v.minor, err = strconv.Atoi(match[2])
+	if err != nil {
+		return v, fmt.Errorf("failed to convert value from '%s' to integer : %w", match[2], err)
+	}
+	v.releaseType = match[3]
+	if _, ok := junosReleaseTypeRank()[v.releaseType]; !ok {
+		return v, fmt.Errorf("failed to parse Junos version '%s': unknown release type %s", version, v.releaseType)
+	}
+	v.release, err = strconv.Atoi(match[4])
+	if err != nil {
+		return v, fmt.Errorf("failed to convert value from '%s' to integer : %w", match[4], err)
+	}
+	if match[5] != "" {
+		v.serviceType = match[5]
+		v.service, err = strconv.Atoi(match[6])
+		if err != nil {
+			return v, fmt.Errorf("failed to convert value from '%s' to integer : %w", match[6], err)
+		}
+	}
+	if match[7] != "" {
+		v.build, err = strconv.Atoi(match[7])
+		if err != nil {
+			return v, fmt.Errorf("failed to convert value from '%s' to integer : %w", match[7], err)
+		}
+	}
+
+	return v, nil
+}
+
+// junosReleaseTypeRank : order of release types for the same major and minor version,
+// internal (I) and beta (B) builds are before the feature (F) and general (R) releases,
+// special releases (X, with D service releases) are built on top of the general releases.
+func junosReleaseTypeRank() map[string]int {
+	return map[string]int{"I": 0, "B": 1, "F": 2, "R": 3, "X": 4}
+}
+
+func (v junosVersion) String() string {
+	version := strconv.Itoa(v.major) + "." + strconv.Itoa(v.minor) + v.releaseType + strconv.Itoa(v.release)
+	if v.serviceType != "" {
+		version += "-" + v.serviceType + strconv.Itoa(v.service)
+	}
+	if v.build != 0 {
+		version += "." + strconv.Itoa(v.build)
+	}
+
+	return version
+}
+
+// lessThan compare major, minor, release type, release number, service release and build
+// (a version without service release or build is before the same version with them).
+func (v junosVersion) lessThan(other junosVersion) bool {
+	if v.major != other.major {
+		return v.major < other.major
+	}
+	if v.minor != other.minor {
+		return v.minor < other.minor
+	}
+	if rank := junosReleaseTypeRank(); rank[v.releaseType] != rank[other.releaseType] {
+		return rank[v.releaseType] < rank[other.releaseType]
+	}
+	if v.release != other.release {
+		return v.release < other.release
+	}
+	if v.service != other.service {
+		return v.service < other.service
+	}
+
+	return v.build < other.build
+}
+
+// compatibilityRequirement : platforms and minimum Junos version needed.
+// An empty list of platforms or an empty minVersion means no restriction.
+type compatibilityRequirement struct {
+	minVersion string
+	platforms  []platformFamily
+}
+
+type resourceCompatibility struct {
+	compatibilityRequirement
+	// attributes need a requirement when set (nested attributes in blocks are separated with '.')
+	attributes map[string]compatibilityRequirement
+}
+
+// compatibilityRegistry : requirements for resources and attributes checked at plan time.
+func compatibilityRegistry() map[string]resourceCompatibility {
+	srxOnly := compatibilityRequirement{platforms: []platformFamily{platformSRX}}
+
+	return map[string]resourceCompatibility{
+		"junos_chassis_cluster":    {compatibilityRequirement: srxOnly},
+		"junos_interface_st0_unit": {compatibilityRequirement: srxOnly},
+		"junos_interface": {
+			attributes: map[string]compatibilityRequirement{
+				"security_zone": srxOnly,
+			},
+		},
+		"junos_interface_logical": {
+			attributes: map[string]compatibilityRequirement{
+				"security_zone": srxOnly,
+			},
+		},
+		"junos_security_global_policy": {
+			compatibilityRequirement: srxOnly,
+			attributes: map[string]compatibilityRequirement{
+				"policy.match_dynamic_application": {minVersion: "18.2R1", platforms: []platformFamily{platformSRX}},
+			},
+		},
+		"junos_security_policy": {
+			compatibilityRequirement: srxOnly,
+			attributes: map[string]compatibilityRequirement{
+				"policy.match_dynamic_application": {minVersion: "18.2R1", platforms: []platformFamily{platformSRX}},
+			},
+		},
+	}
+}
+
+// resourceCompatibilityOf return requirements of a resource from registry
+// or SRX only for the others junos_security* and junos_services* resources.
+func resourceCompatibilityOf(resourceName string, registry map[string]resourceCompatibility) (
+	resourceCompatibility, bool) {
+	if compat, ok := registry[resourceName]; ok {
+		return compat, true
+	}
+	if strings.HasPrefix(resourceName, "junos_security") || strings.HasPrefix(resourceName, "junos_services") {
+		return resourceCompatibility{
+			compatibilityRequirement: compatibilityRequirement{platforms: []platformFamily{platformSRX}},
+		}, true
+	}
+
+	return resourceCompatibility{}, false
+}
+
+// check return an error with a readable message when device doesn't match requirement.
+func (req compatibilityRequirement) check(subject string, info sysInfo) error {
+	if len(req.platforms) > 0 {
+		family := platformFamilyOfModel(info.HardwareModel)
+		compatible := false
+		platformsString := make([]string, 0, len(req.platforms))
+		for _, p := range req.platforms {
+			platformsString = append(platformsString, p.String())
+			if p == family {
+				compatible = true
+			}
+		}
+		if !compatible {
+			return fmt.Errorf("%s not compatible with Junos device %s (platform %s required)",
+				subject, info.HardwareModel, strings.Join(platformsString, " or "))
+		}
+	}
+	if req.minVersion != "" {
+		minVersion, err := parseJunosVersion(req.minVersion)
+		if err != nil {
+			return fmt.Errorf("internal error: %w", err)
+		}
+		version, err := parseJunosVersion(info.OsVersion)
+		if err != nil {
+			return fmt.Errorf("%s need Junos version %s minimum : %w", subject, minVersion, err)
+		}
+		if version.lessThan(minVersion) {
+			return fmt.Errorf("%s not compatible with Junos version %s on device %s (%s minimum required)",
+				subject, info.OsVersion, info.HardwareModel, minVersion)
+		}
+	}
+
+	return nil
+}
+
+// customizeDiffCompatibility generate a CustomizeDiff func to check requirements of resource at plan time.
+func customizeDiffCompatibility(resourceName string, compat resourceCompatibility) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		sess := m.(*Session)
+		if sess.junosFakeCreateSetFile != "" {
+			return nil
+		}
+		info, err := sess.systemInformation()
+		if err != nil {
+			return err
+		}
+		if err := compat.check("resource "+resourceName, info); err != nil {
+			return err
+		}
+		for attribute, req := range compat.attributes {
+			if !resourceDiffAttributeIsSet(d, attribute) {
+				continue
+			}
+			if err := req.check("argument "+attribute+" of resource "+resourceName, info); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// addCustomizeDiffCompatibility add check of requirements from compatibilityRegistry on resources.
+func addCustomizeDiffCompatibility(resources map[string]*schema.Resource) {
+	registry := compatibilityRegistry()
+	for resourceName, resource := range resources {
+		compat, ok := resourceCompatibilityOf(resourceName, registry)
+		if !ok {
+			continue
+		}
+		if resource.CustomizeDiff != nil {
+			resource.CustomizeDiff = customdiff.All(resource.CustomizeDiff,
+				customizeDiffCompatibility(resourceName, compat))
+		} else {
+			resource.CustomizeDiff = customizeDiffCompatibility(resourceName, compat)
+		}
+	}
+}
+
+func resourceDiffAttributeIsSet(d *schema.ResourceDiff, attribute string) bool {
+	attributeSplit := strings.Split(attribute, ".")
+	v, ok := d.GetOk(attributeSplit[0])
+	if !ok {
+		return false
+	}
+
+	return valueAttributeIsSet(v, attributeSplit[1:])
+}
+
+func valueAttributeIsSet(v interface{}, path []string) bool {
+	if len(path) == 0 {
+		switch value := v.(type) {
+		case nil:
+			return false
+		case string:
+			return value != ""
+		case bool:
+			return value
+		case int:
+			return value != 0
+		case []interface{}:
+			return len(value) > 0
+		case *schema.Set:
+			return value.Len() > 0
+		}
+
+		return true
+	}
+	var items []interface{}
+	switch value := v.(type) {
+	case []interface{}:
+		items = value
+	case *schema.Set:
+		items = value.List()
+	case map[string]interface{}:
+		items = []interface{}{value}
+	}
+	for _, item := range items {
+		itemMap, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if valueAttributeIsSet(itemMap[path[0]], path[1:]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func checkCompatibilitySecurity(jnprSess *NetconfObject) bool {
+	return platformFamilyOfModel(jnprSess.SystemInformation.HardwareModel) == platformSRX
+}
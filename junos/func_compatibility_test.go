@@ -0,0 +1,83 @@
+package junos
+
+import (
+	"testing"
+)
+
+func TestCompatibilityRequirement(t *testing.T) {
+	req := compatibilityRequirement{minVersion: "18.2R1", platforms: []platformFamily{platformSRX}}
+	tests := []struct {
+		info    sysInfo
+		wantErr bool
+	}{
+		{sysInfo{HardwareModel: "vsrx", OsVersion: "20.2R1.10"}, false},
+		{sysInfo{HardwareModel: "srx345", OsVersion: "18.2R3-S2.1"}, false},
+		{sysInfo{HardwareModel: "srx345", OsVersion: "18.1R3-S9"}, true},
+		{sysInfo{HardwareModel: "srx345", OsVersion: "18.2B1.3"}, true},
+		{sysInfo{HardwareModel: "srx345", OsVersion: "18.2X75-D10.2"}, false},
+		{sysInfo{HardwareModel: "srx1500", OsVersion: "15.1X49-D200.5"}, true},
+		{sysInfo{HardwareModel: "ex4300-48t", OsVersion: "20.2R1.10"}, true},
+		{sysInfo{HardwareModel: "vsrx", OsVersion: "unknown"}, true},
+	}
+	for _, tt := range tests {
+		err := req.check("test", tt.info)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("check(%v) error = %v, wantErr %v", tt.info, err, tt.wantErr)
+		}
+	}
+}
+
+func TestJunosVersionLessThan(t *testing.T) {
+	// versions in ascending order
+	versions := []string{
+		"15.1X49-D200.5",
+		"18.4I20190101_1200",
+		"18.4B1.2",
+		"18.4R1",
+		"18.4R1.8",
+		"18.4R1-S2.4",
+		"18.4R2.7",
+		"18.4R3-S2.1",
+		"18.4R3-S10.2",
+		"18.4X62-D10.1",
+		"20.2R1.10",
+	}
+	for i, version := range versions {
+		v, err := parseJunosVersion(version)
+		if err != nil {
+			t.Fatalf("parseJunosVersion(%s) error = %v", version, err)
+		}
+		for j, otherVersion := range versions {
+			other, err := parseJunosVersion(otherVersion)
+			if err != nil {
+				t.Fatalf("parseJunosVersion(%s) error = %v", otherVersion, err)
+			}
+			if got := v.lessThan(other); got != (i < j) {
+				t.Errorf("%s lessThan %s = %v, want %v", version, otherVersion, got, i < j)
+			}
+		}
+	}
+	if v, _ := parseJunosVersion("18.4R3-S2.1"); v.String() != "18.4R3-S2.1" {
+		t.Errorf("String() = %s, want 18.4R3-S2.1", v)
+	}
+	if _, err := parseJunosVersion("18.4Z1"); err == nil {
+		t.Error("parseJunosVersion with unknown release type without error")
+	}
+}
+
+func TestPlatformFamilyOfModel(t *testing.T) {
+	tests := map[string]platformFamily{
+		"vSRX":       platformSRX,
+		"srx5800":    platformSRX,
+		"ex2300-c":   platformEX,
+		"qfx5100-48": platformQFX,
+		"mx204":      platformMX,
+		"vmx":        platformMX,
+		"ptx10008":   platformUnknown,
+	}
+	for model, want := range tests {
+		if got := platformFamilyOfModel(model); got != want {
+			t.Errorf("platformFamilyOfModel(%s) = %s, want %s", model, got, want)
+		}
+	}
+}
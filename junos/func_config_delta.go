@@ -0,0 +1,342 @@
+package junos
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const deleteLineStart = deleteWord + " "
+
+// configDeltaOrderedWords : words of statements with an order defined by user
+// (a new entry is added at the end of list by a set line).
+func configDeltaOrderedWords() []string {
+	return []string{"export", "import", "policy", "rule", "term"}
+}
+
+// configSetDelta replace the configuration of an object with only the needed lines.
+// replace is the full replace of object (delete of hierarchies then set of new configuration)
+// and is called with a session that only record lines, the current configuration under the deleted hierarchies
+// is read from device and compared to the new set lines to generate only the needed 'delete' and 'set' lines.
+// The full replace is used when delta can't be computed or can't respect the order of statements
+// (entries without children of an ordered list).
+func (sess *Session) configSetDelta(replace func(m interface{}) error, jnpr *NetconfObject) error {
+	lines := make([]string, 0)
+	sessRecord := *sess
+	sessRecord.junosConfigSetRecord = &lines
+	if err := replace(&sessRecord); err != nil {
+		return err
+	}
+	// the ownership marker is found with the lines of full replace
+	sessApply := *sess
+	sessApply.junosOwnershipWrite = nil
+	sess = &sessApply
+	if jnpr == nil {
+		return sess.configSet(lines, jnpr)
+	}
+	hierarchy := configDeltaCommonHierarchy(lines)
+	if hierarchy == "" {
+		sess.logFile("[configSetDelta] fallback on full replace: no common hierarchy in delete lines")
+
+		return sess.configSet(lines, jnpr)
+	}
+	current, err := sess.command(showConfigurationWords+" "+hierarchy+" "+displaySetWords, jnpr)
+	if err != nil {
+		sess.logFile(fmt.Sprintf("[configSetDelta] fallback on full replace: %s", err))
+
+		return sess.configSet(lines, jnpr)
+	}
+	configSet, err := configDeltaLines(strings.Split(current, "\n"), lines)
+	if err != nil {
+		sess.logFile(fmt.Sprintf("[configSetDelta] fallback on full replace: %s", err))
+
+		return sess.configSet(lines, jnpr)
+	}
+	if len(configSet) == 0 {
+		return nil
+	}
+
+	return sess.configSet(configSet, jnpr)
+}
+
+// configDeltaCommonHierarchy return the longest common hierarchy of delete lines.
+// When the delete lines diverge on the entries of a list (like 'policy p1' and 'policy p2'),
+// the keyword of list is removed to have a complete statement in hierarchy.
+func configDeltaCommonHierarchy(lines []string) string {
+	var common []string
+	found := false
+	diverge := false
+	for _, line := range lines {
+		if !strings.HasPrefix(line, deleteLineStart) {
+			continue
+		}
+		words := splitSetLineWords(strings.TrimPrefix(line, deleteLineStart))
+		if !found {
+			common = words
+			found = true
+
+			continue
+		}
+		i := 0
+		for i < len(common) && i < len(words) && common[i] == words[i] {
+			i++
+		}
+		if i < len(common) {
+			diverge = true
+		}
+		common = common[:i]
+	}
+	if diverge && len(common) > 0 && stringInSlice(common[len(common)-1], configDeltaOrderedWords()) {
+		common = common[:len(common)-1]
+	}
+
+	return strings.Join(common, " ")
+}
+
+// configDeltaLines compare the current lines under the deleted hierarchies ('display set' output)
+// with the new lines of full replace and generate the lines to apply only the changes.
+func configDeltaLines(current []string, replace []string) ([]string, error) {
+	deleted := make([][]string, 0)
+	newLines := make([]string, 0)
+	otherLines := make([]string, 0)
+	for _, line := range replace {
+		switch {
+		case strings.HasPrefix(line, deleteLineStart):
+			deleted = append(deleted, splitSetLineWords(strings.TrimPrefix(line, deleteLineStart)))
+		case strings.HasPrefix(line, setLineStart), strings.HasPrefix(line, deactivateLineStart):
+			newLines = append(newLines, line)
+		default:
+			otherLines = append(otherLines, line)
+		}
+	}
+	oldLines := make([]string, 0)
+	// length of deleted hierarchy of each old line
+	oldDeleted := make([]int, 0)
+	for _, line := range current {
+		line = strings.TrimSpace(line)
+		if strings.Contains(line, " "+ownershipMacroWords+" ") {
+			// ownership marker is set again on commit
+			continue
+		}
+		var words []string
+		switch {
+		case strings.HasPrefix(line, setLineStart):
+			words = splitSetLineWords(strings.TrimPrefix(line, setLineStart))
+		case strings.HasPrefix(line, deactivateLineStart):
+			words = splitSetLineWords(strings.TrimPrefix(line, deactivateLineStart))
+		default:
+			continue
+		}
+		for _, del := range deleted {
+			if wordsHasPrefix(words, del) {
+				oldLines = append(oldLines, line)
+				oldDeleted = append(oldDeleted, len(del))
+
+				break
+			}
+		}
+	}
+	insertLines := make([]string, 0)
+	if !configDeltaHasInsert(otherLines) {
+		// without its own 'insert' lines, the order of new lines need to be respected
+		var err error
+		insertLines, err = configDeltaInsertLines(oldLines, newLines)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	newKeys := make(map[string]bool)
+	newWords := make([][]string, 0, len(newLines))
+	for _, line := range newLines {
+		newKeys[configDeltaLineKey(line)] = true
+		if strings.HasPrefix(line, setLineStart) {
+			newWords = append(newWords, splitSetLineWords(strings.TrimPrefix(line, setLineStart)))
+		}
+	}
+	oldKeys := make(map[string]bool)
+	configSet := make([]string, 0)
+	for i, line := range oldLines {
+		oldKeys[configDeltaLineKey(line)] = true
+		if newKeys[configDeltaLineKey(line)] {
+			continue
+		}
+		if strings.HasPrefix(line, deactivateLineStart) {
+			// the delete of hierarchies in full replace remove all the deactivated statements
+			return nil, fmt.Errorf("statement deactivated on device with '%s'", line)
+		}
+		// delete the first statement of line not used by new lines
+		// (entire entry of list removed, leaf with a new value, ...)
+		// but not the parent of new lines (a container without children before)
+		words := splitSetLineWords(strings.TrimPrefix(line, setLineStart))
+		for k := oldDeleted[i]; k <= len(words); k++ {
+			if configDeltaWordsIsParent(newWords, words[:k], 0) {
+				continue
+			}
+			if del := deleteLineStart + strings.Join(words[:k], " "); !stringInSlice(del, configSet) {
+				configSet = append(configSet, del)
+			}
+
+			break
+		}
+	}
+	for _, line := range newLines {
+		if !oldKeys[configDeltaLineKey(line)] {
+			configSet = append(configSet, line)
+		}
+	}
+	configSet = append(configSet, insertLines...)
+
+	return append(configSet, otherLines...), nil
+}
+
+// configDeltaInsertLines generate the 'insert' lines to have the statements with an order defined by user
+// in the order of new lines: the entries kept stay in their order and the new entries are added at the end of list
+// by the set lines, so entries need to be moved when order is different.
+// Only entries with children (like terms) can be moved, error is returned for other lists.
+func configDeltaInsertLines(oldLines, newLines []string) ([]string, error) {
+	oldEntries, _ := configDeltaOrderedEntries(oldLines)
+	newEntries, withChildren := configDeltaOrderedEntries(newLines)
+	parents := make([]string, 0, len(newEntries))
+	for parent := range newEntries {
+		parents = append(parents, parent)
+	}
+	sort.Strings(parents)
+	insertLines := make([]string, 0)
+	for _, parent := range parents {
+		entries := newEntries[parent]
+		current := make([]string, 0, len(entries))
+		for _, entry := range oldEntries[parent] {
+			if stringInSlice(entry, entries) {
+				current = append(current, entry)
+			}
+		}
+		for _, entry := range entries {
+			if !stringInSlice(entry, current) {
+				current = append(current, entry)
+			}
+		}
+		parentWords := strings.Split(parent, " ")
+		keyWord := parentWords[len(parentWords)-1]
+		for i, entry := range entries {
+			if current[i] == entry {
+				continue
+			}
+			if !withChildren[parent] {
+				return nil, fmt.Errorf("order of '%s' entries changed", parent)
+			}
+			if i == 0 {
+				insertLines = append(insertLines, "insert "+parent+" "+entry+" before "+keyWord+" "+current[0])
+			} else {
+				insertLines = append(insertLines, "insert "+parent+" "+entry+" after "+keyWord+" "+entries[i-1])
+			}
+			// move entry to its new place in current list
+			moved := append(make([]string, 0, len(current)), current[:i]...)
+			moved = append(moved, entry)
+			for _, v := range current[i:] {
+				if v != entry {
+					moved = append(moved, v)
+				}
+			}
+			current = moved
+		}
+	}
+
+	return insertLines, nil
+}
+
+// configDeltaHasInsert check if the full replace has its own 'insert' lines to place the entries
+// (like the position of policies in junos_security_policy),
+// the 'insert' lines are then only generated by the full replace.
+func configDeltaHasInsert(lines []string) bool {
+	for _, line := range lines {
+		if strings.HasPrefix(line, "insert ") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// configDeltaOrderedEntries list, for each parent of statements with an order defined by user,
+// the entries in order of lines and if entries have children statements.
+func configDeltaOrderedEntries(lines []string) (map[string][]string, map[string]bool) {
+	entries := make(map[string][]string)
+	withChildren := make(map[string]bool)
+	orderedWords := configDeltaOrderedWords()
+	for _, line := range lines {
+		if !strings.HasPrefix(line, setLineStart) {
+			continue
+		}
+		words := splitSetLineWords(strings.TrimPrefix(line, setLineStart))
+		for i := 0; i < len(words)-1; i++ {
+			if !stringInSlice(words[i], orderedWords) {
+				continue
+			}
+			parent := strings.Join(words[:i+1], " ")
+			entry := strings.Trim(words[i+1], "\"")
+			if !stringInSlice(entry, entries[parent]) {
+				entries[parent] = append(entries[parent], entry)
+			}
+			if len(words) > i+2 {
+				withChildren[parent] = true
+			}
+		}
+	}
+
+	return entries, withChildren
+}
+
+// configDeltaWordsIsParent check if words is the hierarchy of one of lines
+// with at least minMore words after it.
+func configDeltaWordsIsParent(lines [][]string, words []string, minMore int) bool {
+	for _, w := range lines {
+		if len(w) >= len(words)+minMore && wordsHasPrefix(w, words) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// configDeltaLineKey generate a key to compare lines without quotes
+// (quotes are only added by device when necessary).
+func configDeltaLineKey(line string) string {
+	return strings.ReplaceAll(line, "\"", "")
+}
+
+// configDecodedLineKey generate a key to compare a line read on device with a line generated by resource:
+// without quotes, with '$9$' secrets decoded (the resources read the decoded value)
+// and with the long name of logical interfaces like the device
+// (interfaces ge-0/0/3.100 => interfaces ge-0/0/3 unit 100).
+func configDecodedLineKey(line string) string {
+	words := splitSetLineWords(line)
+	if len(words) > 2 && words[1] == "interfaces" && strings.Contains(words[2], ".") {
+		nameSplit := strings.SplitN(words[2], ".", 2)
+		words = append([]string{words[0], words[1], nameSplit[0], "unit", nameSplit[1]}, words[3:]...)
+	}
+	for i, word := range words {
+		value := strings.Trim(word, "\"")
+		if !strings.HasPrefix(value, secretEncodedPrefix) {
+			continue
+		}
+		if decoded, err := jdecodeSecret(value); err == nil {
+			words[i] = decoded
+		}
+	}
+
+	return configDeltaLineKey(strings.Join(words, " "))
+}
+
+func wordsHasPrefix(words, prefix []string) bool {
+	if len(prefix) > len(words) {
+		return false
+	}
+	for i, w := range prefix {
+		if strings.Trim(words[i], "\"") != strings.Trim(w, "\"") {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,547 @@
+package junos
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestConfigDeltaLines(t *testing.T) {
+	filter := "firewall family inet filter filter1"
+	cases := map[string]struct {
+		current []string
+		replace []string
+		want    []string
+		wantErr bool
+	}{
+		"unchanged": {
+			current: []string{
+				"set " + filter + " term t1 from protocol tcp",
+				"set " + filter + " term t1 then accept",
+			},
+			replace: []string{
+				"delete " + filter,
+				"set " + filter + " term t1 from protocol tcp",
+				"set " + filter + " term t1 then accept",
+			},
+			want: []string{},
+		},
+		"term modified": {
+			current: []string{
+				"set " + filter + " term t1 from protocol tcp",
+				"set " + filter + " term t1 then accept",
+				"set " + filter + " term t2 then discard",
+			},
+			replace: []string{
+				"delete " + filter,
+				"set " + filter + " term t1 from protocol udp",
+				"set " + filter + " term t1 then accept",
+				"set " + filter + " term t2 then discard",
+			},
+			want: []string{
+				"delete " + filter + " term t1 from protocol tcp",
+				"set " + filter + " term t1 from protocol udp",
+			},
+		},
+		"term added at end": {
+			current: []string{
+				"set " + filter + " term t1 then accept",
+			},
+			replace: []string{
+				"delete " + filter,
+				"set " + filter + " term t1 then accept",
+				"set " + filter + " term t2 then discard",
+			},
+			want: []string{
+				"set " + filter + " term t2 then discard",
+			},
+		},
+		"term added before": {
+			current: []string{
+				"set " + filter + " term t1 then accept",
+			},
+			replace: []string{
+				"delete " + filter,
+				"set " + filter + " term t0 then discard",
+				"set " + filter + " term t1 then accept",
+			},
+			want: []string{
+				"set " + filter + " term t0 then discard",
+				"insert " + filter + " term t0 before term t1",
+			},
+		},
+		"terms reordered": {
+			current: []string{
+				"set " + filter + " term t1 then accept",
+				"set " + filter + " term t2 then discard",
+			},
+			replace: []string{
+				"delete " + filter,
+				"set " + filter + " term t2 then discard",
+				"set " + filter + " term t1 then accept",
+			},
+			want: []string{
+				"insert " + filter + " term t2 before term t1",
+			},
+		},
+		"terms moved": {
+			current: []string{
+				"set " + filter + " term t1 then accept",
+				"set " + filter + " term t2 then accept",
+				"set " + filter + " term t3 then accept",
+				"set " + filter + " term t4 then discard",
+			},
+			replace: []string{
+				"delete " + filter,
+				"set " + filter + " term t1 then accept",
+				"set " + filter + " term t4 then discard",
+				"set " + filter + " term t5 then accept",
+				"set " + filter + " term t2 then accept",
+			},
+			want: []string{
+				"delete " + filter + " term t3",
+				"set " + filter + " term t5 then accept",
+				"insert " + filter + " term t4 after term t1",
+				"insert " + filter + " term t5 after term t4",
+			},
+		},
+		"policies with position": {
+			current: []string{
+				"set security policies from-zone trust to-zone untrust policy p1 then permit",
+				"set security policies from-zone trust to-zone untrust policy p2 then permit",
+			},
+			replace: []string{
+				"delete security policies from-zone trust to-zone untrust policy p1",
+				"delete security policies from-zone trust to-zone untrust policy p2",
+				"set security policies from-zone trust to-zone untrust policy p2 then permit",
+				"set security policies from-zone trust to-zone untrust policy p1 then permit",
+				"insert security policies from-zone trust to-zone untrust policy p2 before policy other1",
+				"insert security policies from-zone trust to-zone untrust policy p1 after policy p2",
+			},
+			want: []string{
+				"insert security policies from-zone trust to-zone untrust policy p2 before policy other1",
+				"insert security policies from-zone trust to-zone untrust policy p1 after policy p2",
+			},
+		},
+		"list reordered": {
+			current: []string{
+				"set protocols bgp group group1 import policy1",
+				"set protocols bgp group group1 import policy2",
+			},
+			replace: []string{
+				"delete protocols bgp group group1 import",
+				"set protocols bgp group group1 import policy2",
+				"set protocols bgp group group1 import policy1",
+			},
+			wantErr: true,
+		},
+		"quotes": {
+			current: []string{
+				"set routing-options static route 192.0.2.0/24 next-hop 192.0.2.254",
+				"set routing-options static route 192.0.2.0/24 community 65000:100",
+				"set routing-options static route 192.0.2.0/24 tag 1",
+				"set routing-options static route 198.51.100.0/24 discard",
+			},
+			replace: []string{
+				"delete routing-options static route 192.0.2.0/24",
+				"set routing-options static route 192.0.2.0/24 next-hop 192.0.2.254",
+				"set routing-options static route 192.0.2.0/24 community \"65000:100\"",
+				"set routing-options static route 192.0.2.0/24 tag 2",
+			},
+			want: []string{
+				"delete routing-options static route 192.0.2.0/24 tag 1",
+				"set routing-options static route 192.0.2.0/24 tag 2",
+			},
+		},
+		"deactivated statement": {
+			current: []string{
+				"set routing-options static route 192.0.2.0/24 next-hop 192.0.2.254",
+				"set routing-options static route 192.0.2.0/24 tag 1",
+				"deactivate routing-options static route 192.0.2.0/24 tag",
+			},
+			replace: []string{
+				"delete routing-options static route 192.0.2.0/24",
+				"set routing-options static route 192.0.2.0/24 next-hop 192.0.2.254",
+				"set routing-options static route 192.0.2.0/24 tag 1",
+			},
+			wantErr: true,
+		},
+		"deactivated and kept inactive": {
+			current: []string{
+				"set routing-options static route 192.0.2.0/24 next-hop 192.0.2.254",
+				"deactivate routing-options static route 192.0.2.0/24",
+			},
+			replace: []string{
+				"delete routing-options static route 192.0.2.0/24",
+				"set routing-options static route 192.0.2.0/24 next-hop 192.0.2.254",
+				"deactivate routing-options static route 192.0.2.0/24",
+			},
+			want: []string{},
+		},
+		"entry removed": {
+			current: []string{
+				"set routing-options static route 192.0.2.0/24 qualified-next-hop st0.0 preference 101",
+				"set routing-options static route 192.0.2.0/24 qualified-next-hop 192.0.2.250 interface st0.0",
+				"set routing-options static route 192.0.2.0/24 qualified-next-hop 192.0.2.250 preference 102",
+			},
+			replace: []string{
+				"delete routing-options static route 192.0.2.0/24",
+				"set routing-options static route 192.0.2.0/24 qualified-next-hop st0.0",
+				"set routing-options static route 192.0.2.0/24 qualified-next-hop st0.0 preference 101",
+			},
+			want: []string{
+				"delete routing-options static route 192.0.2.0/24 qualified-next-hop 192.0.2.250",
+				"set routing-options static route 192.0.2.0/24 qualified-next-hop st0.0",
+			},
+		},
+		"container with new children": {
+			current: []string{
+				"set interfaces st0 unit 0",
+			},
+			replace: []string{
+				"delete interfaces st0 unit 0",
+				"set interfaces st0 unit 0 family inet",
+			},
+			want: []string{
+				"set interfaces st0 unit 0 family inet",
+			},
+		},
+	}
+	for name, c := range cases {
+		got, err := configDeltaLines(c.current, c.replace)
+		if (err != nil) != c.wantErr {
+			t.Errorf("%s: error = %v, wantErr %v", name, err, c.wantErr)
+
+			continue
+		}
+		if !c.wantErr && !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: lines = %q, want %q", name, got, c.want)
+		}
+	}
+}
+
+func TestConfigDeltaCommonHierarchy(t *testing.T) {
+	policies := "security policies from-zone trust to-zone untrust"
+	cases := map[string]struct {
+		lines []string
+		want  string
+	}{
+		"one delete": {
+			lines: []string{"delete routing-options static route 192.0.2.0/24", "set routing-options static route 192.0.2.0/24"},
+			want:  "routing-options static route 192.0.2.0/24",
+		},
+		"entries of list": {
+			lines: []string{"delete " + policies + " policy p1", "delete " + policies + " policy p2"},
+			want:  policies,
+		},
+		"statements of object": {
+			lines: []string{"delete protocols bgp group g1 family", "delete protocols bgp group g1 description"},
+			want:  "protocols bgp group g1",
+		},
+		"no delete": {
+			lines: []string{"set routing-options static route 192.0.2.0/24"},
+			want:  "",
+		},
+	}
+	for name, c := range cases {
+		if got := configDeltaCommonHierarchy(c.lines); got != c.want {
+			t.Errorf("%s: configDeltaCommonHierarchy() = %q, want %q", name, got, c.want)
+		}
+	}
+}
+
+func TestConfigSetDelta(t *testing.T) {
+	fake, err := StartFakeDevice("vsrx", "20.2R1.10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fake.Close()
+	sess := newFakeDeviceSession(t, fake)
+	ctx := context.Background()
+	r := Provider().ResourcesMap["junos_static_route"]
+
+	config := map[string]interface{}{
+		"destination": "192.0.2.0/24",
+		"next_hop":    []interface{}{"192.0.2.253", "192.0.2.254"},
+		"preference":  5,
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(ctx, d, sess); diags.HasError() {
+		t.Fatalf("create error = %v", diags)
+	}
+	config["next_hop"] = []interface{}{"192.0.2.253", "192.0.2.252"}
+	loadedBefore := len(fake.Loaded())
+	updateFakeDeviceResource(t, r, d, config, sess)
+	wantLoaded := []string{
+		"delete routing-options static route 192.0.2.0/24 next-hop 192.0.2.254",
+		"set routing-options static route 192.0.2.0/24 next-hop 192.0.2.252",
+	}
+	if loaded := fake.Loaded()[loadedBefore:]; !reflect.DeepEqual(loaded, wantLoaded) {
+		t.Errorf("lines loaded by update = %q, want %q", loaded, wantLoaded)
+	}
+	wantCommitted := []string{
+		"set routing-options static route 192.0.2.0/24 next-hop 192.0.2.253",
+		"set routing-options static route 192.0.2.0/24 preference 5",
+		"set routing-options static route 192.0.2.0/24 next-hop 192.0.2.252",
+	}
+	if committed := fake.Committed(); !reflect.DeepEqual(committed, wantCommitted) {
+		t.Errorf("committed = %q, want %q", committed, wantCommitted)
+	}
+}
+
+func TestSecurityPolicyPosition(t *testing.T) {
+	fake, err := StartFakeDevice("vsrx", "20.2R1.10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fake.Close()
+	sess := newFakeDeviceSession(t, fake)
+	ctx := context.Background()
+	r := Provider().ResourcesMap["junos_security_policy"]
+
+	preset := make([]string, 0)
+	for _, name := range []string{"other1", "other2"} {
+		prefix := "set security policies from-zone trust to-zone untrust policy " + name
+		preset = append(preset,
+			prefix+" match source-address any",
+			prefix+" match destination-address any",
+			prefix+" match application any",
+			prefix+" then permit",
+		)
+	}
+	fake.SetCommitted(preset)
+	policy := func(name string) map[string]interface{} {
+		return map[string]interface{}{
+			"name":                      name,
+			"match_source_address":      []interface{}{"any"},
+			"match_destination_address": []interface{}{"any"},
+			"match_application":         []interface{}{"junos-ssh"},
+			"then":                      "deny",
+		}
+	}
+	wantOrder := func(want []string) {
+		t.Helper()
+		jnprSess, err := sess.startNewSession()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer sess.closeSession(jnprSess)
+		names, err := readSecurityPolicyNames("trust", "untrust", sess, jnprSess)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(names, want) {
+			t.Errorf("policies = %q, want %q", names, want)
+		}
+	}
+
+	config := map[string]interface{}{
+		"from_zone": "trust",
+		"to_zone":   "untrust",
+		"policy":    []interface{}{policy("p1"), policy("p2")},
+		"position":  []interface{}{map[string]interface{}{"strategy": "after", "policy": "other1"}},
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(ctx, d, sess); diags.HasError() {
+		t.Fatalf("create error = %v", diags)
+	}
+	wantOrder([]string{"other1", "p1", "p2", "other2"})
+	if policies := d.Get("policy").([]interface{}); len(policies) != 2 {
+		t.Errorf("read %d policies, want 2", len(policies))
+	}
+	if d.Id() != "trust"+idSeparator+"untrust" {
+		t.Errorf("id = %q, want the zones", d.Id())
+	}
+	dImport := r.Data(nil)
+	dImport.SetId("trust" + idSeparator + "untrust" + idSeparator + "p1" + idSeparator + "p2")
+	imported, err := r.Importer.State(dImport, sess)
+	if err != nil {
+		t.Fatalf("import error = %v", err)
+	}
+	if policies := imported[0].Get("policy").([]interface{}); len(policies) != 2 {
+		t.Errorf("import %d policies, want 2", len(policies))
+	}
+	if imported[0].Id() != "trust"+idSeparator+"untrust" {
+		t.Errorf("id after import = %q, want the zones", imported[0].Id())
+	}
+	wantPosition := []interface{}{map[string]interface{}{"strategy": "after", "policy": "other1"}}
+	if position := imported[0].Get("position"); !reflect.DeepEqual(position, wantPosition) {
+		t.Errorf("position after import = %v, want %v", position, wantPosition)
+	}
+	// only the policies of resource are its root statements
+	committed := fake.Committed()
+	fake.SetCommitted(append(committed, "deactivate security policies from-zone trust to-zone untrust policy other1"))
+	if diags := r.ReadContext(ctx, d, sess); diags.HasError() {
+		t.Fatalf("read error = %v", diags)
+	}
+	if d.Get("inactive").(bool) {
+		t.Error("inactive = true after deactivate of a policy not managed by resource, want false")
+	}
+	fake.SetCommitted(committed)
+
+	config["policy"] = []interface{}{policy("p2"), policy("p1"), policy("p3")}
+	config["position"] = []interface{}{map[string]interface{}{"strategy": "first"}}
+	d = updateFakeDeviceResource(t, r, d, config, sess)
+	wantOrder([]string{"p2", "p1", "p3", "other1", "other2"})
+
+	config["policy"] = []interface{}{policy("p3")}
+	config["position"] = []interface{}{map[string]interface{}{"strategy": "last"}}
+	d = updateFakeDeviceResource(t, r, d, config, sess)
+	wantOrder([]string{"other1", "other2", "p3"})
+	if d.Id() != "trust"+idSeparator+"untrust" {
+		t.Errorf("id after update = %q, want the zones", d.Id())
+	}
+
+	for _, position := range []map[string]interface{}{
+		{"strategy": "before"},
+		{"strategy": "first", "policy": "other1"},
+		{"strategy": "after", "policy": "p3"},
+	} {
+		config["position"] = []interface{}{position}
+		if _, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(config), sess); err == nil {
+			t.Errorf("plan with position %v without error", position)
+		}
+	}
+	config["position"] = []interface{}{map[string]interface{}{"strategy": "after", "policy": "other1"}}
+	if _, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(config), sess); err != nil {
+		t.Errorf("plan with valid position error = %v", err)
+	}
+
+	if diags := r.DeleteContext(ctx, d, sess); diags.HasError() {
+		t.Fatalf("delete error = %v", diags)
+	}
+	wantOrder([]string{"other1", "other2"})
+}
+
+func TestResourceConfigDrift(t *testing.T) {
+	fake, err := StartFakeDevice("vsrx", "20.2R1.10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fake.Close()
+	sess := newFakeDeviceSession(t, fake)
+	ctx := context.Background()
+	r := Provider().ResourcesMap["junos_config"]
+	fake.SetCommitted([]string{"set snmp community other authorization read-only"})
+
+	config := map[string]interface{}{
+		"hierarchy": "snmp community drift",
+		"lines":     []interface{}{"authorization read-only", "clients 192.0.2.0/25", "clients \"192.0.2.128/25\""},
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(ctx, d, sess); diags.HasError() {
+		t.Fatalf("create error = %v", diags)
+	}
+	if got := d.Get("lines").([]interface{}); !reflect.DeepEqual(got, config["lines"]) {
+		t.Errorf("lines after create = %q, want %q", got, config["lines"])
+	}
+
+	// a line removed and a line added outside Terraform
+	lines := make([]string, 0)
+	for _, line := range fake.Committed() {
+		if !strings.Contains(line, "192.0.2.0/25") {
+			lines = append(lines, line)
+		}
+	}
+	fake.SetCommitted(append(lines, "set snmp community drift clients 198.51.100.0/24"))
+	dRead := r.Data(d.State())
+	if diags := r.ReadContext(ctx, dRead, sess); diags.HasError() {
+		t.Fatalf("read error = %v", diags)
+	}
+	wantRead := []interface{}{"authorization read-only", "clients \"192.0.2.128/25\""}
+	if got := dRead.Get("lines").([]interface{}); !reflect.DeepEqual(got, wantRead) {
+		t.Errorf("lines after drift = %q, want %q", got, wantRead)
+	}
+	wantUnmanaged := []interface{}{"clients 198.51.100.0/24"}
+	if got := dRead.Get("unmanaged_lines").([]interface{}); !reflect.DeepEqual(got, wantUnmanaged) {
+		t.Errorf("unmanaged_lines after drift = %q, want %q", got, wantUnmanaged)
+	}
+
+	dUpdate := updateFakeDeviceResource(t, r, dRead, config, sess)
+	if got := dUpdate.Get("lines").([]interface{}); !reflect.DeepEqual(got, config["lines"]) {
+		t.Errorf("lines after update = %q, want %q", got, config["lines"])
+	}
+	committed := strings.Join(fake.Committed(), "\n")
+	if !strings.Contains(committed, "198.51.100.0/24") || !strings.Contains(committed, "clients 192.0.2.0/25") {
+		t.Errorf("missing line not set again or unmanaged line removed, committed = %q", fake.Committed())
+	}
+
+	if diags := r.DeleteContext(ctx, dUpdate, sess); diags.HasError() {
+		t.Fatalf("delete error = %v", diags)
+	}
+	want := []string{
+		"set snmp community other authorization read-only",
+		"set snmp community drift clients 198.51.100.0/24",
+	}
+	if got := fake.Committed(); !reflect.DeepEqual(got, want) {
+		t.Errorf("committed after delete = %q, want %q", got, want)
+	}
+}
+
+func TestResourceConfigDelete(t *testing.T) {
+	fake, err := StartFakeDevice("vsrx", "20.2R1.10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fake.Close()
+	sess := newFakeDeviceSession(t, fake)
+	sess.junosOwnershipMarker = true
+	ctx := context.Background()
+	r := Provider().ResourcesMap["junos_config"]
+
+	config := map[string]interface{}{
+		"hierarchy": "snmp community c1",
+		"lines":     []interface{}{"clients 192.0.2.0/25"},
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(ctx, d, sess); diags.HasError() {
+		t.Fatalf("create error = %v", diags)
+	}
+	// a statement added under hierarchy outside Terraform is kept on destroy (after a refresh)
+	fake.SetCommitted(append(fake.Committed(), "set snmp community c1 authorization read-only"))
+	if diags := r.ReadContext(ctx, d, sess); diags.HasError() {
+		t.Fatalf("read error = %v", diags)
+	}
+	if diags := r.DeleteContext(ctx, d, sess); diags.HasError() {
+		t.Fatalf("delete error = %v", diags)
+	}
+	want := []string{"set snmp community c1 authorization read-only"}
+	if got := fake.Committed(); !reflect.DeepEqual(got, want) {
+		t.Errorf("committed after delete = %q, want %q", got, want)
+	}
+}
+
+func TestResourceConfigCreateUnderExistingHierarchy(t *testing.T) {
+	fake, err := StartFakeDevice("vsrx", "20.2R1.10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fake.Close()
+	sess := newFakeDeviceSession(t, fake)
+	ctx := context.Background()
+	r := Provider().ResourcesMap["junos_config"]
+	fake.SetCommitted([]string{"set system services ssh"})
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"hierarchy": "system services",
+		"lines":     []interface{}{"netconf ssh"},
+	})
+	if diags := r.CreateContext(ctx, d, sess); diags.HasError() {
+		t.Fatalf("create under a hierarchy with other statements error = %v", diags)
+	}
+	if got := d.Get("unmanaged_lines").([]interface{}); !reflect.DeepEqual(got, []interface{}{"ssh"}) {
+		t.Errorf("unmanaged_lines = %q, want [ssh]", got)
+	}
+
+	dAgain := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"hierarchy": "system services",
+		"lines":     []interface{}{"netconf ssh"},
+	})
+	if diags := r.CreateContext(ctx, dAgain, sess); !diags.HasError() {
+		t.Error("create with a line already on device without error")
+	}
+}
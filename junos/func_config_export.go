@@ -0,0 +1,94 @@
+package junos
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const configExportSecretRemoved = "secret-removed"
+
+// normalizeConfigExport remove the timestamps (and user of commit) and/or the secrets
+// in the configuration exported in a format.
+func normalizeConfigExport(content, format string, stripTimestamps, stripSecrets bool) (string, error) {
+	if stripTimestamps {
+		switch format {
+		case commandFormatXML:
+			// attributes with time of commit or change of configuration
+			content = regexp.MustCompile(`\s+junos:(changed|commit)-(seconds|localtime|user)="[^"]*"`).
+				ReplaceAllString(content, "")
+		case commandFormatJSON:
+			var err error
+			content, err = stripConfigExportTimestampJSON(content)
+			if err != nil {
+				return "", err
+			}
+		default:
+			lines := make([]string, 0)
+			for _, line := range strings.Split(content, "\n") {
+				if strings.HasPrefix(line, "## Last commit: ") || strings.HasPrefix(line, "## Last changed: ") {
+					continue
+				}
+				lines = append(lines, line)
+			}
+			content = strings.Join(lines, "\n")
+		}
+	}
+	if stripSecrets {
+		// encrypted secrets ($9$ encoded) and hashes (md5, sha256, sha512 crypt)
+		content = regexp.MustCompile(`\$[0-9]\$[^"<\s;]*`).ReplaceAllString(content, configExportSecretRemoved)
+	}
+
+	return content, nil
+}
+
+// stripConfigExportTimestampJSON remove the attributes with time of commit or change in JSON format
+// (the keys of objects are sorted in output).
+func stripConfigExportTimestampJSON(content string) (string, error) {
+	decoder := json.NewDecoder(strings.NewReader(content))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return "", fmt.Errorf("failed to decode json : %w", err)
+	}
+	stripConfigExportTimestampValue(value)
+	var output bytes.Buffer
+	encoder := json.NewEncoder(&output)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "    ")
+	if err := encoder.Encode(value); err != nil {
+		return "", fmt.Errorf("failed to encode json : %w", err)
+	}
+
+	return strings.TrimSpace(output.String()), nil
+}
+
+func stripConfigExportTimestampValue(value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if configExportTimestampJSONKey(key) {
+				delete(v, key)
+
+				continue
+			}
+			stripConfigExportTimestampValue(child)
+		}
+	case []interface{}:
+		for _, child := range v {
+			stripConfigExportTimestampValue(child)
+		}
+	}
+}
+
+func configExportTimestampJSONKey(key string) bool {
+	for _, prefix := range []string{"junos:changed-", "junos:commit-"} {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
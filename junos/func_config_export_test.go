@@ -0,0 +1,65 @@
+package junos
+
+import (
+	"testing"
+)
+
+func TestNormalizeConfigExport(t *testing.T) {
+	cases := []struct {
+		name    string
+		content string
+		format  string
+		want    string
+	}{
+		{
+			name:    "set",
+			content: "set system radius-server 192.0.2.1 secret \"$9$Hk5FCA0IEyrvMX\"",
+			format:  configExportFormatSet,
+			want:    "set system radius-server 192.0.2.1 secret \"secret-removed\"",
+		},
+		{
+			name: "text",
+			content: "## Last commit: 2021-05-10 10:20:30 UTC by netconf\n" +
+				"system {\n    root-authentication {\n        encrypted-password \"$6$abc$def\"; ## SECRET-DATA\n    }\n}",
+			format: commandFormatText,
+			want: "system {\n    root-authentication {\n" +
+				"        encrypted-password \"secret-removed\"; ## SECRET-DATA\n    }\n}",
+		},
+		{
+			name: "xml",
+			content: `<configuration junos:commit-seconds="1620642030" junos:commit-localtime="2021-05-10 10:20:30 UTC"` +
+				` junos:commit-user="netconf"><system junos:changed-seconds="1620642030"` +
+				` junos:changed-localtime="2021-05-10 10:20:30 UTC"><radius-server><name>192.0.2.1</name>` +
+				`<secret>$9$Hk5FCA0IEyrvMX</secret></radius-server></system></configuration>`,
+			format: commandFormatXML,
+			want: `<configuration><system><radius-server><name>192.0.2.1</name>` +
+				`<secret>secret-removed</secret></radius-server></system></configuration>`,
+		},
+		{
+			name: "json",
+			content: `{"configuration":{"@":{"junos:commit-seconds":"1620642030","junos:commit-user":"netconf"},` +
+				`"system":{"@":{"junos:changed-seconds":"1620642030"},"host-name":"fake","port":830}}}`,
+			format: commandFormatJSON,
+			want: "{\n    \"configuration\": {\n        \"@\": {},\n        \"system\": {\n            \"@\": {},\n" +
+				"            \"host-name\": \"fake\",\n            \"port\": 830\n        }\n    }\n}",
+		},
+	}
+	for _, c := range cases {
+		got, err := normalizeConfigExport(c.content, c.format, true, true)
+		if err != nil {
+			t.Errorf("normalizeConfigExport() %s error = %v", c.name, err)
+
+			continue
+		}
+		if got != c.want {
+			t.Errorf("normalizeConfigExport() %s = %q, want %q", c.name, got, c.want)
+		}
+	}
+	secret := "secret \"$9$abc\""
+	if got, _ := normalizeConfigExport(secret, configExportFormatSet, true, false); got != secret {
+		t.Errorf("normalizeConfigExport() without strip_secrets = %q", got)
+	}
+	if _, err := normalizeConfigExport("{", commandFormatJSON, true, false); err == nil {
+		t.Errorf("normalizeConfigExport() with invalid json doesn't return error")
+	}
+}
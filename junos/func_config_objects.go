@@ -0,0 +1,295 @@
+package junos
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// configObjectType : objects of a resource found in configuration with patterns of 'display set' lines
+// ('*' is a word captured for the id of object), the function to generate the id of object
+// (in format of the resource importer) from captured words and the function to set the configuration of resource.
+// An empty id exclude the line from resource.
+type configObjectType struct {
+	resource string
+	patterns [][]string
+	id       func(captured []string) string
+	set      func(*schema.ResourceData, interface{}, *NetconfObject) error
+}
+
+// configObject : an object of resource found in configuration with the indexes of its lines.
+type configObject struct {
+	resource string
+	id       string
+	lines    []int
+}
+
+// configObjectTypes : resources with objects found in configuration.
+// The first type with a pattern matching a line has this line, so the order matters
+// (a neighbor before its bgp group, a logical interface before its physical interface, ...).
+func configObjectTypes() []configObjectType {
+	routes := func(statement string) [][]string {
+		return [][]string{
+			{"routing-options", statement, "route", "*"},
+			{"routing-options", "rib", "inet6.0", statement, "route", "*"},
+			{"routing-instances", "*", "routing-options", statement, "route", "*"},
+			{"routing-instances", "*", "routing-options", "rib", "*", statement, "route", "*"},
+		}
+	}
+	routeID := func(captured []string) string {
+		switch len(captured) {
+		case 1:
+			return captured[0] + idSeparator + defaultWord
+		case 2:
+			return captured[1] + idSeparator + captured[0]
+		default:
+			return captured[2] + idSeparator + captured[0]
+		}
+	}
+	nameID := func(captured []string) string {
+		return captured[0]
+	}
+
+	return []configObjectType{
+		{
+			resource: "junos_application",
+			patterns: [][]string{{"applications", "application", "*"}},
+			id:       nameID,
+			set:      setApplication,
+		},
+		{
+			resource: "junos_application_set",
+			patterns: [][]string{{"applications", "application-set", "*"}},
+			id:       nameID,
+			set:      setApplicationSet,
+		},
+		{
+			resource: "junos_bgp_neighbor",
+			patterns: [][]string{
+				{"protocols", "bgp", "group", "*", "neighbor", "*"},
+				{"routing-instances", "*", "protocols", "bgp", "group", "*", "neighbor", "*"},
+			},
+			id: func(captured []string) string {
+				if len(captured) == 2 {
+					return captured[1] + idSeparator + defaultWord + idSeparator + captured[0]
+				}
+
+				return captured[2] + idSeparator + captured[0] + idSeparator + captured[1]
+			},
+			set: setBgpNeighbor,
+		},
+		{
+			resource: "junos_bgp_group",
+			patterns: [][]string{
+				{"protocols", "bgp", "group", "*"},
+				{"routing-instances", "*", "protocols", "bgp", "group", "*"},
+			},
+			id: func(captured []string) string {
+				if len(captured) == 1 {
+					return captured[0] + idSeparator + defaultWord
+				}
+
+				return captured[1] + idSeparator + captured[0]
+			},
+			set: setBgpGroup,
+		},
+		{
+			resource: "junos_aggregate_route",
+			patterns: routes("aggregate"),
+			id:       routeID,
+			set:      setAggregateRoute,
+		},
+		{
+			resource: "junos_generate_route",
+			patterns: routes("generate"),
+			id:       routeID,
+			set:      setGenerateRoute,
+		},
+		{
+			resource: "junos_static_route",
+			patterns: routes("static"),
+			id:       routeID,
+			set:      setStaticRoute,
+		},
+		{
+			resource: "junos_firewall_filter",
+			patterns: [][]string{{"firewall", "family", "*", "filter", "*"}},
+			id: func(captured []string) string {
+				return captured[1] + idSeparator + captured[0]
+			},
+			set: setFirewallFilter,
+		},
+		{
+			resource: "junos_interface_logical",
+			patterns: [][]string{{"interfaces", "*", "unit", "*"}},
+			id: func(captured []string) string {
+				if captured[0] == "interface-range" {
+					return ""
+				}
+
+				return captured[0] + "." + captured[1]
+			},
+			set: setInterfaceLogical,
+		},
+		{
+			resource: "junos_interface_physical",
+			patterns: [][]string{{"interfaces", "*"}},
+			id: func(captured []string) string {
+				if captured[0] == "interface-range" {
+					return ""
+				}
+
+				return captured[0]
+			},
+			set: setInterfacePhysical,
+		},
+		{
+			resource: "junos_policyoptions_as_path",
+			patterns: [][]string{{"policy-options", "as-path", "*"}},
+			id:       nameID,
+			set:      setPolicyoptionsAsPath,
+		},
+		{
+			resource: "junos_policyoptions_community",
+			patterns: [][]string{{"policy-options", "community", "*"}},
+			id:       nameID,
+			set:      setPolicyoptionsCommunity,
+		},
+		{
+			resource: "junos_policyoptions_policy_statement",
+			patterns: [][]string{{"policy-options", "policy-statement", "*"}},
+			id:       nameID,
+			set:      setPolicyStatement,
+		},
+		{
+			resource: "junos_policyoptions_prefix_list",
+			patterns: [][]string{{"policy-options", "prefix-list", "*"}},
+			id:       nameID,
+			set:      setPolicyoptionsPrefixList,
+		},
+		{
+			resource: "junos_security_address_book",
+			patterns: [][]string{{"security", "address-book", "*"}},
+			id:       nameID,
+			set:      setAddressBook,
+		},
+		{
+			resource: "junos_security_policy",
+			patterns: [][]string{{"security", "policies", "from-zone", "*", "to-zone", "*"}},
+			id: func(captured []string) string {
+				return captured[0] + idSeparator + captured[1]
+			},
+			set: setSecurityPolicy,
+		},
+		{
+			resource: "junos_security_zone",
+			patterns: [][]string{{"security", "zones", "security-zone", "*"}},
+			id:       nameID,
+			set:      setSecurityZone,
+		},
+		{
+			resource: "junos_routing_instance",
+			patterns: [][]string{{"routing-instances", "*"}},
+			id:       nameID,
+			set:      setRoutingInstance,
+		},
+	}
+}
+
+// configObjectsFromLines find the objects of resources in lines of configuration ('display set' format),
+// objects are in order of their first line and the indexes of lines without object are returned
+// (a line of object isn't necessarily read by resource, see configObjectLinesNotSet).
+func configObjectsFromLines(lines []string, types []configObjectType) ([]configObject, []int) {
+	objects := make([]configObject, 0)
+	objectIndex := make(map[string]int)
+	uncovered := make([]int, 0)
+	for i, line := range lines {
+		words := splitSetLineWords(line)
+		if len(words) < 2 {
+			continue
+		}
+		resource, id := configObjectOfWords(words[1:], types)
+		if id == "" {
+			uncovered = append(uncovered, i)
+
+			continue
+		}
+		key := resource + " " + id
+		if index, ok := objectIndex[key]; ok {
+			objects[index].lines = append(objects[index].lines, i)
+
+			continue
+		}
+		objectIndex[key] = len(objects)
+		objects = append(objects, configObject{resource: resource, id: id, lines: []int{i}})
+	}
+
+	return objects, uncovered
+}
+
+// configObjectOfWords return the resource and id of object with a pattern matching words (without first word).
+func configObjectOfWords(words []string, types []configObjectType) (string, string) {
+	for _, objectType := range types {
+		for _, pattern := range objectType.patterns {
+			captured, ok := configObjectMatch(words, pattern)
+			if !ok {
+				continue
+			}
+
+			return objectType.resource, objectType.id(captured)
+		}
+	}
+
+	return "", ""
+}
+
+func configObjectMatch(words, pattern []string) ([]string, bool) {
+	if len(words) < len(pattern) {
+		return nil, false
+	}
+	captured := make([]string, 0)
+	for i, p := range pattern {
+		word := strings.Trim(words[i], "\"")
+		switch {
+		case p == "*":
+			captured = append(captured, word)
+		case p != word:
+			return nil, false
+		}
+	}
+
+	return captured, true
+}
+
+// configObjectSetLines return the lines generated by the set function of an object type
+// with the configuration of resource in d (lines are recorded, not sent to device).
+func configObjectSetLines(
+	set func(*schema.ResourceData, interface{}, *NetconfObject) error, d *schema.ResourceData, sess *Session,
+) ([]string, error) {
+	lines := make([]string, 0)
+	sessRecord := *sess
+	sessRecord.junosConfigSetRecord = &lines
+	sessRecord.junosOwnershipWrite = nil
+	if err := set(d, &sessRecord, nil); err != nil {
+		return nil, err
+	}
+
+	return lines, nil
+}
+
+// configObjectLinesNotSet return the indexes of lines of objects not generated again by the set functions
+// of resources (statements ignored by the read of resources).
+func configObjectLinesNotSet(lines []string, indexes []int, setLines []string) []int {
+	keys := make(map[string]bool)
+	for _, line := range setLines {
+		keys[configDecodedLineKey(line)] = true
+	}
+	notSet := make([]int, 0)
+	for _, i := range indexes {
+		if !keys[configDecodedLineKey(lines[i])] {
+			notSet = append(notSet, i)
+		}
+	}
+
+	return notSet
+}
@@ -0,0 +1,572 @@
+package junos
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const rpcGetConfigSchema = "<get-xnm-information><type>xml-schema</type>" +
+	"<namespace>junos-configuration</namespace></get-xnm-information>"
+
+// configSchemaCache : configuration schemas already parsed, by model and Junos version.
+type configSchemaCache struct {
+	mutex   sync.Mutex
+	schemas map[string]*configSchemaNode
+}
+
+// xsdNode : element of xml schema file (only the necessary).
+type xsdNode struct {
+	name     string
+	attrs    map[string]string
+	children []*xsdNode
+	text     string
+}
+
+// configSchemaNode : a keyword of Junos configuration with the information to check set lines.
+type configSchemaNode struct {
+	children  map[string]*configSchemaNode
+	keys      []*configSchemaNode
+	noKeyword []*configSchemaNode
+	name      string
+	baseType  string
+	enums     []string
+	min       *int64
+	max       *int64
+	list      bool
+	leaf      bool
+	value     bool
+	// for lazy build of children
+	xsd    *xsdNode
+	schema *configSchema
+	built  bool
+}
+
+type configSchema struct {
+	complexTypes map[string]*xsdNode
+	simpleTypes  map[string]*xsdNode
+	elements     map[string]*xsdNode
+}
+
+// addCustomizeDiffSchemaValidation add the check of set lines against the configuration schema of device
+// at plan time on resources which generate configuration.
+// It need to be called before the other wrappers of CreateContext to record only the set lines of resource.
+func addCustomizeDiffSchemaValidation(resources map[string]*schema.Resource) {
+	for resourceName, resource := range resources {
+		// junos_interface_st0_unit need the device to find the name of interface to create
+		if resourceName == "junos_interface_st0_unit" ||
+			stringInSlice(resourceName, listOfResourcesWithoutConfig()) ||
+			resource.CreateContext == nil {
+			continue
+		}
+		if resource.CustomizeDiff != nil {
+			resource.CustomizeDiff = customdiff.All(resource.CustomizeDiff,
+				customizeDiffSchemaValidation(resource, resource.CreateContext))
+		} else {
+			resource.CustomizeDiff = customizeDiffSchemaValidation(resource, resource.CreateContext)
+		}
+	}
+}
+
+// customizeDiffSchemaValidation generate a CustomizeDiff func to record the set lines of the planned
+// configuration (with the create in fake mode) and check them against the configuration schema of device,
+// before any change in the candidate configuration.
+func customizeDiffSchemaValidation(
+	resource *schema.Resource, create schema.CreateContextFunc,
+) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		sess := m.(*Session)
+		if sess.junosSchemaCacheDir == "" || sess.junosFakeCreateSetFile != "" {
+			return nil
+		}
+		if d.Id() != "" && !resourceDiffHasChanges(resource, d) {
+			return nil
+		}
+		data, ok := resourceDataFromDiff(resource, d)
+		if !ok {
+			// values unknown at plan time, lines are checked on next plan
+			return nil
+		}
+		lines := make([]string, 0)
+		sessRecord := *sess
+		sessRecord.junosConfigSetRecord = &lines
+		sessRecord.junosOwnershipWrite = nil
+		sessRecord.junosFakeCreateSetFile = "schema-validation"
+		if diags := create(ctx, data, &sessRecord); diags.HasError() {
+			for _, v := range diags {
+				if v.Severity == diag.Error {
+					return fmt.Errorf("%s", v.Summary)
+				}
+			}
+		}
+		if len(lines) == 0 {
+			return nil
+		}
+		jnprSess, err := sess.startNewSession()
+		if err != nil {
+			return err
+		}
+		defer sess.closeSession(jnprSess)
+
+		return sess.validateSetLinesWithSchema(lines, jnprSess)
+	}
+}
+
+// resourceDiffConfigKeys return the keys of schema of resource set by configuration.
+func resourceDiffConfigKeys(resource *schema.Resource) []string {
+	keys := make([]string, 0, len(resource.Schema))
+	for k, v := range resource.Schema {
+		if v.Optional || v.Required {
+			keys = append(keys, k)
+		}
+	}
+
+	return keys
+}
+
+func resourceDiffHasChanges(resource *schema.Resource, d *schema.ResourceDiff) bool {
+	for _, k := range resourceDiffConfigKeys(resource) {
+		if d.HasChange(k) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resourceDataFromDiff build a ResourceData with the planned values of ResourceDiff
+// (return false if a value is unknown).
+func resourceDataFromDiff(resource *schema.Resource, d *schema.ResourceDiff) (*schema.ResourceData, bool) {
+	data := resource.Data(nil)
+	for _, k := range resourceDiffConfigKeys(resource) {
+		if !d.NewValueKnown(k) {
+			return nil, false
+		}
+		if tfErr := data.Set(k, d.Get(k)); tfErr != nil {
+			panic(tfErr)
+		}
+	}
+
+	return data, true
+}
+
+// validateSetLinesWithSchema check the set lines against the configuration schema of device.
+func (sess *Session) validateSetLinesWithSchema(lines []string, jnprSess *NetconfObject) error {
+	// lock for the whole validation because nodes of schema are built when walking
+	sess.junosSchemaCache.mutex.Lock()
+	defer sess.junosSchemaCache.mutex.Unlock()
+	root, err := sess.configSchema(jnprSess)
+	if err != nil {
+		return err
+	}
+	errs := make([]string, 0)
+	for _, line := range lines {
+		if err := root.validateSetLine(line); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("set lines don't match configuration schema of device :\n%s", strings.Join(errs, "\n"))
+	}
+
+	return nil
+}
+
+// configSchema return configuration schema of device from memory, from file in cache directory
+// or download it with <get-xnm-information> and write it in cache directory
+// (not with gnmi transport, the file need to be already in cache directory).
+// junosSchemaCache.mutex need to be locked by caller.
+func (sess *Session) configSchema(jnprSess *NetconfObject) (*configSchemaNode, error) {
+	key := strings.ToLower(jnprSess.SystemInformation.HardwareModel) + "_" + jnprSess.SystemInformation.OsVersion
+	if root, ok := sess.junosSchemaCache.schemas[key]; ok {
+		return root, nil
+	}
+	schemaFile := path.Join(sess.junosSchemaCacheDir, "junos-configuration_"+key+".xsd")
+	var schemaXML []byte
+	if _, err := os.Stat(schemaFile); err == nil {
+		schemaXML, err = ioutil.ReadFile(schemaFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not read file `%s` : %w", schemaFile, err)
+		}
+	} else {
+		if sess.junosTransport == transportGNMI {
+			return nil, fmt.Errorf("configuration schema can't be downloaded with gnmi transport, "+
+				"file `%s` need to be added in cache directory", schemaFile)
+		}
+		reply, err := sess.commandXML(rpcGetConfigSchema, jnprSess)
+		if err != nil {
+			return nil, fmt.Errorf("failed to download configuration schema : %w", err)
+		}
+		schemaXML = []byte(reply)
+		if _, err := os.Stat(sess.junosSchemaCacheDir); err != nil {
+			if err := os.MkdirAll(sess.junosSchemaCacheDir, os.FileMode(directoryPermission)); err != nil {
+				return nil, fmt.Errorf("failed to create directory `%s` : %w", sess.junosSchemaCacheDir, err)
+			}
+		}
+		if err := ioutil.WriteFile(schemaFile, schemaXML, os.FileMode(sess.junosFilePermission)); err != nil {
+			return nil, fmt.Errorf("failed to write file `%s` : %w", schemaFile, err)
+		}
+		sess.logFile(fmt.Sprintf("[configSchema] schema written in %s", schemaFile))
+	}
+	root, err := parseConfigSchema(schemaXML)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse configuration schema : %w", err)
+	}
+	if sess.junosSchemaCache.schemas == nil {
+		sess.junosSchemaCache.schemas = make(map[string]*configSchemaNode)
+	}
+	sess.junosSchemaCache.schemas[key] = root
+
+	return root, nil
+}
+
+// parseConfigSchema read the xml schema and return the 'configuration' node.
+func parseConfigSchema(schemaXML []byte) (*configSchemaNode, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(schemaXML))
+	var stack []*xsdNode
+	var schemaRoot *xsdNode
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch tok := token.(type) {
+		case xml.StartElement:
+			node := &xsdNode{name: tok.Name.Local, attrs: make(map[string]string)}
+			for _, attr := range tok.Attr {
+				node.attrs[attr.Name.Local] = attr.Value
+			}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				if parent.name != "documentation" {
+					parent.children = append(parent.children, node)
+				}
+			} else if node.name != "schema" {
+				// ignore elements around schema (rpc-reply)
+				continue
+			}
+			if node.name == "schema" && schemaRoot == nil {
+				schemaRoot = node
+				stack = stack[:0]
+			}
+			stack = append(stack, node)
+		case xml.CharData:
+			if len(stack) > 0 && stack[len(stack)-1].name == "flag" {
+				stack[len(stack)-1].text += string(tok)
+			}
+		case xml.EndElement:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+	if schemaRoot == nil {
+		return nil, errors.New("schema element not found")
+	}
+	schema := &configSchema{
+		complexTypes: make(map[string]*xsdNode),
+		simpleTypes:  make(map[string]*xsdNode),
+		elements:     make(map[string]*xsdNode),
+	}
+	for _, child := range schemaRoot.children {
+		switch child.name {
+		case "complexType":
+			schema.complexTypes[child.attrs["name"]] = child
+		case "simpleType":
+			schema.simpleTypes[child.attrs["name"]] = child
+		case "element":
+			schema.elements[child.attrs["name"]] = child
+		}
+	}
+	configuration, ok := schema.elements["configuration"]
+	if !ok {
+		return nil, errors.New("configuration element not found in schema")
+	}
+
+	return schema.newNode(configuration), nil
+}
+
+func (schema *configSchema) newNode(element *xsdNode) *configSchemaNode {
+	node := &configSchemaNode{
+		name:   element.attrs["name"],
+		list:   element.attrs["maxOccurs"] == "unbounded",
+		xsd:    element,
+		schema: schema,
+	}
+	if typeName := element.attrs["type"]; typeName != "" {
+		if complexType, ok := schema.complexTypes[typeName]; ok {
+			node.xsd = &xsdNode{name: "element", attrs: element.attrs, children: []*xsdNode{complexType}}
+		} else {
+			node.leaf = true
+			node.value = true
+			if simpleType, ok := schema.simpleTypes[typeName]; ok {
+				node.readSimpleType(simpleType)
+			} else {
+				node.baseType = strings.TrimPrefix(typeName, "xsd:")
+			}
+		}
+
+		return node
+	}
+	for _, child := range element.children {
+		switch child.name {
+		case "simpleType":
+			node.leaf = true
+			node.value = true
+			node.readSimpleType(child)
+		case "complexType":
+			if len(child.children) == 0 || (len(child.children) == 1 && child.children[0].name == "annotation") {
+				// element without value (flag)
+				node.leaf = true
+			}
+			for _, c := range child.children {
+				if c.name == "simpleContent" {
+					node.leaf = true
+					node.value = true
+				}
+			}
+		}
+	}
+
+	return node
+}
+
+func (node *configSchemaNode) readSimpleType(simpleType *xsdNode) {
+	for _, restriction := range simpleType.children {
+		if restriction.name != "restriction" {
+			continue
+		}
+		node.baseType = strings.TrimPrefix(restriction.attrs["base"], "xsd:")
+		if simpleTypeBase, ok := node.schema.simpleTypes[restriction.attrs["base"]]; ok {
+			node.readSimpleType(simpleTypeBase)
+		}
+		for _, facet := range restriction.children {
+			switch facet.name {
+			case "enumeration":
+				node.enums = append(node.enums, facet.attrs["value"])
+			case "minInclusive":
+				if v, err := strconv.ParseInt(facet.attrs["value"], 10, 64); err == nil {
+					node.min = &v
+				}
+			case "maxInclusive":
+				if v, err := strconv.ParseInt(facet.attrs["value"], 10, 64); err == nil {
+					node.max = &v
+				}
+			}
+		}
+	}
+}
+
+// build read children of node when necessary.
+func (node *configSchemaNode) build() {
+	if node.built {
+		return
+	}
+	node.built = true
+	node.children = make(map[string]*configSchemaNode)
+	if node.leaf {
+		return
+	}
+	var walk func(x *xsdNode)
+	walk = func(x *xsdNode) {
+		for _, child := range x.children {
+			switch child.name {
+			case "element":
+				element := child
+				if ref := child.attrs["ref"]; ref != "" {
+					refElement, ok := node.schema.elements[ref]
+					if !ok {
+						continue
+					}
+					element = refElement
+				}
+				childNode := node.schema.newNode(element)
+				node.children[childNode.name] = childNode
+				flags := xsdElementFlags(element)
+				if stringInSlice("identifier", flags) {
+					node.keys = append(node.keys, childNode)
+				} else if stringInSlice("nokeyword", flags) {
+					node.noKeyword = append(node.noKeyword, childNode)
+				}
+			case "complexType", "sequence", "choice", "all", "complexContent", "extension":
+				walk(child)
+			}
+		}
+	}
+	walk(node.xsd)
+	if node.list && len(node.keys) == 0 {
+		if name, ok := node.children["name"]; ok {
+			node.keys = append(node.keys, name)
+		}
+	}
+}
+
+// xsdElementFlags return Junos flags in annotation of element (identifier, nokeyword, ...).
+func xsdElementFlags(element *xsdNode) []string {
+	flags := make([]string, 0)
+	for _, annotation := range element.children {
+		if annotation.name != "annotation" {
+			continue
+		}
+		for _, appinfo := range annotation.children {
+			for _, flag := range appinfo.children {
+				if flag.name == "flag" {
+					flags = append(flags, strings.TrimSpace(flag.text))
+				}
+			}
+		}
+	}
+
+	return flags
+}
+
+// validateSetLine walk the words of a set line in the schema.
+// When the syntax can't be resolved without ambiguity (multiple keys),
+// the rest of line is not checked to avoid false errors.
+func (node *configSchemaNode) validateSetLine(line string) error {
+	words := splitSetLineWords(line)
+	if len(words) == 0 || words[0] != "set" {
+		return nil
+	}
+	words = words[1:]
+	current := node
+	keyRead := false
+	noKeywordRead := 0
+	for i := 0; i < len(words); i++ {
+		word := words[i]
+		current.build()
+		if current.list && !keyRead {
+			if len(current.keys) != 1 {
+				return nil
+			}
+			if err := current.keys[0].validateValue(word); err != nil {
+				return fmt.Errorf("%s : %w", line, err)
+			}
+			keyRead = true
+
+			continue
+		}
+		child, ok := current.children[word]
+		if !ok || current.isKey(child) {
+			if noKeywordRead < len(current.noKeyword) {
+				if err := current.noKeyword[noKeywordRead].validateValue(word); err != nil {
+					return fmt.Errorf("%s : %w", line, err)
+				}
+				noKeywordRead++
+
+				continue
+			}
+			if current.leaf {
+				return fmt.Errorf("%s : unexpected word '%s' after '%s'", line, word, current.name)
+			}
+
+			return fmt.Errorf("%s : unknown keyword '%s' under '%s'", line, word, current.name)
+		}
+		current = child
+		keyRead = false
+		noKeywordRead = 0
+		if current.leaf && current.value {
+			if i+1 >= len(words) {
+				return nil
+			}
+			i++
+			if words[i] == "[" {
+				for i++; i < len(words) && words[i] != "]"; i++ {
+					if err := current.validateValue(words[i]); err != nil {
+						return fmt.Errorf("%s : %w", line, err)
+					}
+				}
+
+				continue
+			}
+			if err := current.validateValue(words[i]); err != nil {
+				return fmt.Errorf("%s : %w", line, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (node *configSchemaNode) isKey(child *configSchemaNode) bool {
+	for _, key := range node.keys {
+		if key == child {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateValue check value of a leaf with enumeration and range of integer.
+func (node *configSchemaNode) validateValue(value string) error {
+	value = strings.Trim(value, "\"")
+	if len(node.enums) > 0 && !stringInSlice(value, node.enums) {
+		return fmt.Errorf("value '%s' for '%s' is not one of %q", value, node.name, node.enums)
+	}
+	switch node.baseType {
+	case "byte", "short", "int", "integer", "long",
+		"unsignedByte", "unsignedShort", "unsignedInt", "unsignedLong":
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("value '%s' for '%s' is not an integer", value, node.name)
+		}
+		if node.min != nil && v < *node.min {
+			return fmt.Errorf("value '%s' for '%s' is lower than %d", value, node.name, *node.min)
+		}
+		if node.max != nil && v > *node.max {
+			return fmt.Errorf("value '%s' for '%s' is greater than %d", value, node.name, *node.max)
+		}
+	}
+
+	return nil
+}
+
+// splitSetLineWords split line on spaces, except inside double quotes.
+func splitSetLineWords(line string) []string {
+	words := make([]string, 0)
+	var word strings.Builder
+	inQuote := false
+	escaped := false
+	for _, r := range line {
+		switch {
+		case escaped:
+			escaped = false
+			word.WriteRune(r)
+		case r == '\\':
+			escaped = true
+			word.WriteRune(r)
+		case r == '"':
+			inQuote = !inQuote
+			word.WriteRune(r)
+		case r == ' ' && !inQuote:
+			if word.Len() > 0 {
+				words = append(words, word.String())
+				word.Reset()
+			}
+		default:
+			word.WriteRune(r)
+		}
+	}
+	if word.Len() > 0 {
+		words = append(words, word.String())
+	}
+
+	return words
+}
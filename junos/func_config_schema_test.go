@@ -0,0 +1,125 @@
+package junos
+
+import (
+	"testing"
+)
+
+const testConfigSchemaXML = `<rpc-reply>
+<xsd:schema xmlns:xsd="http://www.w3.org/2001/XMLSchema">
+  <xsd:element name="configuration">
+    <xsd:complexType>
+      <xsd:sequence>
+        <xsd:choice minOccurs="0" maxOccurs="unbounded">
+          <xsd:element name="routing-options" type="juniper-routing-options" minOccurs="0"/>
+          <xsd:element name="system" minOccurs="0">
+            <xsd:complexType>
+              <xsd:sequence>
+                <xsd:element name="host-name" minOccurs="0">
+                  <xsd:simpleType>
+                    <xsd:restriction base="xsd:string"/>
+                  </xsd:simpleType>
+                </xsd:element>
+              </xsd:sequence>
+            </xsd:complexType>
+          </xsd:element>
+        </xsd:choice>
+      </xsd:sequence>
+    </xsd:complexType>
+  </xsd:element>
+  <xsd:complexType name="juniper-routing-options">
+    <xsd:sequence>
+      <xsd:element name="static" minOccurs="0">
+        <xsd:complexType>
+          <xsd:sequence>
+            <xsd:element name="route" minOccurs="0" maxOccurs="unbounded">
+              <xsd:complexType>
+                <xsd:sequence>
+                  <xsd:element name="name" type="ipprefix">
+                    <xsd:annotation>
+                      <xsd:documentation>Destination IP address or prefix</xsd:documentation>
+                      <xsd:appinfo><flag>identifier</flag></xsd:appinfo>
+                    </xsd:annotation>
+                  </xsd:element>
+                  <xsd:element name="next-hop" type="xsd:string" minOccurs="0" maxOccurs="unbounded"/>
+                  <xsd:element name="discard" minOccurs="0">
+                    <xsd:complexType/>
+                  </xsd:element>
+                  <xsd:element name="preference" minOccurs="0">
+                    <xsd:simpleType>
+                      <xsd:restriction base="xsd:unsignedInt">
+                        <xsd:minInclusive value="0"/>
+                        <xsd:maxInclusive value="4294967295"/>
+                      </xsd:restriction>
+                    </xsd:simpleType>
+                  </xsd:element>
+                  <xsd:element name="as-path" minOccurs="0">
+                    <xsd:complexType>
+                      <xsd:sequence>
+                        <xsd:element name="origin" minOccurs="0">
+                          <xsd:simpleType>
+                            <xsd:restriction base="xsd:string">
+                              <xsd:enumeration value="igp"/>
+                              <xsd:enumeration value="egp"/>
+                              <xsd:enumeration value="incomplete"/>
+                            </xsd:restriction>
+                          </xsd:simpleType>
+                        </xsd:element>
+                        <xsd:element name="aggregator" minOccurs="0">
+                          <xsd:complexType>
+                            <xsd:sequence>
+                              <xsd:element name="as-number" type="xsd:string">
+                                <xsd:annotation><xsd:appinfo><flag>nokeyword</flag></xsd:appinfo></xsd:annotation>
+                              </xsd:element>
+                              <xsd:element name="address" type="xsd:string">
+                                <xsd:annotation><xsd:appinfo><flag>nokeyword</flag></xsd:appinfo></xsd:annotation>
+                              </xsd:element>
+                            </xsd:sequence>
+                          </xsd:complexType>
+                        </xsd:element>
+                      </xsd:sequence>
+                    </xsd:complexType>
+                  </xsd:element>
+                </xsd:sequence>
+              </xsd:complexType>
+            </xsd:element>
+          </xsd:sequence>
+        </xsd:complexType>
+      </xsd:element>
+    </xsd:sequence>
+  </xsd:complexType>
+  <xsd:simpleType name="ipprefix">
+    <xsd:restriction base="xsd:string"/>
+  </xsd:simpleType>
+</xsd:schema>
+</rpc-reply>`
+
+func TestConfigSchemaValidateSetLine(t *testing.T) {
+	root, err := parseConfigSchema([]byte(testConfigSchemaXML))
+	if err != nil {
+		t.Fatalf("parseConfigSchema() error = %v", err)
+	}
+	tests := []struct {
+		line    string
+		wantErr bool
+	}{
+		{"set system host-name \"test host\"", false},
+		{"set routing-options static route 192.0.2.0/24 next-hop 192.0.2.254", false},
+		{"set routing-options static route 192.0.2.0/24 discard", false},
+		{"set routing-options static route 192.0.2.0/24 preference 100", false},
+		{"set routing-options static route 192.0.2.0/24 as-path origin igp", false},
+		{"set routing-options static route 192.0.2.0/24 as-path aggregator 65000 192.0.2.1", false},
+		{"delete routing-options static route 192.0.2.0/24 unknown", false},
+		{"set routing-options static route 192.0.2.0/24 next-hops 192.0.2.254", true},
+		{"set routing-options static route 192.0.2.0/24 discard now", true},
+		{"set routing-options static route 192.0.2.0/24 preference -1", true},
+		{"set routing-options static route 192.0.2.0/24 preference high", true},
+		{"set routing-options static route 192.0.2.0/24 as-path origin bgp", true},
+		{"set routing-options statics route 192.0.2.0/24", true},
+	}
+	for _, tt := range tests {
+		err := root.validateSetLine(tt.line)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("validateSetLine(%s) error = %v, wantErr %v", tt.line, err, tt.wantErr)
+		}
+	}
+}
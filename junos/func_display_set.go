@@ -0,0 +1,78 @@
+package junos
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	showConfigurationWords = "show configuration"
+	displaySetWords        = "| display set"
+	displaySetRelative     = "relative"
+)
+
+// displaySetFilter : hierarchy and format of a 'show configuration ... | display set' command.
+type displaySetFilter struct {
+	hierarchy []string
+	relative  bool
+}
+
+// parseShowConfigurationCommand read hierarchy and options of a 'show configuration ... | display set' command.
+func parseShowConfigurationCommand(cmd string) (displaySetFilter, error) {
+	var filter displaySetFilter
+	if !strings.HasPrefix(cmd, showConfigurationWords) || !strings.Contains(cmd, displaySetWords) {
+		return filter, fmt.Errorf("command '%s' not supported (only '%s ... %s')",
+			cmd, showConfigurationWords, displaySetWords)
+	}
+	cmdSplit := strings.SplitN(strings.TrimPrefix(cmd, showConfigurationWords), displaySetWords, 2)
+	switch strings.TrimSpace(cmdSplit[1]) {
+	case "":
+	case displaySetRelative:
+		filter.relative = true
+	default:
+		return filter, fmt.Errorf("option '%s' not supported in command '%s'", strings.TrimSpace(cmdSplit[1]), cmd)
+	}
+	for _, word := range splitSetLineWords(cmdSplit[0]) {
+		filter.hierarchy = append(filter.hierarchy, strings.Trim(word, "\""))
+	}
+
+	return filter, nil
+}
+
+// apply select the lines under hierarchy and return the output like the device.
+// The first word of lines (set, deactivate, protect, ...) is kept
+// and a line equal to hierarchy is displayed with this word followed by a space in relative mode.
+func (filter displaySetFilter) apply(lines []string) string {
+	output := make([]string, 0)
+	for _, line := range lines {
+		words := splitSetLineWords(line)
+		if len(words) < len(filter.hierarchy)+1 {
+			continue
+		}
+		match := true
+		for i, word := range filter.hierarchy {
+			if strings.Trim(words[i+1], "\"") != word {
+				match = false
+
+				break
+			}
+		}
+		if !match {
+			continue
+		}
+		if filter.relative {
+			if len(words) == len(filter.hierarchy)+1 {
+				output = append(output, words[0]+" ")
+			} else {
+				output = append(output, words[0]+" "+strings.Join(words[len(filter.hierarchy)+1:], " "))
+			}
+		} else {
+			output = append(output, line)
+		}
+	}
+	if len(output) == 0 {
+		return emptyWord
+	}
+
+	return "\n" + strings.Join(output, "\n") + "\n"
+}
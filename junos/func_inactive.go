@@ -0,0 +1,227 @@
+package junos
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	deactivateLineStart = "deactivate "
+	protectLineStart    = "protect "
+)
+
+// readStatements : status of statements in the configuration of the object read by a resource,
+// it's the first 'show configuration ... | display set' command of read
+// (the next commands read the other objects linked to the resource).
+// The object is the hierarchy of command or, with roots, the statements under it
+// (like the policies of a resource in the list of a zone pair).
+type readStatements struct {
+	recorded  bool
+	inactive  bool
+	protected bool
+	marker    ownershipMarker
+	roots     [][]string
+}
+
+// record check the output of command if it's the first configuration command of read.
+func (s *readStatements) record(cmd, output string) {
+	if s.recorded {
+		return
+	}
+	filter, err := parseShowConfigurationCommand(cmd)
+	if err != nil {
+		return
+	}
+	s.recorded = true
+	roots := s.roots
+	if len(roots) == 0 {
+		roots = [][]string{{}}
+	}
+	s.inactive, s.protected = displaySetStatementsStatus(filter, roots, output)
+	s.marker = readOwnershipMarker(cmd, output)
+}
+
+// readStatementsRoots set the root statements of object read by a resource, relative to the hierarchy of
+// the first configuration command of read, when the object isn't the entire hierarchy.
+// It need to be called before this command.
+func (sess *Session) readStatementsRoots(roots ...string) {
+	if sess.junosReadStatements == nil {
+		return
+	}
+	sess.junosReadStatements.roots = make([][]string, 0, len(roots))
+	for _, root := range roots {
+		sess.junosReadStatements.roots = append(sess.junosReadStatements.roots, splitSetLineWords(root))
+	}
+}
+
+// displaySetStatementsStatus detect in output of 'display set' of filter if a root statement is deactivated
+// (not the statements under it) and if statements are protected.
+func displaySetStatementsStatus(filter displaySetFilter, roots [][]string, output string) (inactive, protected bool) {
+	for _, item := range strings.Split(output, "\n") {
+		switch {
+		case item == strings.TrimSuffix(deactivateLineStart, " ") || strings.HasPrefix(item, deactivateLineStart):
+			words := splitSetLineWords(strings.TrimPrefix(item+" ", deactivateLineStart))
+			if !filter.relative {
+				if !wordsHasPrefix(words, filter.hierarchy) {
+					continue
+				}
+				words = words[len(filter.hierarchy):]
+			}
+			for _, root := range roots {
+				if len(words) == len(root) && wordsHasPrefix(words, root) {
+					inactive = true
+				}
+			}
+		case item == strings.TrimSuffix(protectLineStart, " ") || strings.HasPrefix(item, protectLineStart):
+			protected = true
+		}
+	}
+
+	return inactive, protected
+}
+
+// addInactiveAttribute add the computed 'inactive' attribute on resources
+// with read and import wrapped to set it from the statements of object on device
+// and a diff to set it back to false when it's true.
+// Resources with their own 'inactive' argument (see configSetInactive) only have read and import wrapped.
+func addInactiveAttribute(resources map[string]*schema.Resource) {
+	for resourceName, resource := range resources {
+		if stringInSlice(resourceName, listOfResourcesWithoutConfig()) {
+			continue
+		}
+		if resource.Schema == nil {
+			resource.Schema = make(map[string]*schema.Schema)
+		}
+		if _, ok := resource.Schema["inactive"]; !ok {
+			resource.Schema["inactive"] = &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			}
+			resource.CreateContext = writeContextWithActiveStatements(resource.CreateContext)
+			resource.UpdateContext = writeContextWithActiveStatements(resource.UpdateContext)
+			if resource.CustomizeDiff != nil {
+				resource.CustomizeDiff = customdiff.All(resource.CustomizeDiff,
+					customizeDiffInactive(resource.UpdateContext == nil))
+			} else {
+				resource.CustomizeDiff = customizeDiffInactive(resource.UpdateContext == nil)
+			}
+		}
+		resource.ReadContext = readContextWithStatements(resourceName, resource.ReadContext)
+		if resource.Importer != nil && resource.Importer.State != nil {
+			resource.Importer.State = importStateWithStatements(resourceName, resource.Importer.State)
+		}
+	}
+}
+
+// customizeDiffInactive generate a diff to set the computed 'inactive' attribute to false
+// when statements are deactivated on device, the configuration of resource is set again by update
+// (or by replace of resource if it can't be updated).
+func customizeDiffInactive(forceNew bool) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		if d.Id() == "" || !d.Get("inactive").(bool) {
+			return nil
+		}
+		if err := d.SetNew("inactive", false); err != nil {
+			return err
+		}
+		if forceNew {
+			return d.ForceNew("inactive")
+		}
+
+		return nil
+	}
+}
+
+// configSetInactive append to configSet the line to deactivate the hierarchy of setPrefix
+// if 'inactive' argument is true or to activate it when argument change to false.
+func configSetInactive(d *schema.ResourceData, setPrefix string, configSet []string) []string {
+	hierarchy := strings.TrimSpace(strings.TrimPrefix(setPrefix, setLineStart))
+	if d.Get("inactive").(bool) {
+		return append(configSet, deactivateLineStart+hierarchy)
+	}
+	if !d.IsNewResource() && d.HasChange("inactive") {
+		return append(configSet, "activate "+hierarchy)
+	}
+
+	return configSet
+}
+
+// sessionWithReadStatements copy the session to record the status of statements read.
+func sessionWithReadStatements(m interface{}) (interface{}, *readStatements) {
+	sess, ok := m.(*Session)
+	if !ok {
+		return m, nil
+	}
+	statements := &readStatements{}
+	sessRead := *sess
+	sessRead.junosReadStatements = statements
+
+	return &sessRead, statements
+}
+
+// writeContextWithActiveStatements set 'inactive' to false after create or update
+// (configuration of resource is set again without deactivated statements).
+func writeContextWithActiveStatements(
+	write func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics,
+) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	if write == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		diags := write(ctx, d, m)
+		if !diags.HasError() && d.Id() != "" {
+			if tfErr := d.Set("inactive", false); tfErr != nil {
+				panic(tfErr)
+			}
+		}
+
+		return diags
+	}
+}
+
+func readContextWithStatements(resourceName string, read schema.ReadContextFunc) schema.ReadContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		sessRead, statements := sessionWithReadStatements(m)
+		diags := read(ctx, d, sessRead)
+		if diags.HasError() || d.Id() == "" || statements == nil {
+			return diags
+		}
+		if tfErr := d.Set("inactive", statements.inactive); tfErr != nil {
+			panic(tfErr)
+		}
+		if statements.protected {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "configuration of " + d.Id() + " has protected statements, it can't be modified or deleted",
+			})
+		}
+		diags = append(diags, ownershipReadDiags(resourceName, d, m, statements.marker)...)
+
+		return diags
+	}
+}
+
+func importStateWithStatements(resourceName string, importState schema.StateFunc) schema.StateFunc {
+	return func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		sessRead, statements := sessionWithReadStatements(m)
+		result, err := importState(d, sessRead)
+		if err != nil || statements == nil {
+			return result, err
+		}
+		if err := ownershipImportCheck(resourceName, d, m, statements.marker); err != nil {
+			return nil, err
+		}
+		for _, r := range result {
+			if tfErr := r.Set("inactive", statements.inactive); tfErr != nil {
+				panic(tfErr)
+			}
+		}
+
+		return result, nil
+	}
+}
@@ -0,0 +1,206 @@
+package junos
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestDisplaySetStatementsStatus(t *testing.T) {
+	relative := displaySetFilter{hierarchy: []string{"routing-options", "static", "route", "192.0.2.0/24"}, relative: true}
+	full := displaySetFilter{hierarchy: []string{"routing-options", "static", "route", "192.0.2.0/24"}}
+	cases := []struct {
+		filter    displaySetFilter
+		roots     [][]string
+		output    string
+		inactive  bool
+		protected bool
+	}{
+		{filter: relative, output: "\nset next-hop 192.0.2.254\n", inactive: false, protected: false},
+		{filter: relative, output: "\nset next-hop 192.0.2.254\ndeactivate \n", inactive: true, protected: false},
+		{filter: relative, output: "\nset next-hop 192.0.2.254\ndeactivate next-hop 192.0.2.254\n"},
+		{filter: relative, output: "\nset next-hop 192.0.2.254\nprotect \n", inactive: false, protected: true},
+		{filter: relative, output: "\nset description \"deactivate protect\"\n", inactive: false, protected: false},
+		{
+			filter: full,
+			output: "\nset routing-options static route 192.0.2.0/24 next-hop 192.0.2.254\n" +
+				"deactivate routing-options static route 192.0.2.0/24\n",
+			inactive: true,
+		},
+		{
+			filter: full,
+			output: "\nset routing-options static route 192.0.2.0/24 next-hop 192.0.2.254\n" +
+				"deactivate routing-options static route 192.0.2.0/24 next-hop 192.0.2.254\n",
+		},
+		{
+			filter:   relative,
+			roots:    [][]string{{"policy", "p1"}},
+			output:   "\nset policy p1 then permit\nset policy p2 then permit\ndeactivate policy p1\n",
+			inactive: true,
+		},
+		{
+			filter: relative,
+			roots:  [][]string{{"policy", "p1"}},
+			output: "\nset policy p1 then permit\nset policy p2 then permit\ndeactivate policy p2\n",
+		},
+	}
+	for _, c := range cases {
+		roots := c.roots
+		if len(roots) == 0 {
+			roots = [][]string{{}}
+		}
+		inactive, protected := displaySetStatementsStatus(c.filter, roots, c.output)
+		if inactive != c.inactive || protected != c.protected {
+			t.Errorf("displaySetStatementsStatus(%q) = %v, %v, want %v, %v",
+				c.output, inactive, protected, c.inactive, c.protected)
+		}
+	}
+}
+
+func TestInactiveAttribute(t *testing.T) {
+	fake, err := StartFakeDevice("vsrx", "20.2R1.10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fake.Close()
+	sess := newFakeDeviceSession(t, fake)
+	ctx := context.Background()
+	r := Provider().ResourcesMap["junos_static_route"]
+
+	fake.SetCommitted([]string{
+		"set routing-options static route 192.0.2.0/24 next-hop 192.0.2.254",
+		"deactivate routing-options static route 192.0.2.0/24",
+		"set routing-options static route 198.51.100.0/24 next-hop 192.0.2.254",
+		"deactivate routing-options static route 198.51.100.0/24 next-hop 192.0.2.254",
+		"set routing-options static route 203.0.113.0/24 next-hop 192.0.2.254",
+		"protect routing-options static route 203.0.113.0/24",
+	})
+	for destination, want := range map[string]bool{
+		"192.0.2.0/24":    true,
+		"198.51.100.0/24": false,
+		"203.0.113.0/24":  false,
+	} {
+		d := r.Data(nil)
+		d.SetId(destination + idSeparator + defaultWord)
+		if err := d.Set("destination", destination); err != nil {
+			t.Fatal(err)
+		}
+		if err := d.Set("routing_instance", defaultWord); err != nil {
+			t.Fatal(err)
+		}
+		diags := r.ReadContext(ctx, d, sess)
+		if diags.HasError() {
+			t.Fatalf("read of %s error = %v", destination, diags)
+		}
+		if d.Get("inactive").(bool) != want {
+			t.Errorf("inactive of %s = %v, want %v", destination, d.Get("inactive"), want)
+		}
+		if warn := len(diags) > 0 && diags[0].Severity == diag.Warning; warn != (destination == "203.0.113.0/24") {
+			t.Errorf("read of %s diagnostics = %v", destination, diags)
+		}
+
+		dImport := r.Data(nil)
+		dImport.SetId(destination + idSeparator + defaultWord)
+		imported, err := r.Importer.State(dImport, sess)
+		if err != nil {
+			t.Fatalf("import of %s error = %v", destination, err)
+		}
+		if imported[0].Get("inactive").(bool) != want {
+			t.Errorf("inactive of %s after import = %v, want %v", destination, imported[0].Get("inactive"), want)
+		}
+	}
+}
+
+func TestInactiveAttributeDiff(t *testing.T) {
+	r := Provider().ResourcesMap["junos_static_route"]
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"destination": "192.0.2.0/24",
+		"next_hop":    []interface{}{"192.0.2.254"},
+	})
+	for inactive, want := range map[string]bool{"true": true, "false": false} {
+		state := &terraform.InstanceState{
+			ID: "192.0.2.0/24" + idSeparator + defaultWord,
+			Attributes: map[string]string{
+				"id":               "192.0.2.0/24" + idSeparator + defaultWord,
+				"destination":      "192.0.2.0/24",
+				"routing_instance": defaultWord,
+				"next_hop.#":       "1",
+				"next_hop.0":       "192.0.2.254",
+				"inactive":         inactive,
+			},
+		}
+		diff, err := r.Diff(context.Background(), state, config, &Session{})
+		if err != nil {
+			t.Fatalf("diff with inactive %s error = %v", inactive, err)
+		}
+		if got := diff != nil && diff.Attributes["inactive"] != nil; got != want {
+			t.Errorf("diff with inactive %s has change of inactive = %v, want %v", inactive, got, want)
+		}
+	}
+}
+
+func TestInactiveArgument(t *testing.T) {
+	fake, err := StartFakeDevice("vsrx", "20.2R1.10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fake.Close()
+	sess := newFakeDeviceSession(t, fake)
+	ctx := context.Background()
+	r := Provider().ResourcesMap["junos_bgp_group"]
+
+	config := map[string]interface{}{
+		"name":     "group1",
+		"type":     "external",
+		"peer_as":  "65001",
+		"inactive": true,
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(ctx, d, sess); diags.HasError() {
+		t.Fatalf("create error = %v", diags)
+	}
+	if !stringInSlice("deactivate protocols bgp group group1", fake.Committed()) {
+		t.Errorf("group not deactivated after create, committed = %v", fake.Committed())
+	}
+	if !d.Get("inactive").(bool) {
+		t.Error("inactive = false after create, want true")
+	}
+
+	config["inactive"] = false
+	dUpdate := updateFakeDeviceResource(t, r, d, config, sess)
+	for _, line := range fake.Committed() {
+		if strings.HasPrefix(line, deactivateLineStart) {
+			t.Errorf("line '%s' not removed by update", line)
+		}
+	}
+	if dUpdate.Get("inactive").(bool) {
+		t.Error("inactive = true after update, want false")
+	}
+
+	// statement under the group deactivated outside Terraform
+	fake.SetCommitted(append(fake.Committed(), "deactivate protocols bgp group group1 peer-as"))
+	if diags := r.ReadContext(ctx, dUpdate, sess); diags.HasError() {
+		t.Fatalf("read error = %v", diags)
+	}
+	if dUpdate.Get("inactive").(bool) {
+		t.Error("inactive = true after deactivate of a statement under group, want false")
+	}
+	// group deactivated outside Terraform
+	fake.SetCommitted(append(fake.Committed(), "deactivate protocols bgp group group1"))
+	if diags := r.ReadContext(ctx, dUpdate, sess); diags.HasError() {
+		t.Fatalf("read error = %v", diags)
+	}
+	if !dUpdate.Get("inactive").(bool) {
+		t.Error("inactive = false after deactivate of group, want true")
+	}
+	updateFakeDeviceResource(t, r, dUpdate, config, sess)
+	for _, line := range fake.Committed() {
+		if strings.HasPrefix(line, deactivateLineStart) {
+			t.Errorf("line '%s' not removed by update", line)
+		}
+	}
+}
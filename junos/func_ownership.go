@@ -0,0 +1,258 @@
+package junos
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	ownershipMacroWords    = "apply-macro terraform"
+	ownershipImportError   = "error"
+	ownershipImportWarning = "warning"
+)
+
+// ownershipWrite : hierarchies read and set lines loaded by create or update of a resource
+// to find the hierarchy of object where the ownership marker is written.
+type ownershipWrite struct {
+	resource    string
+	id          string
+	hierarchies [][]string
+	setLines    [][]string
+}
+
+// ownershipMarker : ownership marker read in configuration of an object.
+type ownershipMarker struct {
+	found     bool
+	resource  string
+	id        string
+	workspace string
+}
+
+// recordCommand record the hierarchy of a 'show configuration ... | display set' command.
+func (o *ownershipWrite) recordCommand(cmd string) {
+	filter, err := parseShowConfigurationCommand(cmd)
+	if err != nil || len(filter.hierarchy) == 0 {
+		return
+	}
+	o.hierarchies = append(o.hierarchies, filter.hierarchy)
+}
+
+// recordLines record the hierarchies deleted and the set lines.
+func (o *ownershipWrite) recordLines(lines []string) {
+	for _, line := range lines {
+		switch {
+		case strings.HasPrefix(line, deleteLineStart):
+			o.hierarchies = append(o.hierarchies, splitSetLineWords(strings.TrimPrefix(line, deleteLineStart)))
+		case strings.HasPrefix(line, setLineStart):
+			o.setLines = append(o.setLines, splitSetLineWords(strings.TrimPrefix(line, setLineStart)))
+		}
+	}
+}
+
+// hierarchy return the longest hierarchy (read or deleted) with all set lines under it.
+func (o *ownershipWrite) hierarchy() []string {
+	var result []string
+	if len(o.setLines) == 0 {
+		return result
+	}
+	for _, hierarchy := range o.hierarchies {
+		if len(hierarchy) <= len(result) {
+			continue
+		}
+		under := true
+		for _, words := range o.setLines {
+			if !wordsHasPrefix(words, hierarchy) {
+				under = false
+
+				break
+			}
+		}
+		if under {
+			result = hierarchy
+		}
+	}
+
+	return result
+}
+
+// ownershipMarkerLines generate the set lines of the ownership marker for the object written.
+func (sess *Session) ownershipMarkerLines() []string {
+	hierarchy := sess.junosOwnershipWrite.hierarchy()
+	if len(hierarchy) == 0 {
+		sess.logFile(fmt.Sprintf("[ownershipMarker] hierarchy of object not found for %s",
+			sess.junosOwnershipWrite.resource))
+
+		return nil
+	}
+	setPrefix := setLineStart + strings.Join(hierarchy, " ") + " " + ownershipMacroWords + " "
+	lines := []string{setPrefix + "resource " + sess.junosOwnershipWrite.resource}
+	if sess.junosOwnershipWrite.id != "" {
+		lines = append(lines, setPrefix+"id \""+sess.junosOwnershipWrite.id+"\"")
+	}
+	if sess.junosOwnershipWorkspace != "" {
+		lines = append(lines, setPrefix+"workspace \""+sess.junosOwnershipWorkspace+"\"")
+	}
+
+	return lines
+}
+
+// readOwnershipMarker read the ownership marker at the top of object in output of 'display set' command.
+func readOwnershipMarker(cmd, output string) ownershipMarker {
+	var marker ownershipMarker
+	filter, err := parseShowConfigurationCommand(cmd)
+	if err != nil {
+		return marker
+	}
+	hierarchy := filter.hierarchy
+	if filter.relative {
+		hierarchy = nil
+	}
+	macroWords := strings.Split(ownershipMacroWords, " ")
+	for _, item := range strings.Split(output, "\n") {
+		if !strings.HasPrefix(item, setLineStart) {
+			continue
+		}
+		words := splitSetLineWords(strings.TrimPrefix(item, setLineStart))
+		if len(words) != len(hierarchy)+len(macroWords)+2 || !wordsHasPrefix(words, hierarchy) ||
+			!wordsHasPrefix(words[len(hierarchy):], macroWords) {
+			continue
+		}
+		value := strings.Trim(words[len(words)-1], "\"")
+		switch words[len(words)-2] {
+		case "resource":
+			marker.found = true
+			marker.resource = value
+		case "id":
+			marker.found = true
+			marker.id = value
+		case "workspace":
+			marker.found = true
+			marker.workspace = value
+		}
+	}
+
+	return marker
+}
+
+// ownershipMismatch return the reason if the object is owned by another resource type, workspace or resource id.
+func (sess *Session) ownershipMismatch(resourceName, id string, marker ownershipMarker) string {
+	if !sess.junosOwnershipMarker || !marker.found {
+		return ""
+	}
+	if marker.workspace != sess.junosOwnershipWorkspace {
+		return fmt.Sprintf("owned by Terraform workspace '%s'", marker.workspace)
+	}
+	if marker.resource != "" && marker.resource != resourceName {
+		return fmt.Sprintf("owned by Terraform resource %s", marker.resource)
+	}
+	if marker.id != "" && marker.id != id {
+		return fmt.Sprintf("owned by Terraform resource with id '%s'", marker.id)
+	}
+
+	return ""
+}
+
+// addOwnershipMarker wrap create and update of resources to write the ownership marker
+// on object when it's enabled on provider.
+func addOwnershipMarker(resources map[string]*schema.Resource) {
+	for resourceName, resource := range resources {
+		if stringInSlice(resourceName, listOfResourcesWithoutConfig()) {
+			continue
+		}
+		// junos_interface_st0_unit find the name of interface (its id) on device when create
+		createID := resourceName != "junos_interface_st0_unit"
+		resource.CreateContext = writeContextWithOwnership(resourceName, resource, createID, resource.CreateContext)
+		resource.UpdateContext = writeContextWithOwnership(resourceName, resource, false, resource.UpdateContext)
+	}
+}
+
+func writeContextWithOwnership(
+	resourceName string, resource *schema.Resource, createID bool,
+	write func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics,
+) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	if write == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		sess, ok := m.(*Session)
+		if !ok || !sess.junosOwnershipMarker || sess.junosFakeCreateSetFile != "" {
+			return write(ctx, d, m)
+		}
+		sessWrite := *sess
+		sessWrite.junosOwnershipWrite = &ownershipWrite{resource: resourceName, id: d.Id()}
+		if createID {
+			sessWrite.junosOwnershipWrite.id = ownershipCreateID(ctx, resource, write, d, sess)
+		}
+
+		return write(ctx, d, &sessWrite)
+	}
+}
+
+// ownershipCreateID generate the id of resource before create to write it in ownership marker
+// with the create in fake mode on a copy of resource (the lines are only recorded).
+func ownershipCreateID(
+	ctx context.Context, resource *schema.Resource,
+	create func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics,
+	d *schema.ResourceData, sess *Session,
+) string {
+	data := resource.Data(nil)
+	for _, k := range resourceDiffConfigKeys(resource) {
+		if tfErr := data.Set(k, d.Get(k)); tfErr != nil {
+			panic(tfErr)
+		}
+	}
+	lines := make([]string, 0)
+	sessRecord := *sess
+	sessRecord.junosConfigSetRecord = &lines
+	sessRecord.junosOwnershipWrite = nil
+	sessRecord.junosFakeCreateSetFile = "ownership-marker"
+	if diags := create(ctx, data, &sessRecord); diags.HasError() {
+		return ""
+	}
+
+	return data.Id()
+}
+
+// ownershipReadDiags generate a warning when the object read is owned by another resource type or workspace.
+func ownershipReadDiags(
+	resourceName string, d *schema.ResourceData, m interface{}, marker ownershipMarker,
+) diag.Diagnostics {
+	sess, ok := m.(*Session)
+	if !ok {
+		return nil
+	}
+	if mismatch := sess.ownershipMismatch(resourceName, d.Id(), marker); mismatch != "" {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "configuration of " + d.Id() + " is " + mismatch,
+		}}
+	}
+
+	return nil
+}
+
+// ownershipImportCheck refuse (or only log a warning) the import of an object
+// owned by another resource type or workspace.
+func ownershipImportCheck(resourceName string, d *schema.ResourceData, m interface{}, marker ownershipMarker) error {
+	sess, ok := m.(*Session)
+	if !ok {
+		return nil
+	}
+	mismatch := sess.ownershipMismatch(resourceName, d.Id(), marker)
+	if mismatch == "" {
+		return nil
+	}
+	if sess.junosOwnershipImport == ownershipImportWarning {
+		log.Printf("[WARN] import of %s with id %s: configuration is %s", resourceName, d.Id(), mismatch)
+
+		return nil
+	}
+
+	return fmt.Errorf("configuration of %s with id %s is %s", resourceName, d.Id(), mismatch)
+}
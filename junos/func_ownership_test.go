@@ -0,0 +1,138 @@
+package junos
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestReadOwnershipMarker(t *testing.T) {
+	cases := []struct {
+		cmd    string
+		output string
+		want   ownershipMarker
+	}{
+		{
+			cmd:    "show configuration routing-options static route 192.0.2.0/24 | display set relative",
+			output: "\nset next-hop 192.0.2.254\n",
+			want:   ownershipMarker{},
+		},
+		{
+			cmd: "show configuration routing-options static route 192.0.2.0/24 | display set relative",
+			output: "\nset next-hop 192.0.2.254\nset apply-macro terraform resource junos_static_route\n" +
+				"set apply-macro terraform workspace \"ws 1\"\n",
+			want: ownershipMarker{found: true, resource: "junos_static_route", workspace: "ws 1"},
+		},
+		{
+			cmd: "show configuration protocols bgp group group1 | display set",
+			output: "\nset protocols bgp group group1 type external\n" +
+				"set protocols bgp group group1 neighbor 192.0.2.1 apply-macro terraform resource junos_bgp_neighbor\n",
+			want: ownershipMarker{},
+		},
+		{
+			cmd: "show configuration protocols bgp group group1 | display set",
+			output: "\nset protocols bgp group group1 type external\n" +
+				"set protocols bgp group group1 apply-macro terraform resource junos_bgp_group\n",
+			want: ownershipMarker{found: true, resource: "junos_bgp_group"},
+		},
+		{
+			cmd: "show configuration protocols bgp group group1 | display set",
+			output: "\nset protocols bgp group group1 type external\n" +
+				"set protocols bgp group group1 apply-macro terraform resource junos_bgp_group\n" +
+				"set protocols bgp group group1 apply-macro terraform id group1_-_default\n",
+			want: ownershipMarker{found: true, resource: "junos_bgp_group", id: "group1_-_default"},
+		},
+	}
+	for _, c := range cases {
+		if got := readOwnershipMarker(c.cmd, c.output); got != c.want {
+			t.Errorf("readOwnershipMarker(%q, %q) = %+v, want %+v", c.cmd, c.output, got, c.want)
+		}
+	}
+}
+
+func TestOwnershipMarker(t *testing.T) {
+	fake, err := StartFakeDevice("vsrx", "20.2R1.10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fake.Close()
+	sess := newFakeDeviceSession(t, fake)
+	sess.junosOwnershipMarker = true
+	sess.junosOwnershipWorkspace = "ws1"
+	sess.junosOwnershipImport = ownershipImportError
+	ctx := context.Background()
+	r := Provider().ResourcesMap["junos_static_route"]
+
+	fake.SetCommitted([]string{
+		"set routing-instances ri1 instance-type virtual-router",
+	})
+	config := map[string]interface{}{
+		"destination":      "192.0.2.0/24",
+		"routing_instance": "ri1",
+		"next_hop":         []interface{}{"192.0.2.254"},
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(ctx, d, sess); diags.HasError() {
+		t.Fatalf("create error = %v", diags)
+	}
+	prefix := "set routing-instances ri1 routing-options static route 192.0.2.0/24 "
+	wantCommitted := []string{
+		"set routing-instances ri1 instance-type virtual-router",
+		prefix + "next-hop 192.0.2.254",
+		prefix + "apply-macro terraform resource junos_static_route",
+		prefix + "apply-macro terraform id 192.0.2.0/24_-_ri1",
+		prefix + "apply-macro terraform workspace ws1",
+	}
+	if committed := fake.Committed(); !reflect.DeepEqual(committed, wantCommitted) {
+		t.Errorf("committed after create = %q, want %q", committed, wantCommitted)
+	}
+
+	config["next_hop"] = []interface{}{"192.0.2.253"}
+	d = updateFakeDeviceResource(t, r, d, config, sess)
+	markers := 0
+	for _, line := range fake.Committed() {
+		if strings.Contains(line, ownershipMacroWords) {
+			markers++
+		}
+	}
+	if markers != 3 {
+		t.Errorf("committed after update = %q, want ownership marker on route", fake.Committed())
+	}
+	if diags := r.ReadContext(ctx, d, sess); len(diags) != 0 {
+		t.Errorf("read diagnostics = %v, want none", diags)
+	}
+
+	dOtherID := r.Data(d.State())
+	dOtherID.SetId("192.0.2.0/24" + idSeparator + "ri2")
+	diags := r.ReadContext(ctx, dOtherID, sess)
+	if len(diags) != 1 || diags[0].Severity != diag.Warning ||
+		!strings.Contains(diags[0].Summary, "id '192.0.2.0/24_-_ri1'") {
+		t.Errorf("read diagnostics with other id = %v, want a warning", diags)
+	}
+
+	sessOther := *sess
+	sessOther.junosOwnershipWorkspace = "ws2"
+	diags = r.ReadContext(ctx, d, &sessOther)
+	if len(diags) != 1 || diags[0].Severity != diag.Warning ||
+		!strings.Contains(diags[0].Summary, "workspace 'ws1'") {
+		t.Errorf("read diagnostics with other workspace = %v, want a warning", diags)
+	}
+	dImport := r.Data(nil)
+	dImport.SetId("192.0.2.0/24" + idSeparator + "ri1")
+	if _, err := r.Importer.State(dImport, &sessOther); err == nil {
+		t.Error("import with other workspace without error")
+	}
+	sessOther.junosOwnershipImport = ownershipImportWarning
+	dImport = r.Data(nil)
+	dImport.SetId("192.0.2.0/24" + idSeparator + "ri1")
+	if _, err := r.Importer.State(dImport, &sessOther); err != nil {
+		t.Errorf("import with other workspace in warning mode error = %v", err)
+	}
+	if _, err := r.Importer.State(dImport, sess); err != nil {
+		t.Errorf("import with same workspace error = %v", err)
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	jdecode "github.com/jeremmfr/junosdecode"
 )
 
 type bgpOptions struct {
@@ -149,7 +148,7 @@ func setBgpOptsSimple(setPrefix string, d *schema.ResourceData, m interface{}, j
 		configSet = append(configSet, setPrefix+"authentication-algorithm "+d.Get("authentication_algorithm").(string))
 	}
 	if d.Get("authentication_key").(string) != "" {
-		configSet = append(configSet, setPrefix+"authentication-key "+d.Get("authentication_key").(string))
+		configSet = append(configSet, setPrefix+"authentication-key \""+d.Get("authentication_key").(string)+"\"")
 	}
 	if d.Get("authentication_key_chain").(string) != "" {
 		configSet = append(configSet, setPrefix+"authentication-key-chain "+d.Get("authentication_key_chain").(string))
@@ -297,7 +296,7 @@ func readBgpOptsSimple(item string, confRead *bgpOptions) error {
 		confRead.authenticationAlgorithm = strings.TrimPrefix(item, "authentication-algorithm ")
 	case strings.HasPrefix(item, "authentication-key "):
 		var err error
-		confRead.authenticationKey, err = jdecode.Decode(strings.Trim(strings.TrimPrefix(item, "authentication-key "), "\""))
+		confRead.authenticationKey, err = decodeSecret(strings.Trim(strings.TrimPrefix(item, "authentication-key "), "\""))
 		if err != nil {
 			return fmt.Errorf("failed to decode authentication-key : %w", err)
 		}
@@ -0,0 +1,237 @@
+package junos
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const secretEncodedPrefix = "$9$"
+
+// secretHashPrefixes : prefixes of one-way hashes (md5, sha256, sha512 crypt) used by device for some secrets,
+// the plain text of a hash can't be compared with the configured value.
+func secretHashPrefixes() []string {
+	return []string{"$1$", "$5$", "$6$"}
+}
+
+// decodeSecret decode a secret read on device: a '$9$' value is decoded to the plain text,
+// a hash (see secretHashPrefixes) is returned as is (unverifiable) and other values are plain text.
+func decodeSecret(value string) (string, error) {
+	value = strings.Trim(value, "\"")
+	if strings.HasPrefix(value, secretEncodedPrefix) {
+		return jdecodeSecret(value)
+	}
+
+	return value, nil
+}
+
+// secretIsHash check if the value of secret is a one-way hash.
+func secretIsHash(value string) bool {
+	for _, prefix := range secretHashPrefixes() {
+		if strings.HasPrefix(value, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// secretDiffSuppress suppress the diff of a secret when the configured value is the '$9$' encoded form of value
+// in state.
+func secretDiffSuppress(k, oldValue, newValue string, d *schema.ResourceData) bool {
+	if oldValue == "" || newValue == "" {
+		return false
+	}
+	if strings.HasPrefix(newValue, secretEncodedPrefix) {
+		if decoded, err := jdecodeSecret(newValue); err == nil && decoded == oldValue {
+			return true
+		}
+	}
+
+	return false
+}
+
+// addSecretHandling add secretDiffSuppress on the sensitive string arguments of resources,
+// wrap create, read and update to decode the '$9$' secrets left by resource and keep the configured value
+// in state when a secret read on device is a hash (with a warning on read) and wrap import to decode secrets.
+func addSecretHandling(resources map[string]*schema.Resource) {
+	for _, resource := range resources {
+		names := make(map[string]bool)
+		addSecretDiffSuppress(resource.Schema, names)
+		if len(names) == 0 {
+			continue
+		}
+		if resource.CreateContext != nil {
+			resource.CreateContext = contextWithSecretKeep(resource.Schema, false, resource.CreateContext)
+		}
+		if resource.UpdateContext != nil {
+			resource.UpdateContext = contextWithSecretKeep(resource.Schema, false, resource.UpdateContext)
+		}
+		resource.ReadContext = contextWithSecretKeep(resource.Schema, true, resource.ReadContext)
+		if resource.Importer != nil && resource.Importer.State != nil {
+			resource.Importer.State = importWithSecretDecode(resource.Schema, resource.Importer.State)
+		}
+	}
+}
+
+func addSecretDiffSuppress(schemaMap map[string]*schema.Schema, names map[string]bool) {
+	for name, s := range schemaMap {
+		switch {
+		case s.Type == schema.TypeString && s.Sensitive && !s.Computed:
+			names[name] = true
+			if s.DiffSuppressFunc == nil {
+				s.DiffSuppressFunc = secretDiffSuppress
+			}
+		case s.Type == schema.TypeList || s.Type == schema.TypeSet:
+			if elem, ok := s.Elem.(*schema.Resource); ok {
+				addSecretDiffSuppress(elem.Schema, names)
+			}
+		}
+	}
+}
+
+// contextWithSecretKeep wrap a create, read or update function: the values of secrets are taken with d.Get
+// before the call and restored after when the value read on device is a hash.
+func contextWithSecretKeep(schemaMap map[string]*schema.Schema, warn bool,
+	f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics,
+) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		before := make(map[string]string)
+		secretValues(schemaMap, "", d, before)
+		diags := f(ctx, d, m)
+		if diags.HasError() || d.Id() == "" {
+			return diags
+		}
+		if err := decodeSecretValues(schemaMap, d); err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+		after := make(map[string]string)
+		secretValues(schemaMap, "", d, after)
+		keys := make([]string, 0)
+		for k, v := range after {
+			if secretIsHash(v) {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if v := before[k]; v != "" && !secretIsHash(v) {
+				if err := setSecretValue(d, k, v); err != nil {
+					return append(diags, diag.FromErr(err)...)
+				}
+			}
+			if warn {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  "secret " + k + " of " + d.Id() + " is a hash on device, it can't be verified",
+					Detail: "the configured value is kept in state, " +
+						"a change of this secret outside Terraform can't be detected",
+				})
+			}
+		}
+
+		return diags
+	}
+}
+
+// importWithSecretDecode wrap an import function to decode the '$9$' secrets of imported resources
+// (a hash is kept in state and reported by the read after import).
+func importWithSecretDecode(schemaMap map[string]*schema.Schema, f schema.StateFunc) schema.StateFunc {
+	return func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		results, err := f(d, m)
+		if err != nil {
+			return results, err
+		}
+		for _, result := range results {
+			if err := decodeSecretValues(schemaMap, result); err != nil {
+				return nil, err
+			}
+		}
+
+		return results, nil
+	}
+}
+
+// decodeSecretValues replace the '$9$' values of secrets in d with the plain text.
+func decodeSecretValues(schemaMap map[string]*schema.Schema, d *schema.ResourceData) error {
+	values := make(map[string]string)
+	secretValues(schemaMap, "", d, values)
+	keys := make([]string, 0)
+	for k, v := range values {
+		if strings.HasPrefix(v, secretEncodedPrefix) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		decoded, err := jdecodeSecret(values[k])
+		if err != nil {
+			return fmt.Errorf("failed to decode secret %s : %w", k, err)
+		}
+		if err := setSecretValue(d, k, decoded); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// secretValues add to values the value of each sensitive string argument with its path
+// (secrets in blocks of a set are skipped, their path isn't stable).
+func secretValues(
+	schemaMap map[string]*schema.Schema, prefix string, d *schema.ResourceData, values map[string]string,
+) {
+	for name, s := range schemaMap {
+		switch {
+		case s.Type == schema.TypeString && s.Sensitive && !s.Computed:
+			if v, ok := d.Get(prefix + name).(string); ok {
+				values[prefix+name] = v
+			}
+		case s.Type == schema.TypeList:
+			elem, ok := s.Elem.(*schema.Resource)
+			if !ok {
+				continue
+			}
+			count, _ := d.Get(prefix + name + ".#").(int)
+			for i := 0; i < count; i++ {
+				secretValues(elem.Schema, prefix+name+"."+strconv.Itoa(i)+".", d, values)
+			}
+		}
+	}
+}
+
+// setSecretValue set value of secret with path k (see secretValues) in d.
+func setSecretValue(d *schema.ResourceData, k, value string) error {
+	path := strings.Split(k, ".")
+	if len(path) == 1 {
+		return d.Set(k, value)
+	}
+	top := d.Get(path[0])
+	current := top
+	for i := 1; i < len(path); i++ {
+		switch v := current.(type) {
+		case []interface{}:
+			index, err := strconv.Atoi(path[i])
+			if err != nil || index >= len(v) {
+				return fmt.Errorf("secret %s not found", k)
+			}
+			current = v[index]
+		case map[string]interface{}:
+			if i == len(path)-1 {
+				v[path[i]] = value
+
+				return d.Set(path[0], top)
+			}
+			current = v[path[i]]
+		default:
+			return fmt.Errorf("secret %s not found", k)
+		}
+	}
+
+	return fmt.Errorf("secret %s not found", k)
+}
@@ -0,0 +1,248 @@
+package junos
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestDecodeSecret(t *testing.T) {
+	hash := "$6$AbCdEfGh$5iT8QyJ0Pze.UNPx4OGR9V8vmmrZ0zCIsU09LIc7.TW4sVv9fbDj16JLk3q0ReAgSOMxuh9Pz6yJvg5pXSO5c."
+	cases := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{value: jencodeSecret("password"), want: "password"},
+		{value: "\"" + jencodeSecret("my secret") + "\"", want: "my secret"},
+		{value: hash, want: hash},
+		{value: "plain", want: "plain"},
+		{value: "$9$", wantErr: true},
+	}
+	for _, c := range cases {
+		got, err := decodeSecret(c.value)
+		if (err != nil) != c.wantErr {
+			t.Errorf("decodeSecret(%q) error = %v, wantErr %v", c.value, err, c.wantErr)
+
+			continue
+		}
+		if !c.wantErr && got != c.want {
+			t.Errorf("decodeSecret(%q) = %q, want %q", c.value, got, c.want)
+		}
+	}
+}
+
+func TestJencodeSecret(t *testing.T) {
+	for _, value := range []string{"password", "a longer secret with spaces & symbols !"} {
+		decoded, err := jdecodeSecret(jencodeSecret(value))
+		if err != nil || decoded != value {
+			t.Errorf("jdecodeSecret(jencodeSecret(%q)) = %q, %v", value, decoded, err)
+		}
+	}
+}
+
+func TestSecretDiffSuppress(t *testing.T) {
+	cases := []struct {
+		old      string
+		new      string
+		suppress bool
+	}{
+		{old: "password", new: "password", suppress: true},
+		{old: "password", new: "password2", suppress: false},
+		{old: "password", new: jencodeSecret("password"), suppress: true},
+		{old: "password", new: jencodeSecret("password2"), suppress: false},
+		{old: "$1$AbCd$0123456789abcdefghijkl", new: "password", suppress: false},
+		{old: "$1$AbCd$0123456789abcdefghijkl", new: "$1$AbCd$other", suppress: false},
+		{old: "", new: "password", suppress: false},
+	}
+	for _, c := range cases {
+		if got := c.old == c.new || secretDiffSuppress("secret", c.old, c.new, nil); got != c.suppress {
+			t.Errorf("secretDiffSuppress(%q, %q) = %v, want %v", c.old, c.new, got, c.suppress)
+		}
+	}
+}
+
+func TestSecretHandling(t *testing.T) {
+	fake, err := StartFakeDevice("vsrx", "20.2R1.10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fake.Close()
+	sess := newFakeDeviceSession(t, fake)
+	ctx := context.Background()
+	r := Provider().ResourcesMap["junos_system_radius_server"]
+
+	config := map[string]interface{}{
+		"address":                  "192.0.2.1",
+		"secret":                   "password",
+		"preauthentication_secret": "password",
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(ctx, d, sess); diags.HasError() {
+		t.Fatalf("create error = %v", diags)
+	}
+	committed := strings.Join(fake.Committed(), "\n")
+	if strings.Contains(committed, "\"password\"") || !strings.Contains(committed, "$9$") {
+		t.Errorf("secrets not encoded on device, committed = %q", fake.Committed())
+	}
+
+	// secret configured with the encoded form
+	configEncoded := map[string]interface{}{
+		"address":                  "192.0.2.1",
+		"secret":                   jencodeSecret("password"),
+		"preauthentication_secret": "password",
+	}
+	diff, err := r.SimpleDiff(ctx, d.State(), terraform.NewResourceConfigRaw(configEncoded), sess)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != nil && diff.Attributes["secret"] != nil {
+		t.Errorf("diff on secret with encoded value = %v", diff.Attributes["secret"])
+	}
+
+	// secret replaced by a hash on device
+	hash := "$1$AbCd$0123456789abcdefghijkl"
+	lines := make([]string, 0)
+	for _, line := range fake.Committed() {
+		if strings.Contains(line, " secret ") && !strings.Contains(line, "preauthentication-secret") {
+			line = "set system radius-server 192.0.2.1 secret \"" + hash + "\""
+		}
+		lines = append(lines, line)
+	}
+	fake.SetCommitted(lines)
+	dRead := r.Data(d.State())
+	diags := r.ReadContext(ctx, dRead, sess)
+	if diags.HasError() {
+		t.Fatalf("read error = %v", diags)
+	}
+	if len(diags) != 1 || diags[0].Severity != diag.Warning || !strings.Contains(diags[0].Summary, "secret secret") {
+		t.Errorf("read diagnostics = %v, want a warning on secret", diags)
+	}
+	if dRead.Get("secret").(string) != "password" {
+		t.Errorf("secret = %q, want configured value kept", dRead.Get("secret"))
+	}
+	if dRead.Get("preauthentication_secret").(string) != "password" {
+		t.Errorf("preauthentication_secret = %q, want decoded value", dRead.Get("preauthentication_secret"))
+	}
+	diff, err = r.SimpleDiff(ctx, dRead.State(), terraform.NewResourceConfigRaw(config), sess)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != nil && diff.Attributes["secret"] != nil {
+		t.Errorf("diff on secret with a hash on device = %v", diff.Attributes["secret"])
+	}
+}
+
+func TestSecretHandlingImport(t *testing.T) {
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name":   {Type: schema.TypeString, Required: true},
+			"secret": {Type: schema.TypeString, Optional: true, Sensitive: true},
+		},
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			return nil
+		},
+		Importer: &schema.ResourceImporter{
+			State: func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				if err := d.Set("secret", jencodeSecret("password")); err != nil {
+					return nil, err
+				}
+
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+	}
+	addSecretHandling(map[string]*schema.Resource{"junos_test": r})
+	d := r.Data(nil)
+	d.SetId("test")
+	imported, err := r.Importer.State(d, &Session{})
+	if err != nil {
+		t.Fatalf("import error = %v", err)
+	}
+	if imported[0].Get("secret").(string) != "password" {
+		t.Errorf("secret after import = %q, want decoded value", imported[0].Get("secret"))
+	}
+}
+
+func TestSecretNtpAuthenticationKey(t *testing.T) {
+	fake, err := StartFakeDevice("vsrx", "20.2R1.10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fake.Close()
+	sess := newFakeDeviceSession(t, fake)
+	ctx := context.Background()
+	r := Provider().ResourcesMap["junos_system"]
+	if !r.Schema["ntp"].Elem.(*schema.Resource).Schema["authentication_key"].Elem.(*schema.Resource).
+		Schema["value"].Sensitive {
+		t.Error("ntp authentication_key value not sensitive")
+	}
+	config := map[string]interface{}{
+		"ntp": []interface{}{map[string]interface{}{
+			"authentication_key": []interface{}{
+				map[string]interface{}{"id": 1, "type": "sha256", "value": "ntp secret"},
+			},
+			"trusted_key": []interface{}{1},
+		}},
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(ctx, d, sess); diags.HasError() {
+		t.Fatalf("create error = %v", diags)
+	}
+	committed := strings.Join(fake.Committed(), "\n")
+	if strings.Contains(committed, "ntp secret") || !strings.Contains(committed, "value \"$9$") {
+		t.Errorf("ntp key not encoded on device, committed = %q", fake.Committed())
+	}
+	// the value and type of key can be on separate lines
+	fake.SetCommitted([]string{
+		"set system ntp authentication-key 1 type sha256",
+		"set system ntp authentication-key 1 value \"" + jencodeSecret("ntp secret") + "\"",
+		"set system ntp trusted-key 1",
+	})
+	dRead := r.Data(d.State())
+	if diags := r.ReadContext(ctx, dRead, sess); diags.HasError() {
+		t.Fatalf("read error = %v", diags)
+	}
+	diff, err := r.Diff(ctx, dRead.State(), terraform.NewResourceConfigRaw(config), sess)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Errorf("diff after read of encoded key = %v", diff)
+	}
+}
+
+func TestSecretSnmpCommunityName(t *testing.T) {
+	fake, err := StartFakeDevice("vsrx", "20.2R1.10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fake.Close()
+	sess := newFakeDeviceSession(t, fake)
+	ctx := context.Background()
+	r := Provider().ResourcesMap["junos_snmp_community"]
+	fake.SetCommitted([]string{
+		"set snmp community \"" + jencodeSecret("public") + "\" authorization read-only",
+		"set snmp community other authorization read-only",
+	})
+	d := r.Data(nil)
+	d.SetId("public")
+	imported, err := r.Importer.State(d, sess)
+	if err != nil {
+		t.Fatalf("import error = %v", err)
+	}
+	if imported[0].Get("name").(string) != "public" || !imported[0].Get("authorization_read_only").(bool) {
+		t.Errorf("import of community with encoded name = %v", imported[0].State())
+	}
+	if diags := r.DeleteContext(ctx, imported[0], sess); diags.HasError() {
+		t.Fatalf("delete error = %v", diags)
+	}
+	if committed := fake.Committed(); len(committed) != 1 ||
+		committed[0] != "set snmp community other authorization read-only" {
+		t.Errorf("configuration after delete = %v", committed)
+	}
+}
@@ -0,0 +1,204 @@
+package junos
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// strictModeResources : resources with strict mode and the function to generate the full replace
+// of their configuration (delete lines of managed hierarchies then set lines of configuration).
+func strictModeResources() map[string]func(*schema.ResourceData, interface{}, *NetconfObject) error {
+	return map[string]func(*schema.ResourceData, interface{}, *NetconfObject) error{
+		"junos_aggregate_route":                replaceAggregateRoute,
+		"junos_bgp_group":                      replaceBgpGroup,
+		"junos_bgp_neighbor":                   replaceBgpNeighbor,
+		"junos_firewall_filter":                replaceFirewallFilter,
+		"junos_generate_route":                 replaceGenerateRoute,
+		"junos_policyoptions_policy_statement": replacePolicyStatement,
+		"junos_security_policy":                replaceSecurityPolicy,
+		"junos_security_zone":                  replaceSecurityZone,
+		"junos_static_route":                   replaceStaticRoute,
+	}
+}
+
+// addStrictMode add the 'strict' argument and the computed 'unmanaged_config' attribute on resources
+// with strict mode: in strict mode, the lines on device under the hierarchies managed by resource
+// but not generated by its configuration are read in 'unmanaged_config'
+// and a non-empty value is a diff that update of resource removes.
+func addStrictMode(resources map[string]*schema.Resource) {
+	for resourceName, replace := range strictModeResources() {
+		resource, ok := resources[resourceName]
+		if !ok {
+			continue
+		}
+		resource.Schema["strict"] = &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+		}
+		resource.Schema["unmanaged_config"] = &schema.Schema{
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		}
+		resource.CreateContext = writeContextWithoutUnmanaged(resource.CreateContext)
+		resource.UpdateContext = writeContextWithoutUnmanaged(resource.UpdateContext)
+		resource.ReadContext = readContextWithUnmanaged(replace, resource.ReadContext)
+		if resource.CustomizeDiff != nil {
+			resource.CustomizeDiff = customdiff.All(resource.CustomizeDiff, customizeDiffStrictMode)
+		} else {
+			resource.CustomizeDiff = customizeDiffStrictMode
+		}
+	}
+}
+
+func strictModeEnabled(strict bool, m interface{}) bool {
+	if strict {
+		return true
+	}
+	sess, ok := m.(*Session)
+
+	return ok && sess.junosStrict
+}
+
+// customizeDiffStrictMode generate a diff to remove the unmanaged lines read in strict mode.
+func customizeDiffStrictMode(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !strictModeEnabled(d.Get("strict").(bool), m) {
+		return nil
+	}
+	if len(d.Get("unmanaged_config").([]interface{})) == 0 {
+		return nil
+	}
+
+	return d.SetNew("unmanaged_config", []string{})
+}
+
+// writeContextWithoutUnmanaged set 'unmanaged_config' to empty after create or update
+// (configuration of resource is set again without the unmanaged lines).
+func writeContextWithoutUnmanaged(
+	write func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics,
+) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	if write == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		diags := write(ctx, d, m)
+		if !diags.HasError() && d.Id() != "" {
+			if tfErr := d.Set("unmanaged_config", make([]string, 0)); tfErr != nil {
+				panic(tfErr)
+			}
+		}
+
+		return diags
+	}
+}
+
+func readContextWithUnmanaged(
+	replace func(*schema.ResourceData, interface{}, *NetconfObject) error, read schema.ReadContextFunc,
+) schema.ReadContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		diags := read(ctx, d, m)
+		if diags.HasError() || d.Id() == "" {
+			return diags
+		}
+		unmanaged := make([]string, 0)
+		if strictModeEnabled(d.Get("strict").(bool), m) {
+			var err error
+			unmanaged, err = readUnmanagedLines(replace, d, m)
+			if err != nil {
+				return append(diags, diag.FromErr(err)...)
+			}
+		}
+		if tfErr := d.Set("unmanaged_config", unmanaged); tfErr != nil {
+			panic(tfErr)
+		}
+
+		return diags
+	}
+}
+
+// readUnmanagedLines read the lines on device under the hierarchies deleted by the full replace of resource
+// and return those not generated by the configuration of resource.
+func readUnmanagedLines(
+	replace func(*schema.ResourceData, interface{}, *NetconfObject) error, d *schema.ResourceData, m interface{},
+) ([]string, error) {
+	sess := m.(*Session)
+	jnprSess, err := sess.startNewSession()
+	if err != nil {
+		return nil, err
+	}
+	defer sess.closeSession(jnprSess)
+	lines := make([]string, 0)
+	sessRecord := *sess
+	sessRecord.junosConfigSetRecord = &lines
+	sessRecord.junosOwnershipWrite = nil
+	if err := replace(d, &sessRecord, jnprSess); err != nil {
+		return nil, err
+	}
+	hierarchy := configDeltaCommonHierarchy(lines)
+	if hierarchy == "" {
+		return make([]string, 0), nil
+	}
+	current, err := sess.command(showConfigurationWords+" "+hierarchy+" "+displaySetWords, jnprSess)
+	if err != nil {
+		return nil, err
+	}
+
+	return configUnmanagedLines(strings.Split(current, "\n"), lines), nil
+}
+
+// configUnmanagedLines return the current set lines under the deleted hierarchies of replace
+// not generated by the set lines of replace (and not a parent of them).
+// Lines are compared with the values rewritten by device (see configDecodedLineKey),
+// a secret stored as a one-way hash is only compared with the path of its leaf.
+// The ownership marker isn't an unmanaged line.
+func configUnmanagedLines(current []string, replace []string) []string {
+	deleted := make([][]string, 0)
+	newKeys := make(map[string]bool)
+	newPaths := make(map[string]bool)
+	newWords := make([][]string, 0)
+	for _, line := range replace {
+		switch {
+		case strings.HasPrefix(line, deleteLineStart):
+			deleted = append(deleted, splitSetLineWords(strings.TrimPrefix(line, deleteLineStart)))
+		case strings.HasPrefix(line, setLineStart):
+			newKeys[configDecodedLineKey(line)] = true
+			words := splitSetLineWords(strings.TrimPrefix(line, setLineStart))
+			if len(words) > 1 {
+				newPaths[configDeltaLineKey(strings.Join(words[:len(words)-1], " "))] = true
+			}
+			newWords = append(newWords, words)
+		}
+	}
+	unmanaged := make([]string, 0)
+	for _, line := range current {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, setLineStart) || strings.Contains(line, " "+ownershipMacroWords+" ") {
+			continue
+		}
+		if newKeys[configDecodedLineKey(line)] {
+			continue
+		}
+		words := splitSetLineWords(strings.TrimPrefix(line, setLineStart))
+		if len(words) > 1 && secretIsHash(strings.Trim(words[len(words)-1], "\"")) &&
+			newPaths[configDeltaLineKey(strings.Join(words[:len(words)-1], " "))] {
+			continue
+		}
+		if configDeltaWordsIsParent(newWords, words, 0) {
+			continue
+		}
+		for _, del := range deleted {
+			if wordsHasPrefix(words, del) {
+				unmanaged = append(unmanaged, line)
+
+				break
+			}
+		}
+	}
+
+	return unmanaged
+}
@@ -0,0 +1,146 @@
+package junos
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestConfigUnmanagedLines(t *testing.T) {
+	prefix := "set protocols bgp group group1 "
+	current := []string{
+		prefix + "type external",
+		prefix + "peer-as 65001",
+		prefix + "description \"manual change\"",
+		prefix + "apply-macro terraform resource junos_bgp_group",
+		prefix + "neighbor 192.0.2.1",
+		"deactivate protocols bgp group group1 description",
+	}
+	replace := []string{
+		"delete protocols bgp group group1 type",
+		"delete protocols bgp group group1 peer-as",
+		"delete protocols bgp group group1 description",
+		prefix + "type external",
+		prefix + "peer-as \"65001\"",
+	}
+	want := []string{prefix + "description \"manual change\""}
+	if got := configUnmanagedLines(current, replace); !reflect.DeepEqual(got, want) {
+		t.Errorf("configUnmanagedLines() = %q, want %q", got, want)
+	}
+
+	// secrets rewritten by device
+	current = []string{
+		prefix + "authentication-key \"" + jencodeSecret("my secret") + "\"",
+		"set system radius-server 192.0.2.1 secret \"$1$AbCd$0123456789abcdefghijkl\"",
+		"set interfaces ge-0/0/0 unit 0 description test",
+	}
+	replace = []string{
+		"delete protocols bgp group group1",
+		"delete system radius-server 192.0.2.1",
+		"delete interfaces ge-0/0/0 unit 0",
+		prefix + "authentication-key \"my secret\"",
+		"set system radius-server 192.0.2.1 secret \"password\"",
+		"set interfaces ge-0/0/0.0 description test",
+	}
+	if got := configUnmanagedLines(current, replace); len(got) != 0 {
+		t.Errorf("configUnmanagedLines() with secrets = %q, want empty", got)
+	}
+}
+
+func TestStrictModeSecret(t *testing.T) {
+	fake, err := StartFakeDevice("vsrx", "20.2R1.10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fake.Close()
+	sess := newFakeDeviceSession(t, fake)
+	ctx := context.Background()
+	r := Provider().ResourcesMap["junos_bgp_group"]
+
+	config := map[string]interface{}{
+		"name":               "group1",
+		"type":               "external",
+		"authentication_key": "my secret",
+		"strict":             true,
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(ctx, d, sess); diags.HasError() {
+		t.Fatalf("create error = %v", diags)
+	}
+	dRead := r.Data(d.State())
+	if diags := r.ReadContext(ctx, dRead, sess); diags.HasError() {
+		t.Fatalf("read error = %v", diags)
+	}
+	if unmanaged := dRead.Get("unmanaged_config").([]interface{}); len(unmanaged) != 0 {
+		t.Errorf("unmanaged_config with authentication_key = %q, want empty", unmanaged)
+	}
+	diff, err := r.SimpleDiff(ctx, dRead.State(), terraform.NewResourceConfigRaw(config), sess)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != nil && len(diff.Attributes) != 0 {
+		t.Errorf("diff with authentication_key = %v, want none", diff.Attributes)
+	}
+}
+
+func TestStrictMode(t *testing.T) {
+	fake, err := StartFakeDevice("vsrx", "20.2R1.10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fake.Close()
+	sess := newFakeDeviceSession(t, fake)
+	ctx := context.Background()
+	r := Provider().ResourcesMap["junos_static_route"]
+
+	config := map[string]interface{}{
+		"destination": "192.0.2.0/24",
+		"next_hop":    []interface{}{"192.0.2.254"},
+		"strict":      true,
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(ctx, d, sess); diags.HasError() {
+		t.Fatalf("create error = %v", diags)
+	}
+	if unmanaged := d.Get("unmanaged_config").([]interface{}); len(unmanaged) != 0 {
+		t.Errorf("unmanaged_config after create = %q, want empty", unmanaged)
+	}
+	unmanagedLine := "set routing-options static route 192.0.2.0/24 lsp-next-hop lsp1"
+	fake.SetCommitted(append(fake.Committed(), unmanagedLine))
+
+	dRead := r.Data(d.State())
+	if diags := r.ReadContext(ctx, dRead, sess); diags.HasError() {
+		t.Fatalf("read error = %v", diags)
+	}
+	want := []interface{}{unmanagedLine}
+	if unmanaged := dRead.Get("unmanaged_config").([]interface{}); !reflect.DeepEqual(unmanaged, want) {
+		t.Errorf("unmanaged_config after read = %q, want %q", unmanaged, want)
+	}
+	diff, err := r.SimpleDiff(ctx, dRead.State(), terraform.NewResourceConfigRaw(config), sess)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff == nil || diff.Attributes["unmanaged_config.#"] == nil {
+		t.Fatalf("diff = %v, want a diff on unmanaged_config", diff)
+	}
+
+	updateFakeDeviceResource(t, r, dRead, config, sess)
+	if stringInSlice(unmanagedLine, fake.Committed()) {
+		t.Errorf("unmanaged line not removed by update, committed = %q", fake.Committed())
+	}
+
+	dRead = r.Data(d.State())
+	if err := dRead.Set("strict", false); err != nil {
+		t.Fatal(err)
+	}
+	fake.SetCommitted(append(fake.Committed(), unmanagedLine))
+	if diags := r.ReadContext(ctx, dRead, sess); diags.HasError() {
+		t.Fatalf("read error = %v", diags)
+	}
+	if unmanaged := dRead.Get("unmanaged_config").([]interface{}); len(unmanaged) != 0 {
+		t.Errorf("unmanaged_config without strict mode = %q, want empty", unmanaged)
+	}
+}
@@ -0,0 +1,265 @@
+package junos
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// xmlNode : an element of a xml document (like a reply of rpc) with its attributes, children and text.
+type xmlNode struct {
+	name       string
+	attributes map[string]string
+	children   []*xmlNode
+	text       string
+}
+
+// parseXMLNodes read the elements at the root of a xml document.
+// Namespaces are removed from names.
+func parseXMLNodes(data string) ([]*xmlNode, error) {
+	decoder := xml.NewDecoder(strings.NewReader(data))
+	root := &xmlNode{}
+	stack := []*xmlNode{root}
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read xml : %w", err)
+		}
+		current := stack[len(stack)-1]
+		switch t := token.(type) {
+		case xml.StartElement:
+			node := &xmlNode{name: t.Name.Local, attributes: make(map[string]string)}
+			for _, attr := range t.Attr {
+				node.attributes[attr.Name.Local] = attr.Value
+			}
+			current.children = append(current.children, node)
+			stack = append(stack, node)
+		case xml.EndElement:
+			current.text = strings.TrimSpace(current.text)
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			current.text += string(t)
+		}
+	}
+
+	return root.children, nil
+}
+
+// rpcXML generate a rpc with its arguments (an empty value is an element without content like <terse/>).
+func rpcXML(name string, arguments map[string]string) string {
+	keys := make([]string, 0, len(arguments))
+	for k := range arguments {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var rpc strings.Builder
+	rpc.WriteString("<" + name + ">")
+	for _, k := range keys {
+		if arguments[k] == "" {
+			rpc.WriteString("<" + k + "/>")
+		} else {
+			rpc.WriteString("<" + k + ">" + html.EscapeString(arguments[k]) + "</" + k + ">")
+		}
+	}
+	rpc.WriteString("</" + name + ">")
+
+	return rpc.String()
+}
+
+// xmlNodesToJSON convert elements to a JSON object.
+// An element with children is an object, an element without children is its text
+// and elements with the same name in a parent are an array.
+func xmlNodesToJSON(nodes []*xmlNode) (string, error) {
+	var output bytes.Buffer
+	encoder := json.NewEncoder(&output)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(xmlNodesToMap(nodes)); err != nil {
+		return "", fmt.Errorf("failed to encode json : %w", err)
+	}
+
+	return strings.TrimSpace(output.String()), nil
+}
+
+func xmlNodesToMap(nodes []*xmlNode) map[string]interface{} {
+	result := make(map[string]interface{})
+	for _, node := range nodes {
+		var value interface{} = node.text
+		if len(node.children) > 0 {
+			value = xmlNodesToMap(node.children)
+		}
+		switch existing := result[node.name].(type) {
+		case nil:
+			result[node.name] = value
+		case []interface{}:
+			result[node.name] = append(existing, value)
+		default:
+			result[node.name] = []interface{}{existing, value}
+		}
+	}
+
+	return result
+}
+
+// xmlSelect return the text (or attribute) of the first element found with a path in a subset of XPath:
+// steps of names (or '*') separated by '/' (or '//' for descendants) with optional predicates
+// '[<position>]' or '[<child>='<value>']', and a last step 'text()' or '@<attribute>'.
+// A path without '/' at start is relative to root elements.
+func xmlSelect(nodes []*xmlNode, path string) (string, bool, error) {
+	steps, err := xmlParsePath(path)
+	if err != nil {
+		return "", false, err
+	}
+	current := []*xmlNode{{children: nodes}}
+	for i, step := range steps {
+		if i == len(steps)-1 {
+			switch {
+			case step.name == "text()":
+				if len(current) == 0 {
+					return "", false, nil
+				}
+
+				return current[0].text, true, nil
+			case strings.HasPrefix(step.name, "@"):
+				for _, node := range current {
+					if value, ok := node.attributes[strings.TrimPrefix(step.name, "@")]; ok {
+						return value, true, nil
+					}
+				}
+
+				return "", false, nil
+			}
+		}
+		next := make([]*xmlNode, 0)
+		for _, node := range current {
+			candidates := node.children
+			if step.descendant {
+				candidates = xmlDescendants(node)
+			}
+			matched := make([]*xmlNode, 0)
+			for _, child := range candidates {
+				if step.name == "*" || child.name == step.name {
+					if step.childName == "" || xmlChildHasText(child, step.childName, step.childValue) {
+						matched = append(matched, child)
+					}
+				}
+			}
+			if step.position > 0 {
+				if step.position > len(matched) {
+					continue
+				}
+				matched = matched[step.position-1 : step.position]
+			}
+			next = append(next, matched...)
+		}
+		current = next
+	}
+	if len(current) == 0 {
+		return "", false, nil
+	}
+
+	return current[0].text, true, nil
+}
+
+// xmlStep : a step of path for xmlSelect.
+type xmlStep struct {
+	name       string
+	descendant bool
+	position   int
+	childName  string
+	childValue string
+}
+
+func xmlParsePath(path string) ([]xmlStep, error) {
+	steps := make([]xmlStep, 0)
+	rest := strings.TrimPrefix(strings.TrimSpace(path), "/")
+	descendant := strings.HasPrefix(strings.TrimSpace(path), "//")
+	rest = strings.TrimPrefix(rest, "/")
+	for _, part := range xmlSplitPath(rest) {
+		if part == "" {
+			if descendant {
+				return nil, fmt.Errorf("path '%s' not supported", path)
+			}
+			descendant = true
+
+			continue
+		}
+		step := xmlStep{name: part, descendant: descendant}
+		descendant = false
+		if i := strings.Index(part, "["); i != -1 {
+			if !strings.HasSuffix(part, "]") {
+				return nil, fmt.Errorf("predicate of '%s' in path '%s' not closed", part, path)
+			}
+			step.name = part[:i]
+			predicate := part[i+1 : len(part)-1]
+			if position, err := strconv.Atoi(predicate); err == nil && position > 0 {
+				step.position = position
+			} else {
+				equal := strings.SplitN(predicate, "=", 2)
+				if len(equal) != 2 {
+					return nil, fmt.Errorf("predicate '%s' in path '%s' not supported", predicate, path)
+				}
+				step.childName = strings.TrimSpace(equal[0])
+				step.childValue = strings.Trim(strings.TrimSpace(equal[1]), "'\"")
+			}
+		}
+		if step.name == "" {
+			return nil, fmt.Errorf("empty step in path '%s'", path)
+		}
+		steps = append(steps, step)
+	}
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("path '%s' without step", path)
+	}
+
+	return steps, nil
+}
+
+// xmlSplitPath split a path on '/' outside predicates (values can have a '/' like interface names).
+func xmlSplitPath(path string) []string {
+	parts := make([]string, 0)
+	inPredicate := false
+	start := 0
+	for i, c := range path {
+		switch {
+		case c == '[':
+			inPredicate = true
+		case c == ']':
+			inPredicate = false
+		case c == '/' && !inPredicate:
+			parts = append(parts, path[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(parts, path[start:])
+}
+
+func xmlDescendants(node *xmlNode) []*xmlNode {
+	result := make([]*xmlNode, 0)
+	for _, child := range node.children {
+		result = append(result, child)
+		result = append(result, xmlDescendants(child)...)
+	}
+
+	return result
+}
+
+func xmlChildHasText(node *xmlNode, name, value string) bool {
+	for _, child := range node.children {
+		if child.name == name && child.text == value {
+			return true
+		}
+	}
+
+	return false
+}
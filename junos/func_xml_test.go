@@ -0,0 +1,63 @@
+package junos
+
+import (
+	"testing"
+)
+
+func TestXMLSelect(t *testing.T) {
+	reply := `<interface-information xmlns:junos="http://xml.juniper.net/junos/*/junos">
+<physical-interface>
+<name>ge-0/0/0</name>
+<oper-status>up</oper-status>
+<logical-interface><name>ge-0/0/0.0</name></logical-interface>
+</physical-interface>
+<physical-interface>
+<name>ge-0/0/1</name>
+<oper-status junos:format="Down">down</oper-status>
+</physical-interface>
+</interface-information>`
+	nodes, err := parseXMLNodes(reply)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := map[string]string{
+		"interface-information/physical-interface/name":                  "ge-0/0/0",
+		"/interface-information/physical-interface[2]/name":              "ge-0/0/1",
+		"//physical-interface[name='ge-0/0/1']/oper-status":              "down",
+		"//physical-interface[name='ge-0/0/1']/oper-status/@format":      "Down",
+		"//logical-interface/name/text()":                                "ge-0/0/0.0",
+		"interface-information/*[1]/oper-status":                         "up",
+		"//physical-interface[name=\"ge-0/0/0\"]/logical-interface/name": "ge-0/0/0.0",
+	}
+	for path, want := range cases {
+		got, found, err := xmlSelect(nodes, path)
+		if err != nil || !found || got != want {
+			t.Errorf("xmlSelect(%q) = %q, %v, %v, want %q", path, got, found, err, want)
+		}
+	}
+	if _, found, err := xmlSelect(nodes, "//physical-interface[3]/name"); err != nil || found {
+		t.Errorf("xmlSelect of a missing element found = %v, err = %v", found, err)
+	}
+	if _, _, err := xmlSelect(nodes, "//physical-interface[name"); err == nil {
+		t.Error("xmlSelect with a predicate not closed without error")
+	}
+
+	outputJSON, err := xmlNodesToJSON(nodes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantJSON := `{"interface-information":{"physical-interface":[{"logical-interface":{"name":"ge-0/0/0.0"},` +
+		`"name":"ge-0/0/0","oper-status":"up"},{"name":"ge-0/0/1","oper-status":"down"}]}}`
+	if outputJSON != wantJSON {
+		t.Errorf("xmlNodesToJSON() = %s, want %s", outputJSON, wantJSON)
+	}
+}
+
+func TestRPCXML(t *testing.T) {
+	got := rpcXML("get-interface-information", map[string]string{"terse": "", "interface-name": "ge-0/0/0&1"})
+	want := "<get-interface-information><interface-name>ge-0/0/0&amp;1</interface-name><terse/>" +
+		"</get-interface-information>"
+	if got != want {
+		t.Errorf("rpcXML() = %s, want %s", got, want)
+	}
+}
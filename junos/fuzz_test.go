@@ -0,0 +1,113 @@
+//go:build go1.18
+// +build go1.18
+
+package junos
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// fuzzSeedLines harvest the seed corpus of a fuzz target in set lines of testdata
+// (samples of device configurations and golden files of round-trip tests):
+// the first submatch of lineRegexp on each line is added to corpus.
+func fuzzSeedLines(f *testing.F, lineRegexp string) {
+	f.Helper()
+	re := regexp.MustCompile(lineRegexp)
+	files, err := filepath.Glob(filepath.Join(roundTripGoldenDir, "*.set"))
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, file := range append(files, filepath.Join("testdata", "display_set_samples.set")) {
+		fileOpen, err := os.Open(file)
+		if err != nil {
+			f.Fatal(err)
+		}
+		scanner := bufio.NewScanner(fileOpen)
+		for scanner.Scan() {
+			if match := re.FindStringSubmatch(scanner.Text()); len(match) > 1 {
+				f.Add(match[1])
+			}
+		}
+		fileOpen.Close()
+		if err := scanner.Err(); err != nil {
+			f.Fatal(err)
+		}
+	}
+}
+
+func FuzzReadBgpOpts(f *testing.F) {
+	fuzzSeedLines(f, `^set (?:routing-instances \S+ )?protocols bgp group \S+ (?:neighbor \S+ )?(.+)$`)
+	f.Add("authentication-key \"\"")
+	f.Add("local-as loops")
+	f.Add("family inet")
+	f.Fuzz(func(t *testing.T, item string) {
+		var confRead bgpOptions
+		switch {
+		case strings.HasPrefix(item, "bfd-liveness-detection "):
+			_ = readBgpOptsBfd(item, map[string]interface{}{})
+		case strings.HasPrefix(item, "family evpn "):
+			_, _ = readBgpOptsFamily(item, "evpn", confRead.familyEvpn)
+		case strings.HasPrefix(item, "family inet "):
+			_, _ = readBgpOptsFamily(item, inetWord, confRead.familyInet)
+		case strings.HasPrefix(item, "family inet6 "):
+			_, _ = readBgpOptsFamily(item, inet6Word, confRead.familyInet6)
+		case strings.HasPrefix(item, "graceful-restart "):
+			_ = readBgpOptsGracefulRestart(item, map[string]interface{}{})
+		default:
+			_ = readBgpOptsSimple(item, &confRead)
+		}
+	})
+}
+
+func FuzzReadPolicyStatementOpts(f *testing.F) {
+	fuzzSeedLines(f, `^set policy-options policy-statement \S+ (?:term \S+ )?((?:from|then|to) .+)$`)
+	f.Add("from route-filter")
+	f.Add("then community add")
+	f.Fuzz(func(t *testing.T, item string) {
+		switch {
+		case strings.HasPrefix(item, "from "):
+			_ = readPolicyStatementOptsFrom(strings.TrimPrefix(item, "from "), genMapPolicyStatementOptsFrom())
+		case strings.HasPrefix(item, "then "):
+			_ = readPolicyStatementOptsThen(strings.TrimPrefix(item, "then "), genMapPolicyStatementOptsThen())
+		case strings.HasPrefix(item, "to "):
+			_ = readPolicyStatementOptsTo(strings.TrimPrefix(item, "to "), genMapPolicyStatementOptsTo())
+		}
+	})
+}
+
+func FuzzReadInterfacePhysicalOpts(f *testing.F) {
+	fuzzSeedLines(f, `^set interfaces \S+ ((?:esi|ether-options|gigether-options|aggregated-ether-options) .+)$`)
+	f.Add("aggregated-ether-options bfd-liveness-detection minimum-interval")
+	f.Fuzz(func(t *testing.T, item string) {
+		var confRead interfacePhysicalOptions
+		switch {
+		case strings.HasPrefix(item, "esi "):
+			_ = readInterfacePhysicalEsi(&confRead, item)
+		case strings.HasPrefix(item, "ether-options "):
+			readInterfacePhysicalEtherOpts(&confRead, strings.TrimPrefix(item, "ether-options "))
+		case strings.HasPrefix(item, "gigether-options "):
+			readInterfacePhysicalGigetherOpts(&confRead, strings.TrimPrefix(item, "gigether-options "))
+		case strings.HasPrefix(item, "aggregated-ether-options "):
+			_ = readInterfacePhysicalParentEtherOpts(&confRead, strings.TrimPrefix(item, "aggregated-ether-options "))
+		}
+	})
+}
+
+func FuzzFillFamilyInetAddress(f *testing.F) {
+	fuzzSeedLines(f, `^set interfaces \S+ unit \d+ (family inet6? address .+)$`)
+	f.Add("family inet address 192.0.2.1/25 vrrp-group 1 track route 0.0.0.0/0 30")
+	f.Add("family inet address 192.0.2.1/25 vrrp-group 1 authentication-key \"\"")
+	f.Fuzz(func(t *testing.T, item string) {
+		switch {
+		case strings.HasPrefix(item, "family inet address "):
+			_, _ = fillFamilyInetAddress(item, make([]map[string]interface{}, 0), inetWord)
+		case strings.HasPrefix(item, "family inet6 address "):
+			_, _ = fillFamilyInetAddress(item, make([]map[string]interface{}, 0), inet6Word)
+		}
+	})
+}
@@ -0,0 +1,229 @@
+package junos
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// GenerateFromConfig read the objects of resources in a configuration of device in 'display set' format
+// with the import of each resource (on a read-only session on the configuration),
+// write the HCL of resources with their import blocks and a report of lines not covered by resources
+// (lines not generated again by the configuration of resources read).
+// model is the hardware model of device used by compatibility checks of resources
+// (empty to use 'srx' when configuration has 'security' lines).
+func GenerateFromConfig(config io.Reader, model string, hclOutput, report io.Writer) error {
+	lines, version, err := readConfigFileLines(config)
+	if err != nil {
+		return err
+	}
+	if model == "" {
+		model = generateDefaultModel(lines)
+	}
+	sess := &Session{
+		junosTransport:  transportConfigFile,
+		junosConfigFile: configFileNewSession(lines, model, version),
+		junosSysInfo:    &sysInfoCache{},
+	}
+	resources := Provider().ResourcesMap
+	types := configObjectTypes()
+	sets := make(map[string]func(*schema.ResourceData, interface{}, *NetconfObject) error)
+	for _, objectType := range types {
+		sets[objectType.resource] = objectType.set
+	}
+	objects, uncovered := configObjectsFromLines(lines, types)
+	file := hclwrite.NewEmptyFile()
+	names := make(map[string]bool)
+	generated := 0
+	objectLines := make([]int, 0)
+	generatedLines := make([]string, 0)
+	for _, object := range objects {
+		r := resources[object.resource]
+		d := r.Data(nil)
+		d.SetId(object.id)
+		imported, err := r.Importer.State(d, sess)
+		if err == nil && len(imported) != 1 {
+			err = fmt.Errorf("import return %d resources", len(imported))
+		}
+		if err != nil {
+			fmt.Fprintf(report, "# failed to read %s with id %s : %s\n", object.resource, object.id, err)
+			uncovered = append(uncovered, object.lines...)
+
+			continue
+		}
+		setLines, err := configObjectSetLines(sets[object.resource], imported[0], sess)
+		if err != nil {
+			fmt.Fprintf(report, "# failed to generate lines of %s with id %s : %s\n", object.resource, object.id, err)
+			uncovered = append(uncovered, object.lines...)
+
+			continue
+		}
+		// a line can be generated by another resource (like the interfaces of a zone by a logical interface)
+		objectLines = append(objectLines, object.lines...)
+		generatedLines = append(generatedLines, setLines...)
+		name := generateResourceName(object.resource, object.id, names)
+		importBlock := file.Body().AppendNewBlock("import", nil).Body()
+		importBlock.SetAttributeTraversal("to", hcl.Traversal{
+			hcl.TraverseRoot{Name: object.resource},
+			hcl.TraverseAttr{Name: name},
+		})
+		importBlock.SetAttributeValue("id", cty.StringVal(object.id))
+		file.Body().AppendNewline()
+		resourceBlock := file.Body().AppendNewBlock("resource", []string{object.resource, name}).Body()
+		values := make(map[string]interface{})
+		for k := range r.Schema {
+			values[k] = imported[0].Get(k)
+		}
+		generateHCLBody(resourceBlock, r.Schema, values)
+		file.Body().AppendNewline()
+		generated++
+	}
+	if _, err := hclOutput.Write(file.Bytes()); err != nil {
+		return fmt.Errorf("failed to write HCL : %w", err)
+	}
+	uncovered = append(uncovered, configObjectLinesNotSet(lines, objectLines, generatedLines)...)
+	sort.Ints(uncovered)
+	fmt.Fprintf(report, "# %d resources generated from %d lines\n", generated, len(lines))
+	fmt.Fprintf(report, "# %d lines not covered by resources\n", len(uncovered))
+	for _, i := range uncovered {
+		fmt.Fprintln(report, lines[i])
+	}
+
+	return nil
+}
+
+func generateDefaultModel(lines []string) string {
+	for _, line := range lines {
+		if strings.HasPrefix(line, setLineStart+"security ") {
+			return "srx"
+		}
+	}
+
+	return ""
+}
+
+// generateResourceName generate a unique name of resource from the id of object.
+func generateResourceName(resource, id string, names map[string]bool) string {
+	name := regexp.MustCompile(`[^a-zA-Z0-9_-]+`).ReplaceAllString(strings.ReplaceAll(id, idSeparator, "_"), "_")
+	if name == "" || !regexp.MustCompile(`^[a-zA-Z_]`).MatchString(name) {
+		name = "_" + name
+	}
+	unique := name
+	for i := 2; names[resource+"."+unique]; i++ {
+		unique = name + "_" + strconv.Itoa(i)
+	}
+	names[resource+"."+unique] = true
+
+	return unique
+}
+
+// generateHCLBody write the arguments (then the blocks) with a value in body, sorted by name.
+// Computed only and deprecated arguments are ignored, sensitive arguments are written with their '$9$' encoded value.
+func generateHCLBody(body *hclwrite.Body, schemaMap map[string]*schema.Schema, values map[string]interface{}) {
+	keys := make([]string, 0, len(schemaMap))
+	for k, s := range schemaMap {
+		if (s.Computed && !s.Optional) || s.Deprecated != "" {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	blocks := make([]string, 0)
+	for _, k := range keys {
+		s := schemaMap[k]
+		if _, ok := s.Elem.(*schema.Resource); ok {
+			blocks = append(blocks, k)
+
+			continue
+		}
+		if value, ok := generateHCLValue(s, values[k]); ok {
+			body.SetAttributeValue(k, value)
+		}
+	}
+	for _, k := range blocks {
+		elem := schemaMap[k].Elem.(*schema.Resource)
+		for _, v := range generateListOf(values[k]) {
+			block, ok := v.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			generateHCLBody(body.AppendNewBlock(k, nil).Body(), elem.Schema, block)
+		}
+	}
+}
+
+// generateHCLValue convert a value of argument (without blocks) to a cty value, false if it's an empty value.
+func generateHCLValue(s *schema.Schema, v interface{}) (cty.Value, bool) {
+	switch s.Type {
+	case schema.TypeString:
+		value, ok := v.(string)
+		if !ok || value == "" {
+			return cty.NilVal, false
+		}
+		if s.Sensitive && !strings.HasPrefix(value, secretEncodedPrefix) && !secretIsHash(value) {
+			// no secret in plain text, the '$9$' encoded value is compared with the decoded value read on device
+			return cty.StringVal(jencodeSecret(value)), true
+		}
+
+		return cty.StringVal(value), true
+	case schema.TypeInt:
+		if value, ok := v.(int); ok && value != 0 {
+			return cty.NumberIntVal(int64(value)), true
+		}
+	case schema.TypeFloat:
+		if value, ok := v.(float64); ok && value != 0 {
+			return cty.NumberFloatVal(value), true
+		}
+	case schema.TypeBool:
+		if value, ok := v.(bool); ok && value {
+			return cty.True, true
+		}
+	case schema.TypeList, schema.TypeSet:
+		elem, ok := s.Elem.(*schema.Schema)
+		if !ok {
+			return cty.NilVal, false
+		}
+		values := make([]cty.Value, 0)
+		for _, e := range generateListOf(v) {
+			if value, ok := generateHCLValue(elem, e); ok {
+				values = append(values, value)
+			}
+		}
+		if len(values) > 0 {
+			return cty.ListVal(values), true
+		}
+	case schema.TypeMap:
+		m, ok := v.(map[string]interface{})
+		if !ok || len(m) == 0 {
+			return cty.NilVal, false
+		}
+		values := make(map[string]cty.Value)
+		for k, e := range m {
+			values[k] = cty.StringVal(fmt.Sprintf("%v", e))
+		}
+
+		return cty.MapVal(values), true
+	case schema.TypeInvalid:
+	}
+
+	return cty.NilVal, false
+}
+
+func generateListOf(v interface{}) []interface{} {
+	switch value := v.(type) {
+	case []interface{}:
+		return value
+	case *schema.Set:
+		return value.List()
+	}
+
+	return nil
+}
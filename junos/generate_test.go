@@ -0,0 +1,116 @@
+package junos
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+func TestConfigObjectsFromLines(t *testing.T) {
+	lines := []string{
+		"set version 20.2R1.10",
+		"set routing-options static route 192.0.2.0/24 next-hop 192.0.2.254",
+		"set routing-options rib inet6.0 static route 2001:db8::/32 discard",
+		"set routing-instances vr1 routing-options rib vr1.inet6.0 static route 2001:db8::/32 discard",
+		"set routing-instances vr1 instance-type virtual-router",
+		"set protocols bgp group g1 type external",
+		"set protocols bgp group g1 neighbor 192.0.2.1 peer-as 65001",
+		"set interfaces ge-0/0/0 unit 0 family inet",
+		"deactivate interfaces ge-0/0/0 description",
+		"set interfaces interface-range r1 member ge-0/0/1",
+		"set firewall family inet filter f1 term t1 then accept",
+		"set routing-options static route 192.0.2.0/24 preference 5",
+	}
+	objects, uncovered := configObjectsFromLines(lines, configObjectTypes())
+	want := []configObject{
+		{resource: "junos_static_route", id: "192.0.2.0/24_-_default", lines: []int{1, 11}},
+		{resource: "junos_static_route", id: "2001:db8::/32_-_default", lines: []int{2}},
+		{resource: "junos_static_route", id: "2001:db8::/32_-_vr1", lines: []int{3}},
+		{resource: "junos_routing_instance", id: "vr1", lines: []int{4}},
+		{resource: "junos_bgp_group", id: "g1_-_default", lines: []int{5}},
+		{resource: "junos_bgp_neighbor", id: "192.0.2.1_-_default_-_g1", lines: []int{6}},
+		{resource: "junos_interface_logical", id: "ge-0/0/0.0", lines: []int{7}},
+		{resource: "junos_interface_physical", id: "ge-0/0/0", lines: []int{8}},
+		{resource: "junos_firewall_filter", id: "f1_-_inet", lines: []int{10}},
+	}
+	if !reflect.DeepEqual(objects, want) {
+		t.Errorf("objects = %+v, want %+v", objects, want)
+	}
+	if !reflect.DeepEqual(uncovered, []int{0, 9}) {
+		t.Errorf("uncovered = %v, want [0 9]", uncovered)
+	}
+}
+
+func TestGenerateFromConfig(t *testing.T) {
+	config := strings.Join([]string{
+		"## Last commit: 2021-05-20 10:00:00 UTC by admin",
+		"set version 20.2R1.10",
+		"set system host-name vsrx1",
+		"set interfaces ge-0/0/0 description \"uplink ${main}\"",
+		"set interfaces ge-0/0/0 unit 0 family inet address 192.0.2.1/24",
+		"set routing-options static route 0.0.0.0/0 next-hop 192.0.2.254",
+		"set security zones security-zone trust interfaces ge-0/0/0.0",
+		"set security policies from-zone trust to-zone trust policy p1 match source-address any",
+		"set security policies from-zone trust to-zone trust policy p1 match destination-address any",
+		"set security policies from-zone trust to-zone trust policy p1 match application any",
+		"set security policies from-zone trust to-zone trust policy p1 then permit",
+		"set routing-instances VR1 instance-type virtual-router",
+		"set routing-instances VR1 protocols ospf area 0 interface ge-0/0/1.0",
+		"set protocols bgp group g1 type external",
+		"set protocols bgp group g1 authentication-key \"" + jencodeSecret("bgp secret") + "\"",
+	}, "\n")
+	var output, report bytes.Buffer
+	if err := GenerateFromConfig(strings.NewReader(config), "", &output, &report); err != nil {
+		t.Fatal(err)
+	}
+	file, diags := hclwrite.ParseConfig(output.Bytes(), "generated.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatalf("generated HCL is invalid: %v\n%s", diags, output.String())
+	}
+	imports := 0
+	resources := make([]string, 0)
+	for _, block := range file.Body().Blocks() {
+		switch block.Type() {
+		case "import":
+			imports++
+		case "resource":
+			resources = append(resources, strings.Join(block.Labels(), "."))
+		}
+	}
+	wantResources := []string{
+		"junos_interface_physical.ge-0_0_0",
+		"junos_interface_logical.ge-0_0_0_0",
+		"junos_static_route._0_0_0_0_0_default",
+		"junos_security_zone.trust",
+		"junos_security_policy.trust_trust",
+		"junos_routing_instance.VR1",
+		"junos_bgp_group.g1_default",
+	}
+	if imports != len(wantResources) || !reflect.DeepEqual(resources, wantResources) {
+		t.Errorf("generated %d imports and resources %q, want %q\n%s", imports, resources, wantResources, output.String())
+	}
+	for _, want := range []string{
+		`description = "uplink $${main}"`,
+		`id = "0.0.0.0/0_-_default"`,
+		`security_zone = "trust"`,
+		`cidr_ip = "192.0.2.1/24"`,
+		`authentication_key = "` + jencodeSecret("bgp secret") + `"`,
+	} {
+		if !strings.Contains(strings.Join(strings.Fields(output.String()), " "), want) {
+			t.Errorf("generated HCL without %s\n%s", want, output.String())
+		}
+	}
+	if strings.Contains(output.String(), "bgp secret") {
+		t.Errorf("generated HCL with a secret in plain text\n%s", output.String())
+	}
+	wantReport := "# 7 resources generated from 14 lines\n# 3 lines not covered by resources\n" +
+		"set version 20.2R1.10\nset system host-name vsrx1\n" +
+		"set routing-instances VR1 protocols ospf area 0 interface ge-0/0/1.0\n"
+	if report.String() != wantReport {
+		t.Errorf("report = %q, want %q", report.String(), wantReport)
+	}
+}
@@ -0,0 +1,459 @@
+package junos
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	migrateInterfaceType         = "junos_interface"
+	migrateInterfacePhysicalType = "junos_interface_physical"
+	migrateInterfaceLogicalType  = "junos_interface_logical"
+)
+
+// migrateEdit : replace bytes between start and end of a source with text.
+type migrateEdit struct {
+	start int
+	end   int
+	text  string
+}
+
+// migrateInterfaceArgs : arguments of junos_interface renamed (or moved in a family block)
+// for the new type (with a family prefix 'family_inet[0].' for references).
+// Arguments of junos_interface without equivalent on the new type are absent.
+func migrateInterfaceArgs(newType string) map[string]string {
+	args := map[string]string{
+		"config_interface": "config_interface",
+		"description":      "description",
+		"match":            "match",
+		"name":             "name",
+	}
+	switch newType {
+	case migrateInterfacePhysicalType:
+		for _, arg := range []string{
+			"ae_lacp", "ae_link_speed", "ae_minimum_links", "ether802_3ad",
+			"trunk", "vlan_members", "vlan_native", "vlan_tagging",
+		} {
+			args[arg] = arg
+		}
+		args["complete_destroy"] = "no_disable_on_destroy"
+	case migrateInterfaceLogicalType:
+		args["routing_instance"] = "routing_instance"
+		args["security_zone"] = "security_zone"
+		args["vlan_tagging_id"] = "vlan_id"
+		args["complete_destroy"] = "st0_also_on_destroy"
+		for _, family := range []string{inetWord, inet6Word} {
+			for _, arg := range []string{"address", "filter_input", "filter_output", "mtu", "rpf_check"} {
+				args[family+"_"+arg] = "family_" + family + "[0]." + arg
+			}
+		}
+	}
+
+	return args
+}
+
+// MigrateInterface rewrite the deprecated junos_interface resources and data sources in files of
+// a Terraform configuration to junos_interface_physical (name without dot) or junos_interface_logical,
+// with references to them.
+// For each resource, an import block of the new resource and a removed block (without destroy)
+// of the deprecated resource are added so the next apply moves the state without change on device.
+// Objects which can't be migrated are kept and listed in report with the reason.
+// It return the new content of changed files.
+func MigrateInterface(files map[string][]byte, report io.Writer) (map[string][]byte, error) {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	// first pass on blocks of resources and data sources
+	migrated := make(map[string]string)
+	rewritten := make(map[string][]byte)
+	var resources, dataSources, skipped int
+	for _, name := range names {
+		body, err := migrateParse(files[name], name)
+		if err != nil {
+			return nil, err
+		}
+		edits := make([]migrateEdit, 0)
+		for _, block := range body.Blocks {
+			if (block.Type != "resource" && block.Type != "data") ||
+				len(block.Labels) != 2 || block.Labels[0] != migrateInterfaceType {
+				continue
+			}
+			address := migrateInterfaceType + "." + block.Labels[1]
+			if block.Type == "data" {
+				address = "data." + address
+			}
+			newType, blockEdits, err := migrateInterfaceBlock(files[name], block)
+			if err != nil {
+				fmt.Fprintf(report, "# %s (%s) not migrated : %s\n", address, name, err)
+				skipped++
+
+				continue
+			}
+			edits = append(edits, blockEdits...)
+			migrated[block.Type+"."+block.Labels[1]] = newType
+			if block.Type == "data" {
+				fmt.Fprintf(report, "# %s (%s) migrated to data.%s.%s\n", address, name, newType, block.Labels[1])
+				dataSources++
+			} else {
+				fmt.Fprintf(report, "# %s (%s) migrated to %s.%s with import and removed blocks\n",
+					address, name, newType, block.Labels[1])
+				resources++
+			}
+		}
+		rewritten[name] = migrateApplyEdits(files[name], edits)
+	}
+	// second pass on references to migrated objects
+	changed := make(map[string][]byte)
+	for _, name := range names {
+		body, err := migrateParse(rewritten[name], name)
+		if err != nil {
+			return nil, fmt.Errorf("internal error: rewritten configuration invalid : %w", err)
+		}
+		edits := make([]migrateEdit, 0)
+		for _, block := range body.Blocks {
+			// removed blocks added in first pass keep the deprecated type
+			if block.Type == "removed" {
+				continue
+			}
+			hclsyntax.VisitAll(block.Body, func(node hclsyntax.Node) hcl.Diagnostics {
+				if expr, ok := node.(*hclsyntax.ScopeTraversalExpr); ok {
+					edits = append(edits, migrateInterfaceReference(expr.Traversal, migrated, name, report)...)
+				}
+
+				return nil
+			})
+		}
+		src := migrateApplyEdits(rewritten[name], edits)
+		if string(src) != string(files[name]) {
+			changed[name] = hclwrite.Format(src)
+		}
+	}
+	fmt.Fprintf(report, "# %d resources and %d data sources migrated, %d not migrated\n",
+		resources, dataSources, skipped)
+
+	return changed, nil
+}
+
+func migrateParse(src []byte, filename string) (*hclsyntax.Body, error) {
+	file, diags := hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("failed to parse %s : %s", filename, diags.Error())
+	}
+
+	return file.Body.(*hclsyntax.Body), nil
+}
+
+// migrateInterfaceBlock return the new type and the edits to migrate the block of a junos_interface
+// resource or data source, or an error with the reason when it can't be migrated.
+func migrateInterfaceBlock(src []byte, block *hclsyntax.Block) (string, []migrateEdit, error) {
+	nameArg := "name"
+	if block.Type == "data" {
+		nameArg = "config_interface"
+	}
+	for _, meta := range []string{"count", "for_each"} {
+		if _, ok := block.Body.Attributes[meta]; ok {
+			return "", nil, fmt.Errorf("%s argument not supported, import each instance manually", meta)
+		}
+	}
+	nameAttr, ok := block.Body.Attributes[nameArg]
+	if !ok {
+		return "", nil, fmt.Errorf("without %s argument, the new type is unknown", nameArg)
+	}
+	logical, ok := migrateInterfaceIsLogical(nameAttr.Expr)
+	if !ok {
+		return "", nil, fmt.Errorf("the new type is unknown with the expression of %s argument", nameArg)
+	}
+	newType := migrateInterfacePhysicalType
+	if logical {
+		newType = migrateInterfaceLogicalType
+	}
+	args := migrateInterfaceArgs(newType)
+	edits := make([]migrateEdit, 0)
+	labelRange := block.LabelRanges[0]
+	label := newType
+	if src[labelRange.Start.Byte] == '"' {
+		label = "\"" + newType + "\""
+	}
+	edits = append(edits, migrateEdit{start: labelRange.Start.Byte, end: labelRange.End.Byte, text: label})
+	// items moved in family blocks, in order of source
+	families := map[string][]string{}
+	var moved []migrateEdit
+	moveToFamily := func(family, text string, rng hcl.Range) {
+		families[family] = append(families[family], text)
+		start, end := migrateLineRange(src, rng)
+		moved = append(moved, migrateEdit{start: start, end: end})
+	}
+	attrs := make([]*hclsyntax.Attribute, 0, len(block.Body.Attributes))
+	for _, attr := range block.Body.Attributes {
+		attrs = append(attrs, attr)
+	}
+	sort.Slice(attrs, func(i, j int) bool {
+		return attrs[i].SrcRange.Start.Byte < attrs[j].SrcRange.Start.Byte
+	})
+	for _, attr := range attrs {
+		switch attr.Name {
+		case "provider", "depends_on":
+			continue
+		case inetWord, inet6Word:
+			if !logical {
+				break
+			}
+			enabled, diags := attr.Expr.Value(nil)
+			if diags.HasErrors() || enabled.Type() != cty.Bool || enabled.IsNull() {
+				return "", nil, fmt.Errorf("%s argument with an expression not supported", attr.Name)
+			}
+			if enabled.True() {
+				moveToFamily(attr.Name, "", attr.SrcRange)
+			} else {
+				start, end := migrateLineRange(src, attr.SrcRange)
+				moved = append(moved, migrateEdit{start: start, end: end})
+			}
+
+			continue
+		}
+		newName, ok := args[attr.Name]
+		if !ok {
+			return "", nil, fmt.Errorf("%s argument not available on %s", attr.Name, newType)
+		}
+		if family, arg, ok := migrateInterfaceFamilyArg(newName); ok {
+			moveToFamily(family, arg+" = "+migrateSource(src, attr.Expr.Range()), attr.SrcRange)
+
+			continue
+		}
+		if newName != attr.Name {
+			edits = append(edits, migrateEdit{start: attr.NameRange.Start.Byte, end: attr.NameRange.End.Byte, text: newName})
+		}
+	}
+	for _, nested := range block.Body.Blocks {
+		blockType := nested.Type
+		if nested.Type == "dynamic" && len(nested.Labels) == 1 {
+			blockType = nested.Labels[0]
+		}
+		if nested.Type == "lifecycle" {
+			continue
+		}
+		family, arg, ok := migrateInterfaceFamilyArg(args[blockType])
+		if !ok || !logical {
+			return "", nil, fmt.Errorf("%s block not available on %s", blockType, newType)
+		}
+		moveToFamily(family, migrateInterfaceFamilyBlock(src, nested, blockType, arg), nested.Range())
+	}
+	if len(moved) > 0 {
+		sort.Slice(moved, func(i, j int) bool { return moved[i].start < moved[j].start })
+		var familiesText strings.Builder
+		for _, family := range []string{inetWord, inet6Word} {
+			items, ok := families[family]
+			if !ok {
+				continue
+			}
+			familiesText.WriteString("family_" + family + " {\n")
+			for _, item := range items {
+				if item != "" {
+					familiesText.WriteString(item + "\n")
+				}
+			}
+			familiesText.WriteString("}\n")
+		}
+		// family blocks replace the first item moved
+		moved[0].text = familiesText.String()
+		edits = append(edits, moved...)
+	}
+	if block.Type == "resource" {
+		edits = append(edits, migrateEdit{
+			start: block.Range().End.Byte,
+			end:   block.Range().End.Byte,
+			text: "\n\nimport {\n" +
+				"to = " + newType + "." + block.Labels[1] + "\n" +
+				"id = " + migrateSource(src, nameAttr.Expr.Range()) + "\n" +
+				"}\n\n" +
+				"removed {\n" +
+				"from = " + migrateInterfaceType + "." + block.Labels[1] + "\n\n" +
+				"lifecycle {\n" +
+				"destroy = false\n" +
+				"}\n" +
+				"}",
+		})
+	}
+
+	return newType, edits, nil
+}
+
+// migrateInterfaceIsLogical return if the name of interface has a dot (logical interface)
+// and false as second value if it's unknown (expression without a dot in literal parts).
+func migrateInterfaceIsLogical(expr hclsyntax.Expression) (bool, bool) {
+	value, diags := expr.Value(nil)
+	if !diags.HasErrors() && value.Type() == cty.String && !value.IsNull() {
+		return strings.Contains(value.AsString(), "."), true
+	}
+	if template, ok := expr.(*hclsyntax.TemplateExpr); ok {
+		for _, part := range template.Parts {
+			literal, ok := part.(*hclsyntax.LiteralValueExpr)
+			if ok && literal.Val.Type() == cty.String && strings.Contains(literal.Val.AsString(), ".") {
+				return true, true
+			}
+		}
+	}
+
+	return false, false
+}
+
+// migrateInterfaceFamilyArg split a new argument 'family_<family>[0].<arg>' in family and arg.
+func migrateInterfaceFamilyArg(newName string) (string, string, bool) {
+	if !strings.HasPrefix(newName, "family_") {
+		return "", "", false
+	}
+	split := strings.SplitN(strings.TrimPrefix(newName, "family_"), "[0].", 2)
+	if len(split) != 2 {
+		return "", "", false
+	}
+
+	return split[0], split[1], true
+}
+
+// migrateInterfaceFamilyBlock return the text of a block (or dynamic block) of junos_interface
+// for a family block of junos_interface_logical (address argument of address blocks renamed to cidr_ip).
+func migrateInterfaceFamilyBlock(src []byte, block *hclsyntax.Block, blockType, arg string) string {
+	start := block.OpenBraceRange.End.Byte
+	edits := make([]migrateEdit, 0)
+	renameAddress := func(body *hclsyntax.Body) {
+		if attr, ok := body.Attributes["address"]; ok && arg == "address" {
+			edits = append(edits, migrateEdit{
+				start: attr.NameRange.Start.Byte - start,
+				end:   attr.NameRange.End.Byte - start,
+				text:  "cidr_ip",
+			})
+		}
+	}
+	if block.Type != "dynamic" {
+		renameAddress(block.Body)
+		inner := migrateApplyEdits(src[start:block.CloseBraceRange.Start.Byte], edits)
+
+		return arg + " {" + string(inner) + "}"
+	}
+	for _, content := range block.Body.Blocks {
+		if content.Type == "content" {
+			renameAddress(content.Body)
+		}
+	}
+	inner := string(migrateApplyEdits(src[start:block.CloseBraceRange.Start.Byte], edits))
+	// the iterator keep the name of old block for references in content
+	if _, ok := block.Body.Attributes["iterator"]; !ok {
+		inner = "\niterator = " + blockType + inner
+	}
+
+	return "dynamic \"" + arg + "\" {" + inner + "}"
+}
+
+// migrateInterfaceReference return the edits of a reference to a migrated junos_interface resource
+// or data source (type and arguments).
+// References to arguments without equivalent are listed in report.
+func migrateInterfaceReference(traversal hcl.Traversal, migrated map[string]string,
+	filename string, report io.Writer) []migrateEdit {
+	typeIndex := 0
+	kind := "resource"
+	if traversal.RootName() == "data" {
+		typeIndex = 1
+		kind = "data"
+	}
+	if len(traversal) < typeIndex+2 || migrateTraverseName(traversal[typeIndex]) != migrateInterfaceType {
+		return nil
+	}
+	objectName := migrateTraverseName(traversal[typeIndex+1])
+	newType, ok := migrated[kind+"."+objectName]
+	if !ok {
+		return nil
+	}
+	typeRange := traversal[typeIndex].SourceRange()
+	edits := []migrateEdit{{
+		start: typeRange.End.Byte - len(migrateInterfaceType),
+		end:   typeRange.End.Byte,
+		text:  newType,
+	}}
+	argIndex := typeIndex + 2
+	if len(traversal) <= argIndex {
+		return edits
+	}
+	arg := migrateTraverseName(traversal[argIndex])
+	if arg == "" || arg == "id" {
+		return edits
+	}
+	newName, ok := migrateInterfaceArgs(newType)[arg]
+	if !ok {
+		fmt.Fprintf(report, "# reference to %s.%s (%s:%d) not migrated : %s argument not available on %s\n",
+			migrateInterfaceType, objectName, filename, typeRange.Start.Line, arg, newType)
+
+		return edits
+	}
+	argRange := traversal[argIndex].SourceRange()
+	edits = append(edits, migrateEdit{
+		start: argRange.End.Byte - len(arg),
+		end:   argRange.End.Byte,
+		text:  newName,
+	})
+	// address in old inet_address/inet6_address blocks is cidr_ip in address blocks of family
+	if strings.HasSuffix(newName, "].address") && len(traversal) > argIndex+2 {
+		if _, ok := traversal[argIndex+1].(hcl.TraverseIndex); ok &&
+			migrateTraverseName(traversal[argIndex+2]) == "address" {
+			addressRange := traversal[argIndex+2].SourceRange()
+			edits = append(edits, migrateEdit{
+				start: addressRange.End.Byte - len("address"),
+				end:   addressRange.End.Byte,
+				text:  "cidr_ip",
+			})
+		}
+	}
+
+	return edits
+}
+
+func migrateTraverseName(traverser hcl.Traverser) string {
+	switch t := traverser.(type) {
+	case hcl.TraverseRoot:
+		return t.Name
+	case hcl.TraverseAttr:
+		return t.Name
+	}
+
+	return ""
+}
+
+func migrateSource(src []byte, rng hcl.Range) string {
+	return string(src[rng.Start.Byte:rng.End.Byte])
+}
+
+// migrateLineRange extend the range with the blanks before on its line and the end of line.
+func migrateLineRange(src []byte, rng hcl.Range) (int, int) {
+	start, end := rng.Start.Byte, rng.End.Byte
+	for start > 0 && (src[start-1] == ' ' || src[start-1] == '\t') {
+		start--
+	}
+	for end < len(src) && (src[end] == ' ' || src[end] == '\t' || src[end] == '\r') {
+		end++
+	}
+	if end < len(src) && src[end] == '\n' {
+		end++
+	}
+
+	return start, end
+}
+
+// migrateApplyEdits apply edits (without overlap) on a copy of src.
+func migrateApplyEdits(src []byte, edits []migrateEdit) []byte {
+	sort.Slice(edits, func(i, j int) bool { return edits[i].start > edits[j].start })
+	result := append([]byte{}, src...)
+	for _, edit := range edits {
+		tail := append([]byte(edit.text), result[edit.end:]...)
+		result = append(result[:edit.start], tail...)
+	}
+
+	return result
+}
@@ -0,0 +1,123 @@
+package junos
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+func TestMigrateInterface(t *testing.T) {
+	files := map[string][]byte{
+		"interfaces.tf": []byte(`resource junos_interface "physical" {
+  name             = "ge-0/0/0"
+  description      = "uplink"
+  vlan_tagging     = true
+  complete_destroy = true
+}
+# logical interface
+resource junos_interface "logical" {
+  name            = "${junos_interface.physical.name}.100"
+  vlan_tagging_id = 100
+  inet_address {
+    address = "192.0.2.1/25"
+    vrrp_group {
+      identifier       = 1
+      virtual_address  = ["192.0.2.126"]
+    }
+  }
+  inet_filter_input = "filter_demo"
+  dynamic "inet6_address" {
+    for_each = var.addresses6
+    content {
+      address = inet6_address.value
+    }
+  }
+}
+resource junos_interface "st0" {
+  name = "st0.0"
+  inet = true
+}
+resource junos_interface "ranges" {
+  for_each = toset(var.names)
+  name     = each.value
+}
+data junos_interface "data_logical" {
+  config_interface = "ge-0/0/1.0"
+}
+`),
+		"outputs.tf": []byte(`output "address" {
+  value = junos_interface.logical.inet_address[0].address
+}
+output "filter" {
+  value = data.junos_interface.data_logical.inet_filter_input
+}
+output "tagging" {
+  value = junos_interface.physical.vlan_tagging
+}
+output "inet" {
+  value = junos_interface.st0.inet
+}
+output "ranges" {
+  value = junos_interface.ranges
+}
+`),
+	}
+	var report bytes.Buffer
+	changed, err := MigrateInterface(files, &report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changed) != 2 {
+		t.Fatalf("changed %d files, want 2\n%s", len(changed), report.String())
+	}
+	for name, src := range changed {
+		if _, diags := hclwrite.ParseConfig(src, name, hcl.InitialPos); diags.HasErrors() {
+			t.Fatalf("migrated %s is invalid: %v\n%s", name, diags, src)
+		}
+	}
+	interfaces := strings.Join(strings.Fields(string(changed["interfaces.tf"])), " ")
+	for _, want := range []string{
+		`resource junos_interface_physical "physical" { name = "ge-0/0/0" description = "uplink" ` +
+			`vlan_tagging = true no_disable_on_destroy = true }`,
+		`import { to = junos_interface_physical.physical id = "ge-0/0/0" }`,
+		`removed { from = junos_interface.physical lifecycle { destroy = false } }`,
+		`# logical interface resource junos_interface_logical "logical" { ` +
+			`name = "${junos_interface_physical.physical.name}.100" vlan_id = 100 family_inet { ` +
+			`filter_input = "filter_demo" address { cidr_ip = "192.0.2.1/25" vrrp_group { identifier = 1`,
+		`family_inet6 { dynamic "address" { iterator = inet6_address for_each = var.addresses6 ` +
+			`content { cidr_ip = inet6_address.value } } } }`,
+		`id = "${junos_interface_physical.physical.name}.100"`,
+		`resource junos_interface_logical "st0" { name = "st0.0" family_inet { } }`,
+		`resource junos_interface "ranges" {`,
+		`data junos_interface_logical "data_logical" { config_interface = "ge-0/0/1.0" }`,
+	} {
+		if !strings.Contains(interfaces, want) {
+			t.Errorf("migrated interfaces.tf without %s\n%s", want, changed["interfaces.tf"])
+		}
+	}
+	outputs := strings.Join(strings.Fields(string(changed["outputs.tf"])), " ")
+	for _, want := range []string{
+		`value = junos_interface_logical.logical.family_inet[0].address[0].cidr_ip`,
+		`value = data.junos_interface_logical.data_logical.family_inet[0].filter_input`,
+		`value = junos_interface_physical.physical.vlan_tagging`,
+		`value = junos_interface_logical.st0.inet`,
+		`value = junos_interface.ranges`,
+	} {
+		if !strings.Contains(outputs, want) {
+			t.Errorf("migrated outputs.tf without %s\n%s", want, changed["outputs.tf"])
+		}
+	}
+	for _, want := range []string{
+		"# junos_interface.ranges (interfaces.tf) not migrated : for_each argument not supported",
+		"# reference to junos_interface.st0 (outputs.tf:11) not migrated : " +
+			"inet argument not available on junos_interface_logical",
+		"# 3 resources and 1 data sources migrated, 1 not migrated",
+	} {
+		if !strings.Contains(report.String(), want) {
+			t.Errorf("report without %q\n%s", want, report.String())
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/xml"
 	"errors"
 	"fmt"
+	"html"
 	"strings"
 
 	"github.com/jeremmfr/go-netconf/netconf"
@@ -14,6 +15,7 @@ const (
 	warningSeverity string = "warning"
 
 	rpcCommand         = "<command format=\"text\">%s</command>"
+	rpcCommandFormat   = "<command format=\"%s\">%s</command>"
 	rpcConfigStringSet = "<load-configuration action=\"set\" format=\"text\">" +
 		"<configuration-set>%s</configuration-set></load-configuration>"
 	rpcSystemInfo      = "<get-system-information/>"
@@ -28,6 +30,19 @@ const (
 type NetconfObject struct {
 	Session           *netconf.Session
 	SystemInformation sysInfo `xml:"system-information"`
+	transport         junosTransport
+}
+
+// junosTransport : operations on Junos device with a transport other than netconf over ssh.
+type junosTransport interface {
+	command(cmd string) (string, error)
+	commandXML(cmd string) (string, error)
+	configSet(cmd []string) (string, error)
+	configLock() bool
+	configUnlock() []error
+	configClear() []error
+	commit(logMessage string) ([]error, error)
+	close() error
 }
 
 type sysInfo struct {
@@ -172,7 +187,10 @@ func (j *NetconfObject) gatherFacts() error {
 
 // netconfCommand (show, execute) on Junos device.
 func (j *NetconfObject) netconfCommand(cmd string) (string, error) {
-	command := fmt.Sprintf(rpcCommand, cmd)
+	if j.transport != nil {
+		return j.transport.command(cmd)
+	}
+	command := fmt.Sprintf(rpcCommand, html.EscapeString(cmd))
 	reply, err := j.Session.Exec(netconf.RawMethod(command))
 	if err != nil {
 		return "", fmt.Errorf("failed to netconf command exec : %w", err)
@@ -190,10 +208,13 @@ func (j *NetconfObject) netconfCommand(cmd string) (string, error) {
 		return "", fmt.Errorf("failed to xml unmarshal reply : %w", err)
 	}
 
-	return output.Config, nil
+	return html.UnescapeString(output.Config), nil
 }
 
 func (j *NetconfObject) netconfCommandXML(cmd string) (string, error) {
+	if j.transport != nil {
+		return j.transport.commandXML(cmd)
+	}
 	reply, err := j.Session.Exec(netconf.RawMethod(cmd))
 	if err != nil {
 		return "", fmt.Errorf("failed to netconf xml command exec : %w", err)
@@ -208,7 +229,10 @@ func (j *NetconfObject) netconfCommandXML(cmd string) (string, error) {
 }
 
 func (j *NetconfObject) netconfConfigSet(cmd []string) (string, error) {
-	command := fmt.Sprintf(rpcConfigStringSet, strings.Join(cmd, "\n"))
+	if j.transport != nil {
+		return j.transport.configSet(cmd)
+	}
+	command := fmt.Sprintf(rpcConfigStringSet, html.EscapeString(strings.Join(cmd, "\n")))
 	reply, err := j.Session.Exec(netconf.RawMethod(command))
 	if err != nil {
 		return "", fmt.Errorf("failed to netconf set/delete command exec : %w", err)
@@ -228,6 +252,9 @@ func (j *NetconfObject) netconfConfigSet(cmd []string) (string, error) {
 
 // netConfConfigLock locks the candidate configuration.
 func (j *NetconfObject) netconfConfigLock() bool {
+	if j.transport != nil {
+		return j.transport.configLock()
+	}
 	reply, err := j.Session.Exec(netconf.RawMethod(rpcCandidateLock))
 	if err != nil {
 		return false
@@ -241,6 +268,9 @@ func (j *NetconfObject) netconfConfigLock() bool {
 
 // Unlock unlocks the candidate configuration.
 func (j *NetconfObject) netconfConfigUnlock() []error {
+	if j.transport != nil {
+		return j.transport.configUnlock()
+	}
 	reply, err := j.Session.Exec(netconf.RawMethod(rpcCandidateUnlock))
 	if err != nil {
 		return []error{fmt.Errorf("failed to netconf config unlock : %w", err)}
@@ -258,6 +288,9 @@ func (j *NetconfObject) netconfConfigUnlock() []error {
 }
 
 func (j *NetconfObject) netconfConfigClear() []error {
+	if j.transport != nil {
+		return j.transport.configClear()
+	}
 	reply, err := j.Session.Exec(netconf.RawMethod(rpcClearCandidate))
 	if err != nil {
 		return []error{fmt.Errorf("failed to netconf config clear : %w", err)}
@@ -276,8 +309,11 @@ func (j *NetconfObject) netconfConfigClear() []error {
 
 // netconfCommit commits the configuration.
 func (j *NetconfObject) netconfCommit(logMessage string) (_warn []error, _err error) {
+	if j.transport != nil {
+		return j.transport.commit(logMessage)
+	}
 	var errs commitResults
-	reply, err := j.Session.Exec(netconf.RawMethod(fmt.Sprintf(rpcCommit, logMessage)))
+	reply, err := j.Session.Exec(netconf.RawMethod(fmt.Sprintf(rpcCommit, html.EscapeString(logMessage))))
 	if err != nil {
 		return []error{}, fmt.Errorf("failed to netconf commit : %w", err)
 	}
@@ -323,6 +359,12 @@ func (j *NetconfObject) netconfCommit(logMessage string) (_warn []error, _err er
 
 // Close disconnects our session to the device.
 func (j *NetconfObject) close(sleepClosed int) error {
+	if j.transport != nil {
+		err := j.transport.close()
+		sleep(sleepClosed)
+
+		return err
+	}
 	_, err := j.Session.Exec(netconf.RawMethod(rpcClose))
 	j.Session.Transport.Close()
 	if err != nil {
@@ -6,13 +6,14 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 var mutex = &sync.Mutex{} // nolint: gochecknoglobals
 
 // Provider junos for terraform.
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	provider := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"ip": {
 				Type:        schema.TypeString,
@@ -49,6 +50,45 @@ func Provider() *schema.Provider {
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("JUNOS_KEYPASS", nil),
 			},
+			"transport": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("JUNOS_TRANSPORT", transportNetconf),
+				ValidateFunc: validation.StringInSlice([]string{transportNetconf, transportREST, transportGNMI}, false),
+			},
+			"outbound_ssh_listen": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("JUNOS_OUTBOUND_SSH_LISTEN", nil),
+			},
+			"outbound_ssh_device_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("JUNOS_OUTBOUND_SSH_DEVICE_ID", nil),
+			},
+			"outbound_ssh_secret": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("JUNOS_OUTBOUND_SSH_SECRET", nil),
+			},
+			"tls_ca_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("JUNOS_TLS_CA_FILE", nil),
+			},
+			"tls_cert_file": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("JUNOS_TLS_CERT_FILE", nil),
+				RequiredWith: []string{"tls_key_file"},
+			},
+			"tls_key_file": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("JUNOS_TLS_KEY_FILE", nil),
+				RequiredWith: []string{"tls_cert_file"},
+			},
 			"group_interface_delete": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -85,6 +125,42 @@ func Provider() *schema.Provider {
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("JUNOS_FAKECREATE_SETFILE", ""),
 			},
+			"schema_validation_cache_dir": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("JUNOS_SCHEMA_CACHE_DIR", ""),
+			},
+			"ownership_marker": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("JUNOS_OWNERSHIP_MARKER", false),
+			},
+			"ownership_workspace_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("JUNOS_OWNERSHIP_WORKSPACE_ID", ""),
+			},
+			"ownership_import_mismatch": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("JUNOS_OWNERSHIP_IMPORT_MISMATCH", ownershipImportError),
+				ValidateFunc: validation.StringInSlice([]string{ownershipImportError, ownershipImportWarning}, false),
+			},
+			"strict": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("JUNOS_STRICT", false),
+			},
+			"commit_log_suffix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("JUNOS_COMMIT_LOG_SUFFIX", ""),
+			},
+			"command_allowed_prefixes": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"junos_aggregate_route":                                      resourceAggregateRoute(),
@@ -93,6 +169,8 @@ func Provider() *schema.Provider {
 			"junos_bgp_group":                                            resourceBgpGroup(),
 			"junos_bgp_neighbor":                                         resourceBgpNeighbor(),
 			"junos_chassis_cluster":                                      resourceChassisCluster(),
+			"junos_config":                                               resourceConfig(),
+			"junos_config_export":                                        resourceConfigExport(),
 			"junos_firewall_filter":                                      resourceFirewallFilter(),
 			"junos_firewall_policer":                                     resourceFirewallPolicer(),
 			"junos_forwardingoptions_sampling_instance":                  resourceForwardingoptionsSamplingInstance(),
@@ -109,7 +187,9 @@ func Provider() *schema.Provider {
 			"junos_policyoptions_community":                              resourcePolicyoptionsCommunity(),
 			"junos_policyoptions_policy_statement":                       resourcePolicyoptionsPolicyStatement(),
 			"junos_policyoptions_prefix_list":                            resourcePolicyoptionsPrefixList(),
+			"junos_request":                                              resourceRequest(),
 			"junos_rib_group":                                            resourceRibGroup(),
+			"junos_rollback":                                             resourceRollback(),
 			"junos_routing_instance":                                     resourceRoutingInstance(),
 			"junos_routing_options":                                      resourceRoutingOptions(),
 			"junos_security":                                             resourceSecurity(),
@@ -159,19 +239,33 @@ func Provider() *schema.Provider {
 			"junos_system_login_user":                                    resourceSystemLoginUser(),
 			"junos_system_ntp_server":                                    resourceSystemNtpServer(),
 			"junos_system_radius_server":                                 resourceSystemRadiusServer(),
+			"junos_system_rescue_configuration":                          resourceSystemRescueConfiguration(),
 			"junos_system_root_authentication":                           resourceSystemRootAuthentication(),
 			"junos_system_syslog_file":                                   resourceSystemSyslogFile(),
 			"junos_system_syslog_host":                                   resourceSystemSyslogHost(),
 			"junos_vlan":                                                 resourceVlan(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
+			"junos_command":            dataSourceCommand(),
+			"junos_commit_history":     dataSourceCommitHistory(),
+			"junos_config_inventory":   dataSourceConfigInventory(),
 			"junos_interface":          dataSourceInterface(),
 			"junos_interface_logical":  dataSourceInterfaceLogical(),
 			"junos_interface_physical": dataSourceInterfacePhysical(),
+			"junos_rpc":                dataSourceRPC(),
 			"junos_system_information": dataSourceSystemInformation(),
 		},
 		ConfigureContextFunc: configureProvider,
 	}
+	addCustomizeDiffSchemaValidation(provider.ResourcesMap)
+	addCustomizeDiffCompatibility(provider.ResourcesMap)
+	addGNMITransportCheck(provider.ResourcesMap, provider.DataSourcesMap)
+	addInactiveAttribute(provider.ResourcesMap)
+	addOwnershipMarker(provider.ResourcesMap)
+	addStrictMode(provider.ResourcesMap)
+	addSecretHandling(provider.ResourcesMap)
+
+	return provider
 }
 
 func configureProvider(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
@@ -183,6 +277,13 @@ func configureProvider(ctx context.Context, d *schema.ResourceData) (interface{}
 		junosSSHKeyPEM:           d.Get("sshkey_pem").(string),
 		junosSSHKeyFile:          d.Get("sshkeyfile").(string),
 		junosKeyPass:             d.Get("keypass").(string),
+		junosTransport:           d.Get("transport").(string),
+		junosOutboundSSHListen:   d.Get("outbound_ssh_listen").(string),
+		junosOutboundSSHDeviceID: d.Get("outbound_ssh_device_id").(string),
+		junosOutboundSSHSecret:   d.Get("outbound_ssh_secret").(string),
+		junosTLSCAFile:           d.Get("tls_ca_file").(string),
+		junosTLSCertFile:         d.Get("tls_cert_file").(string),
+		junosTLSKeyFile:          d.Get("tls_key_file").(string),
 		junosGroupIntDel:         d.Get("group_interface_delete").(string),
 		junosCmdSleepShort:       d.Get("cmd_sleep_short").(int),
 		junosCmdSleepLock:        d.Get("cmd_sleep_lock").(int),
@@ -190,6 +291,15 @@ func configureProvider(ctx context.Context, d *schema.ResourceData) (interface{}
 		junosFilePermission:      d.Get("file_permission").(string),
 		junosDebugNetconfLogPath: d.Get("debug_netconf_log_path").(string),
 		junosFakeCreateSetFile:   d.Get("fake_create_with_setfile").(string),
+		junosSchemaCacheDir:      d.Get("schema_validation_cache_dir").(string),
+		junosOwnershipMarker:     d.Get("ownership_marker").(bool),
+		junosOwnershipWorkspace:  d.Get("ownership_workspace_id").(string),
+		junosOwnershipImport:     d.Get("ownership_import_mismatch").(string),
+		junosStrict:              d.Get("strict").(bool),
+		junosCommitLogSuffix:     d.Get("commit_log_suffix").(string),
+	}
+	for _, v := range d.Get("command_allowed_prefixes").([]interface{}) {
+		c.junosCommandPrefixes = append(c.junosCommandPrefixes, v.(string))
 	}
 
 	return c.prepareSession()
@@ -3,6 +3,8 @@ package junos_test
 import (
 	"context"
 	"os"
+	"strconv"
+	"sync"
 	"terraform-provider-junos/junos"
 	"testing"
 
@@ -15,6 +17,9 @@ var (
 		"junos": testAccProvider,
 	}
 	testAccProvider = junos.Provider() // nolint: gochecknoglobals
+
+	testAccFakeDevice     *junos.FakeDevice // nolint: gochecknoglobals
+	testAccFakeDeviceOnce sync.Once         // nolint: gochecknoglobals
 )
 
 const defaultInterfaceTestAcc = "ge-0/0/3"
@@ -34,8 +39,16 @@ func TestProvider_impl(t *testing.T) {
 // (interface inet, 802.3ad, routing instance, security zone/nat/ike/ipsec, etc  ).
 // Few resources and parameters works on both devices, but most tested without TESTACC_SWITCH
 
+// without JUNOS_HOST and JUNOS_KEYFILE (or with TESTACC_FAKE_DEVICE), acceptance tests run against
+// an in-process fake device which announces the hardware model of TESTACC_FAKE_DEVICE (default vsrx)
+// (TESTACC_FAKE_DEVICE_VERSION to change the Junos version, default 20.2R1.10).
+
 func testAccPreCheck(t *testing.T) {
 	t.Helper()
+	if os.Getenv("TESTACC_FAKE_DEVICE") != "" ||
+		(os.Getenv("JUNOS_HOST") == "" && os.Getenv("JUNOS_KEYFILE") == "") {
+		testAccStartFakeDevice(t)
+	}
 	if os.Getenv("JUNOS_HOST") == "" && os.Getenv("JUNOS_KEYFILE") == "" {
 		t.Fatal("JUNOS_HOST must be set for acceptance tests")
 	}
@@ -50,3 +63,42 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+// testAccStartFakeDevice start the fake device once and set environment variables of provider to use it.
+func testAccStartFakeDevice(t *testing.T) {
+	t.Helper()
+	testAccFakeDeviceOnce.Do(func() {
+		version := os.Getenv("TESTACC_FAKE_DEVICE_VERSION")
+		if version == "" {
+			version = "20.2R1.10"
+		}
+		model := os.Getenv("TESTACC_FAKE_DEVICE")
+		if model == "" {
+			model = "vsrx"
+		}
+		fake, err := junos.StartFakeDevice(model, version)
+		if err != nil {
+			t.Fatal(err)
+		}
+		testAccFakeDevice = fake
+	})
+	if testAccFakeDevice == nil {
+		t.Fatal("fake device not started")
+	}
+	for k, v := range map[string]string{
+		"JUNOS_HOST":     testAccFakeDevice.Host(),
+		"JUNOS_PORT":     strconv.Itoa(testAccFakeDevice.Port()),
+		"JUNOS_USERNAME": testAccFakeDevice.Username(),
+		"JUNOS_PASSWORD": testAccFakeDevice.Password(),
+	} {
+		if err := os.Setenv(k, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the fake device replies without delay, no need to wait long between lock attempts
+	if os.Getenv("JUNOS_SLEEP_LOCK") == "" {
+		if err := os.Setenv("JUNOS_SLEEP_LOCK", "1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -119,6 +119,10 @@ func resourceAggregateRoute() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"inactive": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
 		},
 	}
 }
@@ -234,13 +238,9 @@ func resourceAggregateRouteUpdate(ctx context.Context, d *schema.ResourceData, m
 	defer sess.closeSession(jnprSess)
 	sess.configLock(jnprSess)
 	var diagWarns diag.Diagnostics
-	if err := delAggregateRoute(d.Get("destination").(string), d.Get("routing_instance").(string),
-		m, jnprSess); err != nil {
-		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
-
-		return append(diagWarns, diag.FromErr(err)...)
-	}
-	if err := setAggregateRoute(d, m, jnprSess); err != nil {
+	if err := sess.configSetDelta(func(sessRecord interface{}) error {
+		return replaceAggregateRoute(d, sessRecord, jnprSess)
+	}, jnprSess); err != nil {
 		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
 
 		return append(diagWarns, diag.FromErr(err)...)
@@ -356,6 +356,15 @@ func checkAggregateRouteExists(destination string, instance string, m interface{
 	return true, nil
 }
 
+func replaceAggregateRoute(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error {
+	if err := delAggregateRoute(d.Get("destination").(string), d.Get("routing_instance").(string),
+		m, jnprSess); err != nil {
+		return err
+	}
+
+	return setAggregateRoute(d, m, jnprSess)
+}
+
 func setAggregateRoute(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error {
 	sess := m.(*Session)
 	configSet := make([]string, 0)
@@ -421,6 +430,8 @@ func setAggregateRoute(d *schema.ResourceData, m interface{}, jnprSess *NetconfO
 		configSet = append(configSet, setPrefix+" preference "+strconv.Itoa(d.Get("preference").(int)))
 	}
 
+	configSet = configSetInactive(d, setPrefix, configSet)
+
 	return sess.configSet(configSet, jnprSess)
 }
 
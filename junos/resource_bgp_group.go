@@ -80,6 +80,7 @@ func resourceBgpGroup() *schema.Resource {
 			"authentication_key": {
 				Type:          schema.TypeString,
 				Optional:      true,
+				Sensitive:     true,
 				ConflictsWith: []string{"authentication_algorithm", "authentication_key_chain"},
 			},
 			"authentication_key_chain": {
@@ -592,6 +593,10 @@ func resourceBgpGroup() *schema.Resource {
 				Type:     schema.TypeBool,
 				Optional: true,
 			},
+			"inactive": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
 		},
 	}
 }
@@ -702,12 +707,9 @@ func resourceBgpGroupUpdate(ctx context.Context, d *schema.ResourceData, m inter
 	defer sess.closeSession(jnprSess)
 	sess.configLock(jnprSess)
 	var diagWarns diag.Diagnostics
-	if err := delBgpOpts(d, "group", m, jnprSess); err != nil {
-		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
-
-		return append(diagWarns, diag.FromErr(err)...)
-	}
-	if err := setBgpGroup(d, m, jnprSess); err != nil {
+	if err := sess.configSetDelta(func(sessRecord interface{}) error {
+		return replaceBgpGroup(d, sessRecord, jnprSess)
+	}, jnprSess); err != nil {
 		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
 
 		return append(diagWarns, diag.FromErr(err)...)
@@ -803,6 +805,14 @@ func checkBgpGroupExists(bgpGroup, instance string, m interface{}, jnprSess *Net
 	return true, nil
 }
 
+func replaceBgpGroup(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error {
+	if err := delBgpOpts(d, "group", m, jnprSess); err != nil {
+		return err
+	}
+
+	return setBgpGroup(d, m, jnprSess)
+}
+
 func setBgpGroup(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error {
 	setPrefix := setLineStart
 	if d.Get("routing_instance").(string) == defaultWord {
@@ -839,7 +849,14 @@ func setBgpGroup(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject)
 		return err
 	}
 
-	return setBgpOptsGrafefulRestart(setPrefix, d.Get("graceful_restart").([]interface{}), m, jnprSess)
+	if err := setBgpOptsGrafefulRestart(setPrefix, d.Get("graceful_restart").([]interface{}), m, jnprSess); err != nil {
+		return err
+	}
+	if configSet := configSetInactive(d, setPrefix, make([]string, 0)); len(configSet) > 0 {
+		return sess.configSet(configSet, jnprSess)
+	}
+
+	return nil
 }
 
 func readBgpGroup(bgpGroup, instance string, m interface{}, jnprSess *NetconfObject) (bgpOptions, error) {
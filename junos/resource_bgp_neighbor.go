@@ -79,6 +79,7 @@ func resourceBgpNeighbor() *schema.Resource {
 			"authentication_key": {
 				Type:          schema.TypeString,
 				Optional:      true,
+				Sensitive:     true,
 				ConflictsWith: []string{"authentication_algorithm", "authentication_key_chain"},
 			},
 			"authentication_key_chain": {
@@ -584,6 +585,10 @@ func resourceBgpNeighbor() *schema.Resource {
 				Type:     schema.TypeBool,
 				Optional: true,
 			},
+			"inactive": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
 		},
 	}
 }
@@ -713,12 +718,9 @@ func resourceBgpNeighborUpdate(ctx context.Context, d *schema.ResourceData, m in
 	defer sess.closeSession(jnprSess)
 	sess.configLock(jnprSess)
 	var diagWarns diag.Diagnostics
-	if err := delBgpOpts(d, "neighbor", m, jnprSess); err != nil {
-		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
-
-		return append(diagWarns, diag.FromErr(err)...)
-	}
-	if err := setBgpNeighbor(d, m, jnprSess); err != nil {
+	if err := sess.configSetDelta(func(sessRecord interface{}) error {
+		return replaceBgpNeighbor(d, sessRecord, jnprSess)
+	}, jnprSess); err != nil {
 		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
 
 		return append(diagWarns, diag.FromErr(err)...)
@@ -814,6 +816,14 @@ func checkBgpNeighborExists(ip, instance, group string, m interface{}, jnprSess
 	return true, nil
 }
 
+func replaceBgpNeighbor(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error {
+	if err := delBgpOpts(d, "neighbor", m, jnprSess); err != nil {
+		return err
+	}
+
+	return setBgpNeighbor(d, m, jnprSess)
+}
+
 func setBgpNeighbor(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error {
 	setPrefix := setLineStart
 	if d.Get("routing_instance").(string) == defaultWord {
@@ -824,6 +834,7 @@ func setBgpNeighbor(d *schema.ResourceData, m interface{}, jnprSess *NetconfObje
 			" protocols bgp group " + d.Get("group").(string) +
 			" neighbor " + d.Get("ip").(string) + " "
 	}
+	sess := m.(*Session)
 	if err := setBgpOptsSimple(setPrefix, d, m, jnprSess); err != nil {
 		return err
 	}
@@ -840,7 +851,14 @@ func setBgpNeighbor(d *schema.ResourceData, m interface{}, jnprSess *NetconfObje
 		return err
 	}
 
-	return setBgpOptsGrafefulRestart(setPrefix, d.Get("graceful_restart").([]interface{}), m, jnprSess)
+	if err := setBgpOptsGrafefulRestart(setPrefix, d.Get("graceful_restart").([]interface{}), m, jnprSess); err != nil {
+		return err
+	}
+	if configSet := configSetInactive(d, setPrefix, make([]string, 0)); len(configSet) > 0 {
+		return sess.configSet(configSet, jnprSess)
+	}
+
+	return nil
 }
 
 func readBgpNeighbor(ip, instance, group string, m interface{}, jnprSess *NetconfObject) (bgpOptions, error) {
@@ -0,0 +1,382 @@
+package junos
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceConfig() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceConfigCreate,
+		ReadContext:   resourceConfigRead,
+		UpdateContext: resourceConfigUpdate,
+		DeleteContext: resourceConfigDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceConfigImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"hierarchy": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateConfigStatement(),
+			},
+			"lines": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validateConfigStatement(),
+				},
+			},
+			"unmanaged_lines": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceConfigCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sess := m.(*Session)
+	if sess.junosFakeCreateSetFile != "" {
+		if err := setConfig(d, m, nil); err != nil {
+			return diag.FromErr(err)
+		}
+		d.SetId(d.Get("hierarchy").(string))
+
+		return nil
+	}
+	jnprSess, err := sess.startNewSession()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer sess.closeSession(jnprSess)
+	sess.configLock(jnprSess)
+	var diagWarns diag.Diagnostics
+	currentLines, err := readConfig(d.Get("hierarchy").(string), m, jnprSess)
+	if err != nil {
+		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
+
+		return append(diagWarns, diag.FromErr(err)...)
+	}
+	// only the configured lines are checked, other statements under hierarchy are not managed by resource
+	for _, v := range d.Get("lines").([]interface{}) {
+		if configLineFound(v.(string), currentLines) {
+			appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
+
+			return append(diagWarns, diag.FromErr(fmt.Errorf("line '%s' already exists under hierarchy '%v'",
+				v.(string), d.Get("hierarchy").(string)))...)
+		}
+	}
+	if err := setConfig(d, m, jnprSess); err != nil {
+		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
+
+		return append(diagWarns, diag.FromErr(err)...)
+	}
+	warns, err := sess.commitConf("create resource junos_config", jnprSess)
+	appendDiagWarns(&diagWarns, warns)
+	if err != nil {
+		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
+
+		return append(diagWarns, diag.FromErr(err)...)
+	}
+	currentLines, err = readConfig(d.Get("hierarchy").(string), m, jnprSess)
+	if err != nil {
+		return append(diagWarns, diag.FromErr(err)...)
+	}
+	for _, v := range d.Get("lines").([]interface{}) {
+		if configLineFound(v.(string), currentLines) {
+			d.SetId(d.Get("hierarchy").(string))
+
+			break
+		}
+	}
+	if d.Id() == "" {
+		return append(diagWarns, diag.FromErr(fmt.Errorf("lines under hierarchy '%v' not exists after commit "+
+			"=> check your config", d.Get("hierarchy").(string)))...)
+	}
+
+	return append(diagWarns, resourceConfigReadWJnprSess(d, m, jnprSess)...)
+}
+
+func resourceConfigRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sess := m.(*Session)
+	jnprSess, err := sess.startNewSession()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer sess.closeSession(jnprSess)
+
+	return resourceConfigReadWJnprSess(d, m, jnprSess)
+}
+
+func resourceConfigReadWJnprSess(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) diag.Diagnostics {
+	mutex.Lock()
+	lines, err := readConfig(d.Get("hierarchy").(string), m, jnprSess)
+	mutex.Unlock()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if !fillConfigData(d, lines, d.Get("lines").([]interface{})) {
+		d.SetId("")
+	}
+
+	return nil
+}
+
+func resourceConfigUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.Partial(true)
+	sess := m.(*Session)
+	jnprSess, err := sess.startNewSession()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer sess.closeSession(jnprSess)
+	sess.configLock(jnprSess)
+	var diagWarns diag.Diagnostics
+	if err := sess.configSetDelta(func(sessRecord interface{}) error {
+		return replaceConfig(d, sessRecord, jnprSess)
+	}, jnprSess); err != nil {
+		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
+
+		return append(diagWarns, diag.FromErr(err)...)
+	}
+	warns, err := sess.commitConf("update resource junos_config", jnprSess)
+	appendDiagWarns(&diagWarns, warns)
+	if err != nil {
+		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
+
+		return append(diagWarns, diag.FromErr(err)...)
+	}
+
+	d.Partial(false)
+
+	return append(diagWarns, resourceConfigReadWJnprSess(d, m, jnprSess)...)
+}
+
+func resourceConfigDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sess := m.(*Session)
+	jnprSess, err := sess.startNewSession()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer sess.closeSession(jnprSess)
+	sess.configLock(jnprSess)
+	var diagWarns diag.Diagnostics
+	lines := d.Get("lines").([]interface{})
+	if sess.junosOwnershipMarker {
+		lines = append(lines, ownershipMacroWords)
+	}
+	if err := delConfig(d.Get("hierarchy").(string), lines, m, jnprSess); err != nil {
+		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
+
+		return append(diagWarns, diag.FromErr(err)...)
+	}
+	warns, err := sess.commitConf("delete resource junos_config", jnprSess)
+	appendDiagWarns(&diagWarns, warns)
+	if err != nil {
+		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
+
+		return append(diagWarns, diag.FromErr(err)...)
+	}
+
+	return diagWarns
+}
+
+func resourceConfigImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	sess := m.(*Session)
+	jnprSess, err := sess.startNewSession()
+	if err != nil {
+		return nil, err
+	}
+	defer sess.closeSession(jnprSess)
+	result := make([]*schema.ResourceData, 1)
+	lines, err := readConfig(d.Id(), m, jnprSess)
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("don't find configuration with id '%v' (id must be <hierarchy>)", d.Id())
+	}
+	if tfErr := d.Set("hierarchy", d.Id()); tfErr != nil {
+		panic(tfErr)
+	}
+	configured := make([]interface{}, len(lines))
+	for i, line := range lines {
+		configured[i] = line
+	}
+	fillConfigData(d, lines, configured)
+
+	result[0] = d
+
+	return result, nil
+}
+
+// replaceConfig delete the lines in state then set the lines of configuration.
+func replaceConfig(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error {
+	oldLines, _ := d.GetChange("lines")
+	if err := delConfig(d.Get("hierarchy").(string), oldLines.([]interface{}), m, jnprSess); err != nil {
+		return err
+	}
+
+	return setConfig(d, m, jnprSess)
+}
+
+func setConfig(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error {
+	sess := m.(*Session)
+	configSet := make([]string, 0)
+
+	setPrefix := setLineStart + d.Get("hierarchy").(string) + " "
+	for _, v := range d.Get("lines").([]interface{}) {
+		configSet = append(configSet, setPrefix+v.(string))
+	}
+
+	return sess.configSet(configSet, jnprSess)
+}
+
+// readConfig read the statements under hierarchy ('display set relative' lines without 'set' word).
+// Deactivate lines and ownership marker are ignored.
+func readConfig(hierarchy string, m interface{}, jnprSess *NetconfObject) ([]string, error) {
+	sess := m.(*Session)
+	lines := make([]string, 0)
+	showConfig, err := sess.command(showConfigurationWords+" "+hierarchy+" "+displaySetWords+" "+displaySetRelative,
+		jnprSess)
+	if err != nil {
+		return lines, err
+	}
+	if showConfig == emptyWord {
+		return lines, nil
+	}
+	for _, item := range strings.Split(showConfig, "\n") {
+		if strings.Contains(item, "<configuration-output>") {
+			continue
+		}
+		if strings.Contains(item, "</configuration-output>") {
+			break
+		}
+		if !strings.HasPrefix(item, setLineStart) {
+			continue
+		}
+		itemTrim := strings.TrimPrefix(item, setLineStart)
+		if strings.HasPrefix(itemTrim, ownershipMacroWords+" ") {
+			continue
+		}
+		lines = append(lines, itemTrim)
+	}
+
+	return lines, nil
+}
+
+// delConfig delete each line under hierarchy,
+// the other statements under hierarchy are not managed by resource and kept.
+func delConfig(hierarchy string, lines []interface{}, m interface{}, jnprSess *NetconfObject) error {
+	sess := m.(*Session)
+	configSet := make([]string, 0, len(lines))
+	for _, v := range lines {
+		configSet = append(configSet, deleteLineStart+hierarchy+" "+v.(string))
+	}
+
+	return sess.configSet(configSet, jnprSess)
+}
+
+// configLineFound check a configured line is found in lines read on device
+// (the device only displays the last statement of a hierarchy so a line can be a parent of lines read).
+func configLineFound(line string, lines []string) bool {
+	configWords := splitSetLineWords(line)
+	for _, v := range lines {
+		if wordsHasPrefix(splitSetLineWords(v), configWords) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// fillConfigData set lines with the configured lines found on device
+// (the device can add or remove quotes, the configured format is kept) to have a diff when a line is missing,
+// and unmanaged_lines with the other lines read on device (not managed by resource).
+// Return false when no configured line is found.
+func fillConfigData(d *schema.ResourceData, lines []string, configured []interface{}) bool {
+	words := make([][]string, 0, len(lines))
+	for _, line := range lines {
+		words = append(words, splitSetLineWords(line))
+	}
+	managed := make([]bool, len(lines))
+	stateLines := make([]string, 0, len(configured))
+	for _, v := range configured {
+		configWords := splitSetLineWords(v.(string))
+		present := false
+		for i, w := range words {
+			if !wordsHasPrefix(w, configWords) {
+				continue
+			}
+			present = true
+			if len(w) == len(configWords) {
+				managed[i] = true
+			}
+		}
+		if present {
+			stateLines = append(stateLines, v.(string))
+		}
+	}
+	unmanagedLines := make([]string, 0)
+	for i, line := range lines {
+		if !managed[i] {
+			unmanagedLines = append(unmanagedLines, line)
+		}
+	}
+	if tfErr := d.Set("lines", stateLines); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("unmanaged_lines", unmanagedLines); tfErr != nil {
+		panic(tfErr)
+	}
+
+	return len(stateLines) > 0
+}
+
+// validateConfigStatement check a statement (hierarchy or line) of configuration without the command word.
+func validateConfigStatement() schema.SchemaValidateDiagFunc {
+	return func(i interface{}, path cty.Path) diag.Diagnostics {
+		var diags diag.Diagnostics
+		v, ok := i.(string)
+		if !ok {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "expected type to be string",
+				AttributePath: path,
+			})
+
+			return diags
+		}
+		if strings.TrimSpace(v) == "" || strings.ContainsAny(v, "\n\r") {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       fmt.Sprintf("statement '%s' must be one line and not empty", v),
+				AttributePath: path,
+			})
+
+			return diags
+		}
+		firstWord := strings.Fields(v)[0]
+		if stringInSlice(firstWord, []string{
+			setWord, deleteWord, "activate", "deactivate", "insert", "protect", "unprotect", "annotate", "rename",
+		}) {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       fmt.Sprintf("statement '%s' must not start with the command '%s'", v, firstWord),
+				AttributePath: path,
+			})
+		}
+
+		return diags
+	}
+}
@@ -0,0 +1,226 @@
+package junos
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"html"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const configExportFormatSet = "set"
+
+func resourceConfigExport() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceConfigExportCreate,
+		ReadContext:   resourceConfigExportRead,
+		DeleteContext: resourceConfigExportDelete,
+		Schema: map[string]*schema.Schema{
+			"hierarchies": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validateConfigStatement(),
+				},
+			},
+			"format": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  configExportFormatSet,
+				ValidateFunc: validation.StringInSlice([]string{
+					configExportFormatSet, commandFormatText, commandFormatXML, commandFormatJSON}, false),
+			},
+			"strip_timestamps": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+			},
+			"strip_secrets": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+			},
+			"filename": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"file_permission": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				RequiredWith:     []string{"filename"},
+				ValidateDiagFunc: validateFilePermission(),
+			},
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+			},
+			"content": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"hash": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceConfigExportCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sess := m.(*Session)
+	if sess.junosFakeCreateSetFile != "" {
+		d.SetId(configExportID(d))
+
+		return nil
+	}
+	jnprSess, err := sess.startNewSession()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer sess.closeSession(jnprSess)
+	content, err := readConfigExport(d, m, jnprSess)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if filename := d.Get("filename").(string); filename != "" {
+		if err := writeConfigExportFile(filename, d.Get("file_permission").(string), content, sess); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	d.SetId(configExportID(d))
+	if tfErr := d.Set("content", content); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("hash", hashConfigExport(content)); tfErr != nil {
+		panic(tfErr)
+	}
+
+	return nil
+}
+
+func resourceConfigExportRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sess := m.(*Session)
+	jnprSess, err := sess.startNewSession()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer sess.closeSession(jnprSess)
+	mutex.Lock()
+	content, err := readConfigExport(d, m, jnprSess)
+	mutex.Unlock()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// configuration changed on device or file removed need a new export
+	if hashConfigExport(content) != d.Get("hash").(string) {
+		d.SetId("")
+
+		return nil
+	}
+	if filename := d.Get("filename").(string); filename != "" {
+		if err := replaceTildeToHomeDir(&filename); err != nil {
+			return diag.FromErr(err)
+		}
+		if _, err := os.Stat(filename); os.IsNotExist(err) {
+			d.SetId("")
+		}
+	}
+
+	return nil
+}
+
+func resourceConfigExportDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// the file is kept as an artifact of export
+	d.SetId("")
+
+	return nil
+}
+
+// configExportID generate id with format and hierarchies.
+func configExportID(d *schema.ResourceData) string {
+	id := []string{d.Get("format").(string)}
+	for _, v := range d.Get("hierarchies").([]interface{}) {
+		id = append(id, v.(string))
+	}
+
+	return strings.Join(id, idSeparator)
+}
+
+// readConfigExport read the committed configuration for each hierarchy (or all) in the format and normalize it.
+func readConfigExport(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) (string, error) {
+	sess := m.(*Session)
+	format := d.Get("format").(string)
+	hierarchies := make([]string, 0)
+	for _, v := range d.Get("hierarchies").([]interface{}) {
+		hierarchies = append(hierarchies, v.(string))
+	}
+	if len(hierarchies) > 1 && (format == commandFormatXML || format == commandFormatJSON) {
+		return "", fmt.Errorf("only one hierarchy can be exported with format %s", format)
+	}
+	if len(hierarchies) == 0 {
+		hierarchies = append(hierarchies, "")
+	}
+	outputs := make([]string, 0, len(hierarchies))
+	for _, hierarchy := range hierarchies {
+		command := strings.TrimSpace(showConfigurationWords + " " + hierarchy)
+		commandFormat := format
+		switch format {
+		case configExportFormatSet:
+			command += " " + displaySetWords
+			commandFormat = commandFormatText
+		case commandFormatText:
+			if len(hierarchies) > 1 {
+				outputs = append(outputs, "## "+hierarchy)
+			}
+		}
+		reply, err := sess.commandXML(fmt.Sprintf(rpcCommandFormat, commandFormat, html.EscapeString(command)),
+			jnprSess)
+		if err != nil {
+			return "", err
+		}
+		output, err := readCommandOutput(reply, commandFormat)
+		if err != nil {
+			return "", fmt.Errorf("reply of command '%s' : %w", command, err)
+		}
+		outputs = append(outputs, strings.TrimSpace(output))
+	}
+
+	return normalizeConfigExport(strings.Join(outputs, "\n"), format,
+		d.Get("strip_timestamps").(bool), d.Get("strip_secrets").(bool))
+}
+
+func writeConfigExportFile(filename, filePermission, content string, sess *Session) error {
+	if err := replaceTildeToHomeDir(&filename); err != nil {
+		return err
+	}
+	permission := sess.junosFilePermission
+	if filePermission != "" {
+		var err error
+		permission, err = strconv.ParseInt(filePermission, 8, 64)
+		if err != nil {
+			return fmt.Errorf("failed to convert value from '%s' to int64 : %w", filePermission, err)
+		}
+	}
+	if err := ioutil.WriteFile(filename, []byte(content+"\n"), os.FileMode(permission)); err != nil {
+		return fmt.Errorf("could not write file `%s` : %w", filename, err)
+	}
+
+	return nil
+}
+
+func hashConfigExport(content string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(content)))
+}
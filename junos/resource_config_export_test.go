@@ -0,0 +1,100 @@
+package junos_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccJunosConfigExport_basic(t *testing.T) {
+	exportFile := filepath.Join(t.TempDir(), "radius.set")
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccJunosConfigExportConfigCreate(exportFile),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("junos_config_export.testacc_export",
+						"id", "set_-_system radius-server"),
+					resource.TestCheckResourceAttr("junos_config_export.testacc_export",
+						"content", `set system radius-server 192.0.2.1 secret "secret-removed"`),
+					resource.TestMatchResourceAttr("junos_config_export.testacc_export",
+						"hash", regexp.MustCompile(`^[0-9a-f]{64}$`)),
+					testAccCheckJunosConfigExportFile(exportFile, `secret "secret-removed"`),
+				),
+			},
+			{
+				// port changes without a change of triggers, export is done again on next apply
+				Config:             testAccJunosConfigExportConfigUpdate(exportFile),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccJunosConfigExportConfigUpdate(exportFile),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("junos_config_export.testacc_export",
+						"content", "set system radius-server 192.0.2.1 secret \"secret-removed\"\n"+
+							"set system radius-server 192.0.2.1 port 1645"),
+					testAccCheckJunosConfigExportFile(exportFile, "port 1645"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckJunosConfigExportFile(filename, want string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		content, err := ioutil.ReadFile(filename)
+		if err != nil {
+			return fmt.Errorf("could not read file `%s` : %w", filename, err)
+		}
+		if !strings.Contains(string(content), want) {
+			return fmt.Errorf("file `%s` doesn't contain `%s` : %s", filename, want, content)
+		}
+
+		return nil
+	}
+}
+
+func testAccJunosConfigExportConfigCreate(filename string) string {
+	return `
+resource junos_system_radius_server testacc_export {
+  address = "192.0.2.1"
+  secret  = "password"
+}
+resource junos_config_export testacc_export {
+  hierarchies     = ["system radius-server"]
+  strip_secrets   = true
+  filename        = "` + filename + `"
+  file_permission = "0600"
+  triggers = {
+    radius = junos_system_radius_server.testacc_export.id
+  }
+}
+`
+}
+
+func testAccJunosConfigExportConfigUpdate(filename string) string {
+	return `
+resource junos_system_radius_server testacc_export {
+  address = "192.0.2.1"
+  secret  = "password"
+  port    = 1645
+}
+resource junos_config_export testacc_export {
+  hierarchies     = ["system radius-server"]
+  strip_secrets   = true
+  filename        = "` + filename + `"
+  file_permission = "0600"
+  triggers = {
+    radius = junos_system_radius_server.testacc_export.id
+  }
+}
+`
+}
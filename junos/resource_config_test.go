@@ -0,0 +1,71 @@
+package junos_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccJunosConfig_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccJunosConfigConfigCreate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("junos_config.testacc_config",
+						"id", "snmp community testacc_config"),
+					resource.TestCheckResourceAttr("junos_config.testacc_config",
+						"lines.#", "2"),
+					resource.TestCheckResourceAttr("junos_config.testacc_config",
+						"lines.1", "clients 192.0.2.0/25"),
+				),
+			},
+			{
+				Config: testAccJunosConfigConfigUpdate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("junos_config.testacc_config",
+						"lines.#", "3"),
+					resource.TestCheckResourceAttr("junos_config.testacc_config",
+						"lines.0", "authorization read-write"),
+					resource.TestCheckResourceAttr("junos_config.testacc_config",
+						"lines.2", "clients \"192.0.2.128/25\""),
+				),
+			},
+			{
+				ResourceName:      "junos_config.testacc_config",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"lines",
+				},
+			},
+		},
+	})
+}
+
+func testAccJunosConfigConfigCreate() string {
+	return `
+resource junos_config testacc_config {
+  hierarchy = "snmp community testacc_config"
+  lines = [
+    "authorization read-only",
+    "clients 192.0.2.0/25",
+  ]
+}
+`
+}
+
+func testAccJunosConfigConfigUpdate() string {
+	return `
+resource junos_config testacc_config {
+  hierarchy = "snmp community testacc_config"
+  lines = [
+    "authorization read-write",
+    "clients 192.0.2.0/25",
+    "clients \"192.0.2.128/25\"",
+  ]
+}
+`
+}
@@ -264,6 +264,10 @@ func resourceFirewallFilter() *schema.Resource {
 					},
 				},
 			},
+			"inactive": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
 		},
 	}
 }
@@ -361,12 +365,9 @@ func resourceFirewallFilterUpdate(ctx context.Context, d *schema.ResourceData, m
 	defer sess.closeSession(jnprSess)
 	sess.configLock(jnprSess)
 	var diagWarns diag.Diagnostics
-	if err := delFirewallFilter(d.Get("name").(string), d.Get("family").(string), m, jnprSess); err != nil {
-		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
-
-		return append(diagWarns, diag.FromErr(err)...)
-	}
-	if err := setFirewallFilter(d, m, jnprSess); err != nil {
+	if err := sess.configSetDelta(func(sessRecord interface{}) error {
+		return replaceFirewallFilter(d, sessRecord, jnprSess)
+	}, jnprSess); err != nil {
 		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
 
 		return append(diagWarns, diag.FromErr(err)...)
@@ -452,6 +453,14 @@ func checkFirewallFilterExists(name, family string, m interface{}, jnprSess *Net
 	return true, nil
 }
 
+func replaceFirewallFilter(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error {
+	if err := delFirewallFilter(d.Get("name").(string), d.Get("family").(string), m, jnprSess); err != nil {
+		return err
+	}
+
+	return setFirewallFilter(d, m, jnprSess)
+}
+
 func setFirewallFilter(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error {
 	sess := m.(*Session)
 	configSet := make([]string, 0)
@@ -479,6 +488,8 @@ func setFirewallFilter(d *schema.ResourceData, m interface{}, jnprSess *NetconfO
 		}
 	}
 
+	configSet = configSetInactive(d, setPrefix, configSet)
+
 	return sess.configSet(configSet, jnprSess)
 }
 
@@ -126,6 +126,10 @@ func resourceGenerateRoute() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"inactive": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
 		},
 	}
 }
@@ -240,13 +244,9 @@ func resourceGenerateRouteUpdate(ctx context.Context, d *schema.ResourceData, m
 	defer sess.closeSession(jnprSess)
 	sess.configLock(jnprSess)
 	var diagWarns diag.Diagnostics
-	if err := delGenerateRoute(
-		d.Get("destination").(string), d.Get("routing_instance").(string), m, jnprSess); err != nil {
-		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
-
-		return append(diagWarns, diag.FromErr(err)...)
-	}
-	if err := setGenerateRoute(d, m, jnprSess); err != nil {
+	if err := sess.configSetDelta(func(sessRecord interface{}) error {
+		return replaceGenerateRoute(d, sessRecord, jnprSess)
+	}, jnprSess); err != nil {
 		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
 
 		return append(diagWarns, diag.FromErr(err)...)
@@ -363,6 +363,15 @@ func checkGenerateRouteExists(
 	return true, nil
 }
 
+func replaceGenerateRoute(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error {
+	if err := delGenerateRoute(
+		d.Get("destination").(string), d.Get("routing_instance").(string), m, jnprSess); err != nil {
+		return err
+	}
+
+	return setGenerateRoute(d, m, jnprSess)
+}
+
 func setGenerateRoute(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error {
 	sess := m.(*Session)
 	configSet := make([]string, 0)
@@ -430,6 +439,8 @@ func setGenerateRoute(d *schema.ResourceData, m interface{}, jnprSess *NetconfOb
 		configSet = append(configSet, setPrefix+"preference "+strconv.Itoa(d.Get("preference").(int)))
 	}
 
+	configSet = configSetInactive(d, setPrefix, configSet)
+
 	return sess.configSet(configSet, jnprSess)
 }
 
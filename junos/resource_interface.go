@@ -10,7 +10,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	jdecode "github.com/jeremmfr/junosdecode"
 )
 
 type interfaceOptions struct {
@@ -134,8 +133,9 @@ func resourceInterface() *schema.Resource {
 										ValidateFunc: validation.IntBetween(1, 15),
 									},
 									"authentication_key": {
-										Type:     schema.TypeString,
-										Optional: true,
+										Type:      schema.TypeString,
+										Optional:  true,
+										Sensitive: true,
 									},
 									"authentication_type": {
 										Type:         schema.TypeString,
@@ -1603,7 +1603,7 @@ func fillFamilyInetAddressOld(item string, inetAddress []map[string]interface{},
 				return inetAddress, fmt.Errorf("failed to convert value from '%s' to integer : %w", itemTrimVrrp, err)
 			}
 		case strings.HasPrefix(itemTrimVrrp, "authentication-key "):
-			vrrpGroup["authentication_key"], err = jdecode.Decode(strings.Trim(strings.TrimPrefix(itemTrimVrrp,
+			vrrpGroup["authentication_key"], err = decodeSecret(strings.Trim(strings.TrimPrefix(itemTrimVrrp,
 				"authentication-key "), "\""))
 			if err != nil {
 				return inetAddress, fmt.Errorf("failed to decode authentication-key : %w", err)
@@ -10,7 +10,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	jdecode "github.com/jeremmfr/junosdecode"
 )
 
 type interfaceLogicalOptions struct {
@@ -103,8 +102,9 @@ func resourceInterfaceLogical() *schema.Resource {
 													ValidateFunc: validation.IntBetween(1, 15),
 												},
 												"authentication_key": {
-													Type:     schema.TypeString,
-													Optional: true,
+													Type:      schema.TypeString,
+													Optional:  true,
+													Sensitive: true,
 												},
 												"authentication_type": {
 													Type:         schema.TypeString,
@@ -1243,7 +1243,7 @@ func fillFamilyInetAddress(item string, inetAddress []map[string]interface{},
 				return inetAddress, fmt.Errorf("failed to convert value from '%s' to integer : %w", itemTrimVrrp, err)
 			}
 		case strings.HasPrefix(itemTrimVrrp, "authentication-key "):
-			vrrpGroup["authentication_key"], err = jdecode.Decode(strings.Trim(strings.TrimPrefix(itemTrimVrrp,
+			vrrpGroup["authentication_key"], err = decodeSecret(strings.Trim(strings.TrimPrefix(itemTrimVrrp,
 				"authentication-key "), "\""))
 			if err != nil {
 				return inetAddress, fmt.Errorf("failed to decode authentication-key : %w", err)
@@ -1263,6 +1263,9 @@ func fillFamilyInetAddress(item string, inetAddress []map[string]interface{},
 			}
 		case strings.HasPrefix(itemTrimVrrp, "track interface "):
 			vrrpSlit := strings.Split(itemTrimVrrp, " ")
+			if len(vrrpSlit) < 5 {
+				return inetAddress, fmt.Errorf("can't find interface and priority-cost in '%s'", itemTrimVrrp)
+			}
 			cost, err := strconv.Atoi(vrrpSlit[len(vrrpSlit)-1])
 			if err != nil {
 				return inetAddress, fmt.Errorf("failed to convert value from '%s' to integer : %w", itemTrimVrrp, err)
@@ -1274,6 +1277,9 @@ func fillFamilyInetAddress(item string, inetAddress []map[string]interface{},
 			vrrpGroup["track_interface"] = append(vrrpGroup["track_interface"].([]map[string]interface{}), trackInt)
 		case strings.HasPrefix(itemTrimVrrp, "track route "):
 			vrrpSlit := strings.Split(itemTrimVrrp, " ")
+			if len(vrrpSlit) < 7 {
+				return inetAddress, fmt.Errorf("can't find route, routing-instance and priority-cost in '%s'", itemTrimVrrp)
+			}
 			cost, err := strconv.Atoi(vrrpSlit[len(vrrpSlit)-1])
 			if err != nil {
 				return inetAddress, fmt.Errorf("failed to convert value from '%s' to integer : %w", itemTrimVrrp, err)
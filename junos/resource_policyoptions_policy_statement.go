@@ -676,6 +676,10 @@ func resourcePolicyoptionsPolicyStatement() *schema.Resource {
 					},
 				},
 			},
+			"inactive": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
 		},
 	}
 }
@@ -777,12 +781,9 @@ func resourcePolicyoptionsPolicyStatementUpdate(
 	defer sess.closeSession(jnprSess)
 	sess.configLock(jnprSess)
 	var diagWarns diag.Diagnostics
-	if err := delPolicyStatement(d.Get("name").(string), m, jnprSess); err != nil {
-		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
-
-		return append(diagWarns, diag.FromErr(err)...)
-	}
-	if err := setPolicyStatement(d, m, jnprSess); err != nil {
+	if err := sess.configSetDelta(func(sessRecord interface{}) error {
+		return replacePolicyStatement(d, sessRecord, jnprSess)
+	}, jnprSess); err != nil {
 		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
 
 		return append(diagWarns, diag.FromErr(err)...)
@@ -866,6 +867,14 @@ func checkPolicyStatementExists(name string, m interface{}, jnprSess *NetconfObj
 	return true, nil
 }
 
+func replacePolicyStatement(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error {
+	if err := delPolicyStatement(d.Get("name").(string), m, jnprSess); err != nil {
+		return err
+	}
+
+	return setPolicyStatement(d, m, jnprSess)
+}
+
 func setPolicyStatement(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error {
 	sess := m.(*Session)
 	configSet := make([]string, 0)
@@ -912,6 +921,8 @@ func setPolicyStatement(d *schema.ResourceData, m interface{}, jnprSess *Netconf
 		}
 	}
 
+	configSet = configSetInactive(d, setPrefix, configSet)
+
 	return sess.configSet(configSet, jnprSess)
 }
 
@@ -1282,6 +1293,9 @@ func readPolicyStatementOptsFrom(item string, fromMap map[string]interface{}) er
 			"option_value": "",
 		}
 		itemSplit := strings.Split(item, " ")
+		if len(itemSplit) < 3 {
+			return fmt.Errorf("can't find route and option in '%s'", item)
+		}
 		routeFilterMap["route"] = itemSplit[1]
 		routeFilterMap["option"] = itemSplit[2]
 		if len(itemSplit) > 3 {
@@ -1308,6 +1322,9 @@ func readPolicyStatementOptsThen(item string, thenMap map[string]interface{}) er
 			"value":  "",
 		}
 		itemSplit := strings.Split(item, " ")
+		if len(itemSplit) < 3 {
+			return fmt.Errorf("can't find action and value in '%s'", item)
+		}
 		communityMap["action"] = itemSplit[1]
 		communityMap["value"] = itemSplit[2]
 		thenMap["community"] = append(thenMap["community"].([]map[string]interface{}), communityMap)
@@ -0,0 +1,297 @@
+package junos
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// listOfRequestCommands : first words of operational commands allowed in junos_request resource.
+func listOfRequestCommands() []string {
+	return []string{"clear", "request", "restart", "show", "test"}
+}
+
+func resourceRequest() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRequestCreate,
+		ReadContext:   resourceRequestRead,
+		UpdateContext: resourceRequestUpdate,
+		DeleteContext: resourceRequestDelete,
+		Schema: map[string]*schema.Schema{
+			"create_command": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ConflictsWith:    []string{"create_rpc"},
+				AtLeastOneOf:     []string{"create_command", "create_rpc", "destroy_command", "destroy_rpc"},
+				ValidateDiagFunc: validateRequestCommand(),
+			},
+			"create_rpc": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ConflictsWith:    []string{"create_command"},
+				AtLeastOneOf:     []string{"create_command", "create_rpc", "destroy_command", "destroy_rpc"},
+				ValidateDiagFunc: validateRequestRPC(),
+			},
+			"destroy_command": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ConflictsWith:    []string{"destroy_rpc"},
+				AtLeastOneOf:     []string{"create_command", "create_rpc", "destroy_command", "destroy_rpc"},
+				ValidateDiagFunc: validateRequestCommand(),
+			},
+			"destroy_rpc": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ConflictsWith:    []string{"destroy_command"},
+				AtLeastOneOf:     []string{"create_command", "create_rpc", "destroy_command", "destroy_rpc"},
+				ValidateDiagFunc: validateRequestRPC(),
+			},
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+			},
+			"create_output": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceRequestCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sess := m.(*Session)
+	if sess.junosFakeCreateSetFile != "" {
+		d.SetId(requestID(d))
+
+		return nil
+	}
+	jnprSess, err := sess.startNewSession()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer sess.closeSession(jnprSess)
+	output, err := runRequest(d.Get("create_command").(string), d.Get("create_rpc").(string), m, jnprSess)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(requestID(d))
+	if tfErr := d.Set("create_output", output); tfErr != nil {
+		panic(tfErr)
+	}
+
+	return nil
+}
+
+func resourceRequestRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return nil
+}
+
+func resourceRequestUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// only destroy_command and destroy_rpc can be updated, they are used on destroy
+	return nil
+}
+
+func resourceRequestDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if d.Get("destroy_command").(string) == "" && d.Get("destroy_rpc").(string) == "" {
+		return nil
+	}
+	sess := m.(*Session)
+	jnprSess, err := sess.startNewSession()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer sess.closeSession(jnprSess)
+	if _, err := runRequest(d.Get("destroy_command").(string), d.Get("destroy_rpc").(string), m, jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// requestID generate id with the command or the rpc root name which is run on create or else on destroy.
+func requestID(d *schema.ResourceData) string {
+	for _, v := range []string{"create_command", "create_rpc", "destroy_command", "destroy_rpc"} {
+		value := d.Get(v).(string)
+		if value == "" {
+			continue
+		}
+		if strings.HasSuffix(v, "_command") {
+			return strings.Join(strings.Fields(value), " ")
+		}
+		if nodes, err := parseXMLNodes(value); err == nil && len(nodes) == 1 {
+			return nodes[0].name
+		}
+
+		return value
+	}
+
+	return ""
+}
+
+// runRequest run an operational command (return its text output) or a rpc (return its xml reply).
+func runRequest(command, rpc string, m interface{}, jnprSess *NetconfObject) (string, error) {
+	sess := m.(*Session)
+	if command != "" {
+		command = strings.Join(strings.Fields(command), " ")
+		reply, err := sess.commandXML(fmt.Sprintf(rpcCommandFormat, commandFormatText, html.EscapeString(command)),
+			jnprSess)
+		if err != nil {
+			return "", err
+		}
+		output, err := readCommandOutput(reply, commandFormatText)
+		if err != nil {
+			return "", fmt.Errorf("reply of command '%s' : %w", command, err)
+		}
+
+		return output, nil
+	}
+	reply, err := sess.commandXML(rpc, jnprSess)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(reply), nil
+}
+
+// validateRequestCommand check an operational command start with a word in listOfRequestCommands
+// and only use pipes in listOfCommandPipes.
+func validateRequestCommand() schema.SchemaValidateDiagFunc {
+	return func(i interface{}, path cty.Path) diag.Diagnostics {
+		var diags diag.Diagnostics
+		v, ok := i.(string)
+		if !ok {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "expected type to be string",
+				AttributePath: path,
+			})
+
+			return diags
+		}
+		if strings.ContainsAny(v, ";\n\r") {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       fmt.Sprintf("command '%s' not allowed, only one command is allowed", v),
+				AttributePath: path,
+			})
+
+			return diags
+		}
+		for index, part := range strings.Split(v, "|") {
+			words := strings.Fields(part)
+			switch {
+			case len(words) == 0:
+				diags = append(diags, diag.Diagnostic{
+					Severity:      diag.Error,
+					Summary:       fmt.Sprintf("command '%s' not allowed, empty command or pipe", v),
+					AttributePath: path,
+				})
+			case index == 0 && !stringInSlice(words[0], listOfRequestCommands()):
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary: fmt.Sprintf("command '%s' not allowed, need to start with one of %s "+
+						"(configuration mode commands are refused)", v, strings.Join(listOfRequestCommands(), ", ")),
+					AttributePath: path,
+				})
+			case index > 0 && !stringInSlice(words[0], listOfCommandPipes()):
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary: fmt.Sprintf("pipe '%s' in command '%s' not allowed (allowed pipes: %s)",
+						words[0], v, strings.Join(listOfCommandPipes(), ", ")),
+					AttributePath: path,
+				})
+			}
+			if diags.HasError() {
+				return diags
+			}
+		}
+
+		return diags
+	}
+}
+
+// listOfRPCConfiguration : rpc refused by junos_request resource, configuration need to be managed
+// with other resources.
+func listOfRPCConfiguration() []string {
+	return []string{
+		"close-configuration",
+		"close-session",
+		"commit-configuration",
+		"copy-config",
+		"delete-config",
+		"discard-changes",
+		"edit-config",
+		"kill-session",
+		"load-configuration",
+		"lock",
+		"lock-configuration",
+		"open-configuration",
+		"unlock",
+		"unlock-configuration",
+	}
+}
+
+// validateRequestRPC check a rpc is a xml document with one root element which doesn't change configuration
+// (rpc in listOfRPCConfiguration and the command rpc are refused).
+func validateRequestRPC() schema.SchemaValidateDiagFunc {
+	return func(i interface{}, path cty.Path) diag.Diagnostics {
+		var diags diag.Diagnostics
+		v, ok := i.(string)
+		if !ok {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "expected type to be string",
+				AttributePath: path,
+			})
+
+			return diags
+		}
+		nodes, err := parseXMLNodes(v)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       fmt.Sprintf("rpc invalid : %s", err.Error()),
+				AttributePath: path,
+			})
+
+			return diags
+		}
+		if len(nodes) != 1 {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       fmt.Sprintf("rpc need to have one root element, got %d", len(nodes)),
+				AttributePath: path,
+			})
+
+			return diags
+		}
+		if nodes[0].name == "command" {
+			// the command rpc can run configuration mode commands, commands are checked with *_command arguments
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       "rpc command not allowed in junos_request resource, use create_command or destroy_command",
+				AttributePath: path,
+			})
+
+			return diags
+		}
+		if stringInSlice(nodes[0].name, listOfRPCConfiguration()) {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary: fmt.Sprintf("rpc %s not allowed in junos_request resource, "+
+					"configuration need to be managed with other resources", nodes[0].name),
+				AttributePath: path,
+			})
+		}
+
+		return diags
+	}
+}
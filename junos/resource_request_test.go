@@ -0,0 +1,92 @@
+package junos_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccJunosRequest_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource junos_request testacc_request_configure {
+  create_command = "configure private"
+}
+`,
+				ExpectError: regexp.MustCompile("configuration mode commands are refused"),
+			},
+			{
+				Config: `
+resource junos_request testacc_request_load {
+  create_rpc = "<load-configuration action=\"set\" format=\"text\"/>"
+}
+`,
+				ExpectError: regexp.MustCompile("rpc load-configuration not allowed"),
+			},
+			{
+				Config: `
+resource junos_request testacc_request_command {
+  create_rpc = "<command>configure private</command>"
+}
+`,
+				ExpectError: regexp.MustCompile("rpc command not allowed"),
+			},
+			{
+				Config: testAccJunosRequestConfigCreate(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("junos_request.testacc_request_rescue",
+						"id", "request system configuration rescue save"),
+					resource.TestCheckResourceAttr("junos_request.testacc_request_rpc",
+						"id", "get-system-information"),
+					resource.TestMatchResourceAttr("junos_request.testacc_request_rpc",
+						"create_output", regexp.MustCompile(`<hardware-model>.+</hardware-model>`)),
+				),
+			},
+			{
+				Config:   testAccJunosRequestConfigUpdate(),
+				PlanOnly: true,
+				// only destroy_rpc is changed, resource is updated without run command
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccJunosRequestConfigUpdate(),
+			},
+		},
+	})
+}
+
+func testAccJunosRequestConfigCreate() string {
+	return `
+resource junos_request testacc_request_rescue {
+  create_command  = "request system configuration rescue save"
+  destroy_command = "request system configuration rescue delete"
+  triggers = {
+    key = "testacc"
+  }
+}
+resource junos_request testacc_request_rpc {
+  create_rpc = "<get-system-information/>"
+}
+`
+}
+
+func testAccJunosRequestConfigUpdate() string {
+	return `
+resource junos_request testacc_request_rescue {
+  create_command  = "request system configuration rescue save"
+  destroy_command = "request system configuration rescue delete"
+  triggers = {
+    key = "testacc"
+  }
+}
+resource junos_request testacc_request_rpc {
+  create_rpc  = "<get-system-information/>"
+  destroy_rpc = "<get-system-information/>"
+}
+`
+}
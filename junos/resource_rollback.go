@@ -0,0 +1,187 @@
+package junos
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	rpcLoadRollback           = "<load-configuration rollback=\"%d\"/>"
+	rpcGetRollbackInformation = "<get-rollback-information><rollback>%d</rollback><compare>0</compare>" +
+		"</get-rollback-information>"
+	maxRollback = 49
+)
+
+func resourceRollback() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRollbackCreate,
+		ReadContext:   resourceRollbackRead,
+		DeleteContext: resourceRollbackDelete,
+		Schema: map[string]*schema.Schema{
+			"rollback": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"rollback", "log_match"},
+				ValidateFunc: validation.IntBetween(0, maxRollback),
+			},
+			"log_match": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"rollback", "log_match"},
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"before_match": {
+				Type:         schema.TypeBool,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"log_match"},
+			},
+			"compare_only": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+			},
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+			},
+			"rollback_index": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"diff": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceRollbackCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sess := m.(*Session)
+	if sess.junosFakeCreateSetFile != "" {
+		return diag.FromErr(fmt.Errorf("junos_rollback resource can't be created with fake_create_with_setfile"))
+	}
+	if !d.Get("compare_only").(bool) && sess.junosTransport != transportNetconf {
+		return diag.FromErr(fmt.Errorf("rollback with junos_rollback resource is only supported with %s transport "+
+			"(use compare_only)", transportNetconf))
+	}
+	jnprSess, err := sess.startNewSession()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer sess.closeSession(jnprSess)
+	compareOnly := d.Get("compare_only").(bool)
+	var diagWarns diag.Diagnostics
+	// lock before resolve the rollback index and compare it to have the same commit history when rollback is loaded
+	if !compareOnly {
+		sess.configLock(jnprSess)
+	}
+	rollback, err := resolveRollback(d, m, jnprSess)
+	if err != nil {
+		if !compareOnly {
+			appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
+		}
+
+		return append(diagWarns, diag.FromErr(err)...)
+	}
+	diff, err := compareRollback(rollback, m, jnprSess)
+	if err != nil {
+		if !compareOnly {
+			appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
+		}
+
+		return append(diagWarns, diag.FromErr(err)...)
+	}
+	if !compareOnly {
+		if _, err := sess.commandXML(fmt.Sprintf(rpcLoadRollback, rollback), jnprSess); err != nil {
+			appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
+
+			return append(diagWarns, diag.FromErr(err)...)
+		}
+		warns, err := sess.commitConf(fmt.Sprintf("rollback %d with resource junos_rollback", rollback), jnprSess)
+		appendDiagWarns(&diagWarns, warns)
+		if err != nil {
+			appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
+
+			return append(diagWarns, diag.FromErr(err)...)
+		}
+	}
+	d.SetId(strconv.Itoa(rollback))
+	if tfErr := d.Set("rollback_index", rollback); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("diff", diff); tfErr != nil {
+		panic(tfErr)
+	}
+
+	return diagWarns
+}
+
+func resourceRollbackRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return nil
+}
+
+func resourceRollbackDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	return nil
+}
+
+// resolveRollback return the rollback index with rollback argument or with the most recent commit
+// with a log matching log_match (or the commit before it with before_match).
+func resolveRollback(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) (int, error) {
+	logMatch := d.Get("log_match").(string)
+	if logMatch == "" {
+		return d.Get("rollback").(int), nil
+	}
+	logRegexp := regexp.MustCompile(logMatch)
+	commits, err := readCommitHistory(m, jnprSess)
+	if err != nil {
+		return 0, err
+	}
+	for _, commit := range commits {
+		if !logRegexp.MatchString(commit.log) {
+			continue
+		}
+		rollback := commit.sequence
+		if d.Get("before_match").(bool) {
+			rollback++
+		}
+		if rollback > maxRollback || rollback >= len(commits) {
+			return 0, fmt.Errorf("rollback %d for the commit with log matching '%s' not available", rollback, logMatch)
+		}
+
+		return rollback, nil
+	}
+
+	return 0, fmt.Errorf("commit with log matching '%s' not found in commit history", logMatch)
+}
+
+// compareRollback return the differences between the rollback and the active configuration.
+func compareRollback(rollback int, m interface{}, jnprSess *NetconfObject) (string, error) {
+	sess := m.(*Session)
+	reply, err := sess.commandXML(fmt.Sprintf(rpcGetRollbackInformation, rollback), jnprSess)
+	if err != nil {
+		return "", err
+	}
+	nodes, err := parseXMLNodes(reply)
+	if err != nil {
+		return "", fmt.Errorf("reply of rollback information : %w", err)
+	}
+	diff, _, err := xmlSelect(nodes, "//configuration-output")
+	if err != nil {
+		return "", err
+	}
+
+	return diff, nil
+}
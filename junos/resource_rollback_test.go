@@ -0,0 +1,84 @@
+package junos_test
+
+import (
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// export TESTACC_INTERFACE=<inteface> for choose interface available else it's ge-0/0/3.
+func TestAccJunosRollback_basic(t *testing.T) {
+	var testaccInterface string
+	if os.Getenv("TESTACC_INTERFACE") != "" {
+		testaccInterface = os.Getenv("TESTACC_INTERFACE")
+	} else {
+		testaccInterface = defaultInterfaceTestAcc
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccJunosRollbackConfigInterface(testaccInterface, "testacc_rollback"),
+			},
+			{
+				Config: testAccJunosRollbackConfigInterface(testaccInterface, "testacc_rollback_update"),
+			},
+			{
+				Config: testAccJunosRollbackConfigInterface(testaccInterface, "testacc_rollback_update") + `
+resource junos_rollback testacc_rollback {
+  log_match    = "^update resource junos_interface_physical$"
+  before_match = true
+  compare_only = true
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("junos_rollback.testacc_rollback",
+						"rollback_index", "1"),
+					resource.TestMatchResourceAttr("junos_rollback.testacc_rollback",
+						"diff", regexp.MustCompile(`description testacc_rollback\b`)),
+				),
+			},
+			{
+				Config: testAccJunosRollbackConfigInterface(testaccInterface, "testacc_rollback_update") + `
+resource junos_rollback testacc_rollback {
+  rollback = 1
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("junos_rollback.testacc_rollback",
+						"id", "1"),
+				),
+				// description of interface is back to testacc_rollback
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccJunosRollbackConfigInterface(testaccInterface, "testacc_rollback") + `
+resource junos_rollback testacc_rollback {
+  rollback = 1
+}
+data junos_interface_physical testacc_rollback {
+  config_interface = "` + testaccInterface + `"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("junos_interface_physical.testacc_rollback",
+						"description", "testacc_rollback"),
+					resource.TestCheckResourceAttr("data.junos_interface_physical.testacc_rollback",
+						"description", "testacc_rollback"),
+				),
+			},
+		},
+	})
+}
+
+func testAccJunosRollbackConfigInterface(interFace, description string) string {
+	return `
+resource junos_interface_physical testacc_rollback {
+  name        = "` + interFace + `"
+  description = "` + description + `"
+}
+`
+}
@@ -9,7 +9,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	jdecode "github.com/jeremmfr/junosdecode"
 )
 
 type ikeGatewayOptions struct {
@@ -167,8 +166,9 @@ func resourceIkeGateway() *schema.Resource {
 							},
 						},
 						"client_password": {
-							Type:     schema.TypeString,
-							Optional: true,
+							Type:      schema.TypeString,
+							Optional:  true,
+							Sensitive: true,
 							ConflictsWith: []string{
 								"aaa.0.access_profile",
 							},
@@ -669,7 +669,7 @@ func readIkeGateway(ikeGateway string, m interface{}, jnprSess *NetconfObject) (
 				case strings.HasPrefix(itemTrim, "aaa access-profile "):
 					confRead.aaa[0]["access_profile"] = strings.TrimPrefix(itemTrim, "aaa access-profile ")
 				case strings.HasPrefix(itemTrim, "aaa client password "):
-					confRead.aaa[0]["client_password"], err = jdecode.Decode(strings.Trim(strings.TrimPrefix(itemTrim,
+					confRead.aaa[0]["client_password"], err = decodeSecret(strings.Trim(strings.TrimPrefix(itemTrim,
 						"aaa client password "), "\""))
 					if err != nil {
 						return confRead, fmt.Errorf("failed to decode aaa client password : %w", err)
@@ -8,7 +8,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	jdecode "github.com/jeremmfr/junosdecode"
 )
 
 type ikePolicyOptions struct {
@@ -318,13 +317,13 @@ func readIkePolicy(ikePolicy string, m interface{}, jnprSess *NetconfObject) (ik
 			case strings.HasPrefix(itemTrim, "proposal-set "):
 				confRead.proposalSet = strings.TrimPrefix(itemTrim, "proposal-set ")
 			case strings.HasPrefix(itemTrim, "pre-shared-key hexadecimal "):
-				confRead.preSharedKeyHexa, err = jdecode.Decode(strings.Trim(strings.TrimPrefix(itemTrim,
+				confRead.preSharedKeyHexa, err = decodeSecret(strings.Trim(strings.TrimPrefix(itemTrim,
 					"pre-shared-key hexadecimal "), "\""))
 				if err != nil {
 					return confRead, fmt.Errorf("failed to decode pre-shared-key hexadecimal : %w", err)
 				}
 			case strings.HasPrefix(itemTrim, "pre-shared-key ascii-text "):
-				confRead.preSharedKeyText, err = jdecode.Decode(strings.Trim(strings.TrimPrefix(itemTrim,
+				confRead.preSharedKeyText, err = decodeSecret(strings.Trim(strings.TrimPrefix(itemTrim,
 					"pre-shared-key ascii-text "), "\""))
 				if err != nil {
 					return confRead, fmt.Errorf("failed to decode pre-shared-key ascii-text : %w", err)
@@ -25,6 +25,7 @@ func resourceSecurityPolicy() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceSecurityPolicyImport,
 		},
+		CustomizeDiff: customizeDiffSecurityPolicyPosition,
 		Schema: map[string]*schema.Schema{
 			"from_zone": {
 				Type:             schema.TypeString,
@@ -183,6 +184,29 @@ func resourceSecurityPolicy() *schema.Resource {
 					},
 				},
 			},
+			"position": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"strategy": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"first", "last", "before", "after"}, false),
+						},
+						"policy": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateDiagFunc: validateNameObjectJunos([]string{}, 64, formatDefault),
+						},
+					},
+				},
+			},
+			"inactive": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
 		},
 	}
 }
@@ -208,18 +232,36 @@ func resourceSecurityPolicyCreate(ctx context.Context, d *schema.ResourceData, m
 	}
 	sess.configLock(jnprSess)
 	var diagWarns diag.Diagnostics
-	securityPolicyExists, err := checkSecurityPolicyExists(d.Get("from_zone").(string), d.Get("to_zone").(string),
-		m, jnprSess)
-	if err != nil {
-		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
+	if len(d.Get("position").([]interface{})) == 0 {
+		securityPolicyExists, err := checkSecurityPolicyExists(d.Get("from_zone").(string), d.Get("to_zone").(string),
+			m, jnprSess)
+		if err != nil {
+			appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
 
-		return append(diagWarns, diag.FromErr(err)...)
-	}
-	if securityPolicyExists {
-		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
+			return append(diagWarns, diag.FromErr(err)...)
+		}
+		if securityPolicyExists {
+			appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
+
+			return append(diagWarns, diag.FromErr(fmt.Errorf("security policy from %v to %v already exists",
+				d.Get("from_zone").(string), d.Get("to_zone").(string)))...)
+		}
+	} else {
+		policyNames, err := readSecurityPolicyNames(d.Get("from_zone").(string), d.Get("to_zone").(string),
+			m, jnprSess)
+		if err != nil {
+			appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
 
-		return append(diagWarns, diag.FromErr(fmt.Errorf("security policy from %v to %v already exists",
-			d.Get("from_zone").(string), d.Get("to_zone").(string)))...)
+			return append(diagWarns, diag.FromErr(err)...)
+		}
+		for _, name := range securityPolicyNames(d.Get("policy").([]interface{})) {
+			if stringInSlice(name, policyNames) {
+				appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
+
+				return append(diagWarns, diag.FromErr(fmt.Errorf("policy %v in security policy from %v to %v already exists",
+					name, d.Get("from_zone").(string), d.Get("to_zone").(string)))...)
+			}
+		}
 	}
 
 	if err := setSecurityPolicy(d, m, jnprSess); err != nil {
@@ -234,7 +276,7 @@ func resourceSecurityPolicyCreate(ctx context.Context, d *schema.ResourceData, m
 
 		return append(diagWarns, diag.FromErr(err)...)
 	}
-	securityPolicyExists, err = checkSecurityPolicyExists(d.Get("from_zone").(string), d.Get("to_zone").(string),
+	securityPolicyExists, err := checkSecurityPolicyExists(d.Get("from_zone").(string), d.Get("to_zone").(string),
 		m, jnprSess)
 	if err != nil {
 		return append(diagWarns, diag.FromErr(err)...)
@@ -262,6 +304,9 @@ func resourceSecurityPolicyRead(ctx context.Context, d *schema.ResourceData, m i
 
 func resourceSecurityPolicyReadWJnprSess(
 	d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) diag.Diagnostics {
+	if len(d.Get("position").([]interface{})) != 0 {
+		m.(*Session).readStatementsRoots(securityPolicyRoots(securityPolicyNames(d.Get("policy").([]interface{})))...)
+	}
 	mutex.Lock()
 	policyOptions, err := readSecurityPolicy(d.Get("from_zone").(string)+idSeparator+d.Get("to_zone").(string),
 		m, jnprSess)
@@ -269,6 +314,17 @@ func resourceSecurityPolicyReadWJnprSess(
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if len(d.Get("position").([]interface{})) != 0 {
+		// only the policies managed by resource
+		policyNames := securityPolicyNames(d.Get("policy").([]interface{}))
+		policies := make([]map[string]interface{}, 0, len(policyNames))
+		for _, policy := range policyOptions.policy {
+			if stringInSlice(policy["name"].(string), policyNames) {
+				policies = append(policies, policy)
+			}
+		}
+		policyOptions.policy = policies
+	}
 	if len(policyOptions.policy) == 0 {
 		d.SetId("")
 	} else {
@@ -288,13 +344,9 @@ func resourceSecurityPolicyUpdate(ctx context.Context, d *schema.ResourceData, m
 	defer sess.closeSession(jnprSess)
 	sess.configLock(jnprSess)
 	var diagWarns diag.Diagnostics
-	if err := delSecurityPolicy(d.Get("from_zone").(string), d.Get("to_zone").(string), m, jnprSess); err != nil {
-		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
-
-		return append(diagWarns, diag.FromErr(err)...)
-	}
-
-	if err := setSecurityPolicy(d, m, jnprSess); err != nil {
+	if err := sess.configSetDelta(func(sessRecord interface{}) error {
+		return replaceSecurityPolicy(d, sessRecord, jnprSess)
+	}, jnprSess); err != nil {
 		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
 
 		return append(diagWarns, diag.FromErr(err)...)
@@ -320,7 +372,15 @@ func resourceSecurityPolicyDelete(ctx context.Context, d *schema.ResourceData, m
 	defer sess.closeSession(jnprSess)
 	sess.configLock(jnprSess)
 	var diagWarns diag.Diagnostics
-	if err := delSecurityPolicy(d.Get("from_zone").(string), d.Get("to_zone").(string), m, jnprSess); err != nil {
+	if len(d.Get("position").([]interface{})) != 0 {
+		if err := delSecurityPolicyManaged(d.Get("from_zone").(string), d.Get("to_zone").(string),
+			securityPolicyNames(d.Get("policy").([]interface{})), m, jnprSess); err != nil {
+			appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
+
+			return append(diagWarns, diag.FromErr(err)...)
+		}
+	} else if err := delSecurityPolicy(d.Get("from_zone").(string), d.Get("to_zone").(string),
+		m, jnprSess); err != nil {
 		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
 
 		return append(diagWarns, diag.FromErr(err)...)
@@ -348,6 +408,9 @@ func resourceSecurityPolicyImport(d *schema.ResourceData, m interface{}) ([]*sch
 	if len(idList) < 2 {
 		return nil, fmt.Errorf("missing element(s) in id with separator %v", idSeparator)
 	}
+	if len(idList) > 2 {
+		sess.readStatementsRoots(securityPolicyRoots(idList[2:])...)
+	}
 	securityPolicyExists, err := checkSecurityPolicyExists(idList[0], idList[1], m, jnprSess)
 	if err != nil {
 		return nil, err
@@ -355,10 +418,40 @@ func resourceSecurityPolicyImport(d *schema.ResourceData, m interface{}) ([]*sch
 	if !securityPolicyExists {
 		return nil, fmt.Errorf("don't find policy with id '%v' (id must be <from_zone>"+idSeparator+"<to_zone>)", d.Id())
 	}
-	policyOptions, err := readSecurityPolicy(d.Id(), m, jnprSess)
+	policyOptions, err := readSecurityPolicy(idList[0]+idSeparator+idList[1], m, jnprSess)
 	if err != nil {
 		return nil, err
 	}
+	if len(idList) > 2 {
+		// only the policies in id (resource with position)
+		policies := make([]map[string]interface{}, 0, len(idList)-2)
+		var previous string
+		for _, policy := range policyOptions.policy {
+			if stringInSlice(policy["name"].(string), idList[2:]) {
+				policies = append(policies, policy)
+			} else if len(policies) == 0 {
+				previous = policy["name"].(string)
+			}
+		}
+		if len(policies) != len(idList)-2 {
+			return nil, fmt.Errorf("don't find all policies of id '%v' "+
+				"(id must be <from_zone>"+idSeparator+"<to_zone>"+idSeparator+"<policy>...)", d.Id())
+		}
+		policyOptions.policy = policies
+		// position of policies read on device
+		position := map[string]interface{}{
+			"strategy": "first",
+			"policy":   "",
+		}
+		if previous != "" {
+			position["strategy"] = "after"
+			position["policy"] = previous
+		}
+		if tfErr := d.Set("position", []map[string]interface{}{position}); tfErr != nil {
+			panic(tfErr)
+		}
+		d.SetId(idList[0] + idSeparator + idList[1])
+	}
 	fillSecurityPolicyData(d, policyOptions)
 
 	result[0] = d
@@ -380,6 +473,23 @@ func checkSecurityPolicyExists(fromZone, toZone string, m interface{}, jnprSess
 	return true, nil
 }
 
+func replaceSecurityPolicy(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error {
+	if len(d.Get("position").([]interface{})) != 0 {
+		oldPolicy, newPolicy := d.GetChange("policy")
+		if err := delSecurityPolicyManaged(d.Get("from_zone").(string), d.Get("to_zone").(string),
+			uniqueListString(append(securityPolicyNames(oldPolicy.([]interface{})),
+				securityPolicyNames(newPolicy.([]interface{}))...)),
+			m, jnprSess); err != nil {
+			return err
+		}
+	} else if err := delSecurityPolicy(d.Get("from_zone").(string), d.Get("to_zone").(string),
+		m, jnprSess); err != nil {
+		return err
+	}
+
+	return setSecurityPolicy(d, m, jnprSess)
+}
+
 func setSecurityPolicy(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error {
 	sess := m.(*Session)
 	configSet := make([]string, 0)
@@ -452,9 +562,164 @@ func setSecurityPolicy(d *schema.ResourceData, m interface{}, jnprSess *NetconfO
 		}
 	}
 
+	if len(d.Get("position").([]interface{})) == 0 {
+		configSet = configSetInactive(d, "set security policies"+
+			" from-zone "+d.Get("from_zone").(string)+
+			" to-zone "+d.Get("to_zone").(string), configSet)
+	} else {
+		for _, name := range securityPolicyNames(d.Get("policy").([]interface{})) {
+			configSet = configSetInactive(d, setPrefix+name, configSet)
+		}
+		configSetInsert, err := setSecurityPolicyPosition(d, m, jnprSess)
+		if err != nil {
+			return err
+		}
+		configSet = append(configSet, configSetInsert...)
+	}
+
 	return sess.configSet(configSet, jnprSess)
 }
 
+// customizeDiffSecurityPolicyPosition check the position block at plan time.
+func customizeDiffSecurityPolicyPosition(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("position") || !d.NewValueKnown("policy") {
+		return nil
+	}
+	position := d.Get("position").([]interface{})
+	if len(position) == 0 || position[0] == nil {
+		return nil
+	}
+
+	return validateSecurityPolicyPosition(position[0].(map[string]interface{}),
+		securityPolicyNames(d.Get("policy").([]interface{})))
+}
+
+// validateSecurityPolicyPosition check the reference policy of position block with its strategy.
+func validateSecurityPolicyPosition(position map[string]interface{}, policyNames []string) error {
+	strategy := position["strategy"].(string)
+	reference := position["policy"].(string)
+	switch strategy {
+	case "before", "after":
+		if reference == "" {
+			return fmt.Errorf("policy need to be set in position block with strategy %s", strategy)
+		}
+		if stringInSlice(reference, policyNames) {
+			return fmt.Errorf("policy %s in position block can't be a policy of resource", reference)
+		}
+	default:
+		if reference != "" {
+			return fmt.Errorf("policy in position block not compatible with strategy %s", strategy)
+		}
+	}
+
+	return nil
+}
+
+// setSecurityPolicyPosition generate the insert lines to move the policies of resource
+// at the position in list of policies (with the policies not managed by resource).
+func setSecurityPolicyPosition(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) ([]string, error) {
+	position := d.Get("position").([]interface{})[0].(map[string]interface{})
+	strategy := position["strategy"].(string)
+	reference := position["policy"].(string)
+	policyNames := securityPolicyNames(d.Get("policy").([]interface{}))
+	if err := validateSecurityPolicyPosition(position, policyNames); err != nil {
+		return nil, err
+	}
+	switch {
+	case strategy == "before" || strategy == "after":
+	case jnprSess == nil:
+		strategy = ""
+	default:
+		oldPolicy, _ := d.GetChange("policy")
+		otherNames := make([]string, 0)
+		currentNames, err := readSecurityPolicyNames(d.Get("from_zone").(string), d.Get("to_zone").(string),
+			m, jnprSess)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range currentNames {
+			if !stringInSlice(name, policyNames) && !stringInSlice(name, securityPolicyNames(oldPolicy.([]interface{}))) {
+				otherNames = append(otherNames, name)
+			}
+		}
+		switch {
+		case len(otherNames) == 0:
+			strategy = ""
+		case strategy == "first":
+			strategy = "before"
+			reference = otherNames[0]
+		default:
+			strategy = "after"
+			reference = otherNames[len(otherNames)-1]
+		}
+	}
+	insertPrefix := "insert security policies" +
+		" from-zone " + d.Get("from_zone").(string) +
+		" to-zone " + d.Get("to_zone").(string) +
+		" policy "
+	configSet := make([]string, 0, len(policyNames))
+	for i, name := range policyNames {
+		if i == 0 {
+			if strategy != "" {
+				configSet = append(configSet, insertPrefix+name+" "+strategy+" policy "+reference)
+			}
+
+			continue
+		}
+		configSet = append(configSet, insertPrefix+name+" after policy "+policyNames[i-1])
+	}
+
+	return configSet, nil
+}
+
+// securityPolicyNames return the names of policies in 'policy' argument.
+func securityPolicyNames(policies []interface{}) []string {
+	names := make([]string, 0, len(policies))
+	for _, v := range policies {
+		if v == nil {
+			continue
+		}
+		names = append(names, v.(map[string]interface{})["name"].(string))
+	}
+
+	return names
+}
+
+// securityPolicyRoots return the statements of policies relative to the zone pair.
+func securityPolicyRoots(names []string) []string {
+	roots := make([]string, 0, len(names))
+	for _, name := range names {
+		roots = append(roots, "policy "+name)
+	}
+
+	return roots
+}
+
+// readSecurityPolicyNames read the names of policies in configuration (in order of configuration).
+func readSecurityPolicyNames(fromZone, toZone string, m interface{}, jnprSess *NetconfObject) ([]string, error) {
+	sess := m.(*Session)
+	policyConfig, err := sess.command("show configuration"+
+		" security policies from-zone "+fromZone+" to-zone "+toZone+" | display set relative", jnprSess)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0)
+	if policyConfig == emptyWord {
+		return names, nil
+	}
+	for _, item := range strings.Split(policyConfig, "\n") {
+		itemTrim := strings.TrimPrefix(item, setLineStart)
+		if !strings.HasPrefix(itemTrim, "policy ") {
+			continue
+		}
+		if name := strings.Split(itemTrim, " ")[1]; !stringInSlice(name, names) {
+			names = append(names, name)
+		}
+	}
+
+	return names, nil
+}
+
 func readSecurityPolicy(idPolicy string, m interface{}, jnprSess *NetconfObject) (policyOptions, error) {
 	zone := strings.Split(idPolicy, idSeparator)
 	fromZone := zone[0]
@@ -562,6 +827,35 @@ func delSecurityPolicy(fromZone string, toZone string, m interface{}, jnprSess *
 	return sess.configSet(configSet, jnprSess)
 }
 
+// delSecurityPolicyManaged delete only the policies managed by resource
+// or the entire list if there are no other policies.
+func delSecurityPolicyManaged(fromZone, toZone string, policyNames []string,
+	m interface{}, jnprSess *NetconfObject) error {
+	currentNames, err := readSecurityPolicyNames(fromZone, toZone, m, jnprSess)
+	if err != nil {
+		return err
+	}
+	others := false
+	for _, name := range currentNames {
+		if !stringInSlice(name, policyNames) {
+			others = true
+
+			break
+		}
+	}
+	if !others {
+		return delSecurityPolicy(fromZone, toZone, m, jnprSess)
+	}
+	sess := m.(*Session)
+	configSet := make([]string, 0, len(policyNames))
+	for _, name := range policyNames {
+		configSet = append(configSet, "delete security policies from-zone "+fromZone+" to-zone "+toZone+
+			" policy "+name)
+	}
+
+	return sess.configSet(configSet, jnprSess)
+}
+
 func fillSecurityPolicyData(d *schema.ResourceData, policyOptions policyOptions) {
 	if tfErr := d.Set("from_zone", policyOptions.fromZone); tfErr != nil {
 		panic(tfErr)
@@ -426,6 +426,15 @@ func checkSecurityZonesExists(zone string, m interface{}, jnprSess *NetconfObjec
 	return true, nil
 }
 
+func replaceSecurityZone(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error {
+	if err := delSecurityZoneOpts(
+		d.Get("name").(string), d.Get("address_book_configure_singly").(bool), m, jnprSess); err != nil {
+		return err
+	}
+
+	return setSecurityZone(d, m, jnprSess)
+}
+
 func setSecurityZone(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error {
 	sess := m.(*Session)
 	configSet := make([]string, 0)
@@ -10,7 +10,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	jdecode "github.com/jeremmfr/junosdecode"
 )
 
 type servicesOptions struct {
@@ -1408,7 +1407,7 @@ func readServicesSecurityIntel(confRead *servicesOptions, itemTrimSecurityIntel
 	case strings.HasPrefix(itemTrim, "url-parameter "):
 		var err error
 		confRead.securityIntelligence[0]["url_parameter"], err =
-			jdecode.Decode(strings.Trim(strings.TrimPrefix(itemTrim, "url-parameter "), "\""))
+			decodeSecret(strings.Trim(strings.TrimPrefix(itemTrim, "url-parameter "), "\""))
 		if err != nil {
 			return fmt.Errorf("failed to decode url-parameter : %w", err)
 		}
@@ -1743,7 +1742,7 @@ func readServicesUserIdentification(confRead *servicesOptions, itemTrimUserIdent
 					strings.Trim(strings.TrimPrefix(itemTrimIdentMgmt, "connection primary client-id "), "\"")
 			case strings.HasPrefix(itemTrimIdentMgmt, "connection primary client-secret "):
 				var err error
-				userIdentIdentityMgmtConnect["primary_client_secret"], err = jdecode.Decode(
+				userIdentIdentityMgmtConnect["primary_client_secret"], err = decodeSecret(
 					strings.Trim(strings.TrimPrefix(itemTrimIdentMgmt, "connection primary client-secret "), "\""))
 				if err != nil {
 					return fmt.Errorf("failed to decode primary client-secret : %w", err)
@@ -1775,7 +1774,7 @@ func readServicesUserIdentification(confRead *servicesOptions, itemTrimUserIdent
 					strings.Trim(strings.TrimPrefix(itemTrimIdentMgmt, "connection secondary client-id "), "\"")
 			case strings.HasPrefix(itemTrimIdentMgmt, "connection secondary client-secret "):
 				var err error
-				userIdentIdentityMgmtConnect["secondary_client_secret"], err = jdecode.Decode(
+				userIdentIdentityMgmtConnect["secondary_client_secret"], err = decodeSecret(
 					strings.Trim(strings.TrimPrefix(itemTrimIdentMgmt, "connection secondary client-secret "), "\""))
 				if err != nil {
 					return fmt.Errorf("failed to decode secondary client-secret : %w", err)
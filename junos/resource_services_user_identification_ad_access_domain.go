@@ -9,7 +9,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	jdecode "github.com/jeremmfr/junosdecode"
 )
 
 type svcUserIdentAdAccessDomainOptions struct {
@@ -389,7 +388,7 @@ func readServicesUserIdentAdAccessDomain(domain string, m interface{}, jnprSess
 			switch {
 			case strings.HasPrefix(itemTrim, "user password "):
 				var err error
-				confRead.userPassword, err = jdecode.Decode(strings.Trim(strings.TrimPrefix(itemTrim, "user password "), "\""))
+				confRead.userPassword, err = decodeSecret(strings.Trim(strings.TrimPrefix(itemTrim, "user password "), "\""))
 				if err != nil {
 					return confRead, fmt.Errorf("failed to decode user password : %w", err)
 				}
@@ -450,7 +449,7 @@ func readServicesUserIdentAdAccessDomain(domain string, m interface{}, jnprSess
 				case strings.HasPrefix(itemTrim, "user-group-mapping ldap user password "):
 					var err error
 					confRead.userGroupMappingLdap[0]["user_password"], err =
-						jdecode.Decode(strings.Trim(strings.TrimPrefix(itemTrim, "user-group-mapping ldap user password "), "\""))
+						decodeSecret(strings.Trim(strings.TrimPrefix(itemTrim, "user-group-mapping ldap user password "), "\""))
 					if err != nil {
 						return confRead, fmt.Errorf("failed to decode user password : %w", err)
 					}
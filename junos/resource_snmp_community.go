@@ -253,17 +253,45 @@ func resourceSnmpCommunityImport(d *schema.ResourceData, m interface{}) ([]*sche
 }
 
 func checkSnmpCommunityExists(name string, m interface{}, jnprSess *NetconfObject) (bool, error) {
-	sess := m.(*Session)
-	snmpCommunityConfig, err := sess.command("show configuration"+
-		" snmp community \""+name+"\" | display set", jnprSess)
+	nameOnDevice, err := snmpCommunityNameOnDevice(name, m, jnprSess)
 	if err != nil {
 		return false, err
 	}
-	if snmpCommunityConfig == emptyWord {
-		return false, nil
+
+	return nameOnDevice != "", nil
+}
+
+// snmpCommunityNameOnDevice return the name of community in configuration of device,
+// name can be encoded with '$9$' on device (return empty if community not found).
+func snmpCommunityNameOnDevice(name string, m interface{}, jnprSess *NetconfObject) (string, error) {
+	sess := m.(*Session)
+	snmpConfig, err := sess.command("show configuration snmp | display set relative", jnprSess)
+	if err != nil {
+		return "", err
+	}
+	if snmpConfig == emptyWord {
+		return "", nil
+	}
+	for _, item := range strings.Split(snmpConfig, "\n") {
+		if !strings.HasPrefix(item, setLineStart+"community ") {
+			continue
+		}
+		words := splitSetLineWords(item)
+		if len(words) < 3 {
+			continue
+		}
+		nameOnDevice := strings.Trim(words[2], "\"")
+		if nameOnDevice == name {
+			return nameOnDevice, nil
+		}
+		if strings.HasPrefix(nameOnDevice, secretEncodedPrefix) {
+			if decoded, err := decodeSecret(nameOnDevice); err == nil && decoded == name {
+				return nameOnDevice, nil
+			}
+		}
 	}
 
-	return true, nil
+	return "", nil
 }
 
 func setSnmpCommunity(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error {
@@ -311,8 +339,15 @@ func readSnmpCommunity(name string, m interface{}, jnprSess *NetconfObject) (snm
 	sess := m.(*Session)
 	var confRead snmpCommunityOptions
 
+	nameOnDevice, err := snmpCommunityNameOnDevice(name, m, jnprSess)
+	if err != nil {
+		return confRead, err
+	}
+	if nameOnDevice == "" {
+		return confRead, nil
+	}
 	snmpCommunityConfig, err := sess.command("show configuration"+
-		" snmp community \""+name+"\" | display set relative", jnprSess)
+		" snmp community \""+nameOnDevice+"\" | display set relative", jnprSess)
 	if err != nil {
 		return confRead, err
 	}
@@ -364,7 +399,14 @@ func readSnmpCommunity(name string, m interface{}, jnprSess *NetconfObject) (snm
 
 func delSnmpCommunity(name string, m interface{}, jnprSess *NetconfObject) error {
 	sess := m.(*Session)
-	configSet := []string{"delete snmp community \"" + name + "\""}
+	nameOnDevice, err := snmpCommunityNameOnDevice(name, m, jnprSess)
+	if err != nil {
+		return err
+	}
+	if nameOnDevice == "" {
+		nameOnDevice = name
+	}
+	configSet := []string{"delete snmp community \"" + nameOnDevice + "\""}
 
 	return sess.configSet(configSet, jnprSess)
 }
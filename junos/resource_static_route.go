@@ -201,6 +201,10 @@ func resourceStaticRoute() *schema.Resource {
 				Optional:      true,
 				ConflictsWith: []string{"retain", "resolve"},
 			},
+			"inactive": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
 		},
 	}
 }
@@ -314,12 +318,9 @@ func resourceStaticRouteUpdate(ctx context.Context, d *schema.ResourceData, m in
 	defer sess.closeSession(jnprSess)
 	sess.configLock(jnprSess)
 	var diagWarns diag.Diagnostics
-	if err := delStaticRoute(d.Get("destination").(string), d.Get("routing_instance").(string), m, jnprSess); err != nil {
-		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
-
-		return append(diagWarns, diag.FromErr(err)...)
-	}
-	if err := setStaticRoute(d, m, jnprSess); err != nil {
+	if err := sess.configSetDelta(func(sessRecord interface{}) error {
+		return replaceStaticRoute(d, sessRecord, jnprSess)
+	}, jnprSess); err != nil {
 		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
 
 		return append(diagWarns, diag.FromErr(err)...)
@@ -434,6 +435,15 @@ func checkStaticRouteExists(destination string, instance string, m interface{},
 	return true, nil
 }
 
+func replaceStaticRoute(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error {
+	if err := delStaticRoute(d.Get("destination").(string), d.Get("routing_instance").(string),
+		m, jnprSess); err != nil {
+		return err
+	}
+
+	return setStaticRoute(d, m, jnprSess)
+}
+
 func setStaticRoute(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error {
 	sess := m.(*Session)
 	configSet := make([]string, 0)
@@ -544,6 +554,8 @@ func setStaticRoute(d *schema.ResourceData, m interface{}, jnprSess *NetconfObje
 		configSet = append(configSet, setPrefix+" no-retain")
 	}
 
+	configSet = configSetInactive(d, setPrefix, configSet)
+
 	return sess.configSet(configSet, jnprSess)
 }
 
@@ -9,7 +9,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	jdecode "github.com/jeremmfr/junosdecode"
 )
 
 type systemOptions struct {
@@ -32,6 +31,7 @@ type systemOptions struct {
 	internetOptions                      []map[string]interface{}
 	license                              []map[string]interface{}
 	login                                []map[string]interface{}
+	ntp                                  []map[string]interface{}
 	services                             []map[string]interface{}
 	syslog                               []map[string]interface{}
 }
@@ -440,6 +440,46 @@ func resourceSystem() *schema.Resource {
 				Type:     schema.TypeBool,
 				Optional: true,
 			},
+			"ntp": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"authentication_key": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"id": {
+										Type:         schema.TypeInt,
+										Required:     true,
+										ValidateFunc: validation.IntBetween(1, 65534),
+									},
+									"type": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringInSlice([]string{"md5", "sha1", "sha256"}, false),
+									},
+									"value": {
+										Type:      schema.TypeString,
+										Required:  true,
+										Sensitive: true,
+									},
+								},
+							},
+						},
+						"trusted_key": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeInt,
+								ValidateFunc: validation.IntBetween(1, 65534),
+							},
+						},
+					},
+				},
+			},
 			"services": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -890,6 +930,20 @@ func setSystem(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) e
 	if d.Get("no_redirects_ipv6").(bool) {
 		configSet = append(configSet, setPrefix+"no-redirects-ipv6")
 	}
+	for _, ntp := range d.Get("ntp").([]interface{}) {
+		if ntp == nil {
+			return fmt.Errorf("ntp block is empty")
+		}
+		ntpM := ntp.(map[string]interface{})
+		for _, authKey := range ntpM["authentication_key"].(*schema.Set).List() {
+			authKeyM := authKey.(map[string]interface{})
+			configSet = append(configSet, setPrefix+"ntp authentication-key "+strconv.Itoa(authKeyM["id"].(int))+
+				" type "+authKeyM["type"].(string)+" value \""+authKeyM["value"].(string)+"\"")
+		}
+		for _, trustedKey := range ntpM["trusted_key"].(*schema.Set).List() {
+			configSet = append(configSet, setPrefix+"ntp trusted-key "+strconv.Itoa(trustedKey.(int)))
+		}
+	}
 	if err := setSystemServices(d, m, jnprSess); err != nil {
 		return err
 	}
@@ -1303,6 +1357,13 @@ func listLinesLicense() []string {
 	}
 }
 
+func listLinesNtp() []string {
+	return []string{
+		"ntp authentication-key",
+		"ntp trusted-key",
+	}
+}
+
 func listLinesServices() []string {
 	ls := make([]string, 0)
 	ls = append(ls, listLinesServicesSSH()...)
@@ -1370,6 +1431,7 @@ func delSystem(m interface{}, jnprSess *NetconfObject) error {
 	listLinesToDelete = append(listLinesToDelete, "no-ping-time-stamp")
 	listLinesToDelete = append(listLinesToDelete, "no-redirects")
 	listLinesToDelete = append(listLinesToDelete, "no-redirects-ipv6")
+	listLinesToDelete = append(listLinesToDelete, listLinesNtp()...)
 	listLinesToDelete = append(listLinesToDelete, listLinesServices()...)
 	listLinesToDelete = append(listLinesToDelete, listLinesSyslog()...)
 	listLinesToDelete = append(listLinesToDelete, "time-zone")
@@ -1470,6 +1532,10 @@ func readSystem(m interface{}, jnprSess *NetconfObject) (systemOptions, error) {
 				confRead.noRedirects = true
 			case itemTrim == "no-redirects-ipv6":
 				confRead.noRedirectsIPv6 = true
+			case checkStringHasPrefixInList(itemTrim, listLinesNtp()):
+				if err := readSystemNtp(&confRead, itemTrim); err != nil {
+					return confRead, err
+				}
 			case checkStringHasPrefixInList(itemTrim, listLinesServices()):
 				if len(confRead.services) == 0 {
 					confRead.services = append(confRead.services, map[string]interface{}{
@@ -1840,7 +1906,7 @@ func readSystemLicense(confRead *systemOptions, itemTrim string) error {
 		itemTrimPassword := strings.TrimPrefix(itemTrim, "license autoupdate url "+itemTrimAutoupdateSplit[0]+" ")
 		if strings.HasPrefix(itemTrimPassword, "password ") {
 			var err error
-			confRead.license[0]["autoupdate_password"], err = jdecode.Decode(strings.Trim(strings.TrimPrefix(
+			confRead.license[0]["autoupdate_password"], err = decodeSecret(strings.Trim(strings.TrimPrefix(
 				itemTrimPassword, "password "), "\""))
 			if err != nil {
 				return fmt.Errorf("failed to decode password : %w", err)
@@ -1865,6 +1931,55 @@ func readSystemLicense(confRead *systemOptions, itemTrim string) error {
 	return nil
 }
 
+func readSystemNtp(confRead *systemOptions, itemTrim string) error {
+	if len(confRead.ntp) == 0 {
+		confRead.ntp = append(confRead.ntp, map[string]interface{}{
+			"authentication_key": make([]map[string]interface{}, 0),
+			"trusted_key":        make([]int, 0),
+		})
+	}
+	switch {
+	case strings.HasPrefix(itemTrim, "ntp authentication-key "):
+		itemTrimSplit := strings.Split(strings.TrimPrefix(itemTrim, "ntp authentication-key "), " ")
+		id, err := strconv.Atoi(itemTrimSplit[0])
+		if err != nil {
+			return fmt.Errorf("failed to convert value from '%s' to integer : %w", itemTrimSplit[0], err)
+		}
+		authKey := map[string]interface{}{
+			"id":    id,
+			"type":  "",
+			"value": "",
+		}
+		confRead.ntp[0]["authentication_key"] = copyAndRemoveItemMapList("id", authKey,
+			confRead.ntp[0]["authentication_key"].([]map[string]interface{}))
+		itemTrimKey := strings.TrimPrefix(itemTrim, "ntp authentication-key "+itemTrimSplit[0]+" ")
+		if strings.HasPrefix(itemTrimKey, "type ") {
+			typeSplit := strings.SplitN(strings.TrimPrefix(itemTrimKey, "type "), " ", 2)
+			authKey["type"] = typeSplit[0]
+			itemTrimKey = ""
+			if len(typeSplit) == 2 {
+				itemTrimKey = typeSplit[1]
+			}
+		}
+		if strings.HasPrefix(itemTrimKey, "value ") {
+			authKey["value"], err = decodeSecret(strings.Trim(strings.TrimPrefix(itemTrimKey, "value "), "\""))
+			if err != nil {
+				return err
+			}
+		}
+		confRead.ntp[0]["authentication_key"] = append(
+			confRead.ntp[0]["authentication_key"].([]map[string]interface{}), authKey)
+	case strings.HasPrefix(itemTrim, "ntp trusted-key "):
+		trustedKey, err := strconv.Atoi(strings.TrimPrefix(itemTrim, "ntp trusted-key "))
+		if err != nil {
+			return fmt.Errorf("failed to convert value from '%s' to integer : %w", itemTrim, err)
+		}
+		confRead.ntp[0]["trusted_key"] = append(confRead.ntp[0]["trusted_key"].([]int), trustedKey)
+	}
+
+	return nil
+}
+
 func readSystemServicesSSH(confRead *systemOptions, itemTrim string) error {
 	if len(confRead.services[0]["ssh"].([]map[string]interface{})) == 0 {
 		confRead.services[0]["ssh"] = append(confRead.services[0]["ssh"].([]map[string]interface{}),
@@ -2157,6 +2272,9 @@ func fillSystem(d *schema.ResourceData, systemOptions systemOptions) {
 	if tfErr := d.Set("no_ping_time_stamp", systemOptions.noPingTimeStamp); tfErr != nil {
 		panic(tfErr)
 	}
+	if tfErr := d.Set("ntp", systemOptions.ntp); tfErr != nil {
+		panic(tfErr)
+	}
 	if tfErr := d.Set("no_redirects", systemOptions.noRedirects); tfErr != nil {
 		panic(tfErr)
 	}
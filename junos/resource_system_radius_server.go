@@ -9,7 +9,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	jdecode "github.com/jeremmfr/junosdecode"
 )
 
 type radiusServerOptions struct {
@@ -429,7 +428,7 @@ func readSystemRadiusServer(address string, m interface{}, jnprSess *NetconfObje
 				}
 			case strings.HasPrefix(itemTrim, "preauthentication-secret "):
 				var err error
-				confRead.preauthenticationSecret, err = jdecode.Decode(strings.Trim(strings.TrimPrefix(itemTrim,
+				confRead.preauthenticationSecret, err = decodeSecret(strings.Trim(strings.TrimPrefix(itemTrim,
 					"preauthentication-secret "), "\""))
 				if err != nil {
 					return confRead, fmt.Errorf("failed to decode preauthentication-secret : %w", err)
@@ -444,7 +443,7 @@ func readSystemRadiusServer(address string, m interface{}, jnprSess *NetconfObje
 				confRead.routingInstance = strings.TrimPrefix(itemTrim, "routing-instance ")
 			case strings.HasPrefix(itemTrim, "secret "):
 				var err error
-				confRead.secret, err = jdecode.Decode(strings.Trim(strings.TrimPrefix(itemTrim,
+				confRead.secret, err = decodeSecret(strings.Trim(strings.TrimPrefix(itemTrim,
 					"secret "), "\""))
 				if err != nil {
 					return confRead, fmt.Errorf("failed to decode secret : %w", err)
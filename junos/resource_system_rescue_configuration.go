@@ -0,0 +1,241 @@
+package junos
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	rescueConfigurationID  = "rescue"
+	rescueConfigurationCmd = "system configuration rescue"
+)
+
+func resourceSystemRescueConfiguration() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSystemRescueConfigurationCreate,
+		ReadContext:   resourceSystemRescueConfigurationRead,
+		UpdateContext: resourceSystemRescueConfigurationUpdate,
+		DeleteContext: resourceSystemRescueConfigurationDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSystemRescueConfigurationImport,
+		},
+		CustomizeDiff: customizeDiffSystemRescueConfiguration,
+		Schema: map[string]*schema.Schema{
+			"delete_on_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+			},
+			"hash": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"active_hash": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceSystemRescueConfigurationCreate(
+	ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sess := m.(*Session)
+	if sess.junosFakeCreateSetFile != "" {
+		d.SetId(rescueConfigurationID)
+
+		return nil
+	}
+	jnprSess, err := sess.startNewSession()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer sess.closeSession(jnprSess)
+	if _, err := runRequest("request "+rescueConfigurationCmd+" save", "", m, jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
+	rescueLines, err := readSystemRescueConfiguration(m, jnprSess)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(rescueLines) == 0 {
+		return diag.FromErr(fmt.Errorf("rescue configuration not exists after save => check your device"))
+	}
+	d.SetId(rescueConfigurationID)
+
+	return resourceSystemRescueConfigurationReadWJnprSess(d, m, jnprSess)
+}
+
+func resourceSystemRescueConfigurationRead(
+	ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sess := m.(*Session)
+	jnprSess, err := sess.startNewSession()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer sess.closeSession(jnprSess)
+
+	return resourceSystemRescueConfigurationReadWJnprSess(d, m, jnprSess)
+}
+
+func resourceSystemRescueConfigurationReadWJnprSess(
+	d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) diag.Diagnostics {
+	mutex.Lock()
+	rescueLines, err := readSystemRescueConfiguration(m, jnprSess)
+	if err != nil {
+		mutex.Unlock()
+
+		return diag.FromErr(err)
+	}
+	activeLines, err := readDisplaySetLines(showConfigurationWords+" "+displaySetWords, m, jnprSess)
+	mutex.Unlock()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// rescue configuration deleted need to be saved again by a new resource
+	if len(rescueLines) == 0 {
+		d.SetId("")
+
+		return nil
+	}
+	if tfErr := d.Set("hash", hashRescueConfiguration(rescueLines)); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("active_hash", hashRescueConfiguration(activeLines)); tfErr != nil {
+		panic(tfErr)
+	}
+
+	return nil
+}
+
+// customizeDiffSystemRescueConfiguration generate a diff on hash when rescue configuration is different
+// of active configuration, the update saves the rescue configuration again.
+func customizeDiffSystemRescueConfiguration(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	activeHash := d.Get("active_hash").(string)
+	if activeHash == "" || activeHash == d.Get("hash").(string) {
+		return nil
+	}
+	if err := d.SetNewComputed("hash"); err != nil {
+		return err
+	}
+
+	return d.SetNewComputed("active_hash")
+}
+
+func resourceSystemRescueConfigurationUpdate(
+	ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// delete_on_destroy is only used on destroy,
+	// the rescue configuration is saved again when it differs of active configuration
+	// (with the values read before the diff, hash and active_hash are unknown in the plan)
+	oldHash, _ := d.GetChange("hash")
+	oldActiveHash, _ := d.GetChange("active_hash")
+	if oldHash.(string) == oldActiveHash.(string) {
+		return nil
+	}
+	sess := m.(*Session)
+	jnprSess, err := sess.startNewSession()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer sess.closeSession(jnprSess)
+	if _, err := runRequest("request "+rescueConfigurationCmd+" save", "", m, jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceSystemRescueConfigurationReadWJnprSess(d, m, jnprSess)
+}
+
+func resourceSystemRescueConfigurationDelete(
+	ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if !d.Get("delete_on_destroy").(bool) {
+		return nil
+	}
+	sess := m.(*Session)
+	jnprSess, err := sess.startNewSession()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer sess.closeSession(jnprSess)
+	if _, err := runRequest("request "+rescueConfigurationCmd+" delete", "", m, jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceSystemRescueConfigurationImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	sess := m.(*Session)
+	jnprSess, err := sess.startNewSession()
+	if err != nil {
+		return nil, err
+	}
+	defer sess.closeSession(jnprSess)
+	result := make([]*schema.ResourceData, 1)
+	rescueLines, err := readSystemRescueConfiguration(m, jnprSess)
+	if err != nil {
+		return nil, err
+	}
+	if len(rescueLines) == 0 {
+		return nil, fmt.Errorf("don't find rescue configuration (id must be %s)", rescueConfigurationID)
+	}
+	activeLines, err := readDisplaySetLines(showConfigurationWords+" "+displaySetWords, m, jnprSess)
+	if err != nil {
+		return nil, err
+	}
+	d.SetId(rescueConfigurationID)
+	if tfErr := d.Set("hash", hashRescueConfiguration(rescueLines)); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("active_hash", hashRescueConfiguration(activeLines)); tfErr != nil {
+		panic(tfErr)
+	}
+	result[0] = d
+
+	return result, nil
+}
+
+func readSystemRescueConfiguration(m interface{}, jnprSess *NetconfObject) ([]string, error) {
+	return readDisplaySetLines("show "+rescueConfigurationCmd+" "+displaySetWords, m, jnprSess)
+}
+
+// readDisplaySetLines read the set lines of a command with '| display set' pipe.
+func readDisplaySetLines(cmd string, m interface{}, jnprSess *NetconfObject) ([]string, error) {
+	sess := m.(*Session)
+	lines := make([]string, 0)
+	showConfig, err := sess.command(cmd, jnprSess)
+	if err != nil {
+		return lines, err
+	}
+	if showConfig == emptyWord {
+		return lines, nil
+	}
+	for _, item := range strings.Split(showConfig, "\n") {
+		if strings.Contains(item, "<configuration-output>") {
+			continue
+		}
+		if strings.Contains(item, "</configuration-output>") {
+			break
+		}
+		if strings.HasPrefix(item, setLineStart) {
+			lines = append(lines, item)
+		}
+	}
+
+	return lines, nil
+}
+
+func hashRescueConfiguration(lines []string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(strings.Join(lines, "\n"))))
+}
@@ -0,0 +1,92 @@
+package junos_test
+
+import (
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// export TESTACC_INTERFACE=<inteface> for choose interface available else it's ge-0/0/3.
+func TestAccJunosSystemRescueConfiguration_basic(t *testing.T) {
+	var testaccInterface string
+	if os.Getenv("TESTACC_INTERFACE") != "" {
+		testaccInterface = os.Getenv("TESTACC_INTERFACE")
+	} else {
+		testaccInterface = defaultInterfaceTestAcc
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccJunosSystemRescueConfigurationConfigCreate(testaccInterface, "testacc_rescue"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("junos_system_rescue_configuration.testacc_rescue",
+						"id", "rescue"),
+					resource.TestMatchResourceAttr("junos_system_rescue_configuration.testacc_rescue",
+						"hash", regexp.MustCompile(`^[0-9a-f]{64}$`)),
+					resource.TestCheckResourceAttrPair("junos_system_rescue_configuration.testacc_rescue",
+						"hash", "junos_system_rescue_configuration.testacc_rescue", "active_hash"),
+				),
+			},
+			{
+				ResourceName:      "junos_system_rescue_configuration.testacc_rescue",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"delete_on_destroy",
+					"triggers",
+				},
+			},
+			{
+				// the description changes without triggers, rescue configuration diverges
+				Config: testAccJunosSystemRescueConfigurationConfigDrift(testaccInterface),
+				Check: resource.TestCheckResourceAttr("junos_interface_physical.testacc_rescue",
+					"description", "testacc_rescue_drift"),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				// the diff between hash and active_hash saves the rescue configuration again with an update
+				Config: testAccJunosSystemRescueConfigurationConfigDrift(testaccInterface),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("junos_system_rescue_configuration.testacc_rescue",
+						"id", "rescue"),
+					resource.TestCheckResourceAttrPair("junos_system_rescue_configuration.testacc_rescue",
+						"hash", "junos_system_rescue_configuration.testacc_rescue", "active_hash"),
+				),
+			},
+			{
+				Config: testAccJunosSystemRescueConfigurationConfigCreate(testaccInterface, "testacc_rescue_update"),
+			},
+		},
+	})
+}
+
+func testAccJunosSystemRescueConfigurationConfigCreate(interFace, description string) string {
+	return `
+resource junos_interface_physical testacc_rescue {
+  name        = "` + interFace + `"
+  description = "` + description + `"
+}
+resource junos_system_rescue_configuration testacc_rescue {
+  delete_on_destroy = true
+  triggers = {
+    description = junos_interface_physical.testacc_rescue.description
+  }
+}
+`
+}
+
+func testAccJunosSystemRescueConfigurationConfigDrift(interFace string) string {
+	return `
+resource junos_interface_physical testacc_rescue {
+  name        = "` + interFace + `"
+  description = "testacc_rescue_drift"
+}
+resource junos_system_rescue_configuration testacc_rescue {
+  delete_on_destroy = true
+}
+`
+}
@@ -9,7 +9,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	jdecode "github.com/jeremmfr/junosdecode"
 )
 
 type syslogFileOptions struct {
@@ -663,7 +662,7 @@ func readSystemSyslogFile(filename string, m interface{}, jnprSess *NetconfObjec
 					switch {
 					case strings.HasPrefix(itemTrimArchSites, "password "):
 						var err error
-						sitesOptions["password"], err = jdecode.Decode(strings.Trim(strings.TrimPrefix(
+						sitesOptions["password"], err = decodeSecret(strings.Trim(strings.TrimPrefix(
 							itemTrimArchSites, "password "), "\""))
 						if err != nil {
 							return confRead, fmt.Errorf("failed to decode password : %w", err)
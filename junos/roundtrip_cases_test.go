@@ -0,0 +1,2392 @@
+package junos
+
+// roundTripCases : configurations of resources for TestRoundTrip.
+// Resources without configuration on device are not there:
+// junos_config_export (write the committed configuration in state and file),
+// junos_null_commit_file (commit set lines of a file without state),
+// junos_request (run operational commands or rpcs),
+// junos_rollback (load a previous configuration already committed) and
+// junos_system_rescue_configuration (save the active configuration as rescue).
+func roundTripCases() []roundTripCase {
+	return []roundTripCase{
+		{
+			resource: "junos_aggregate_route",
+			name:     "basic",
+			preset:   []string{"set routing-instances testacc_aggregateRoute instance-type virtual-router"},
+			config: map[string]interface{}{
+				"destination":                  "192.0.2.0/24",
+				"routing_instance":             "testacc_aggregateRoute",
+				"preference":                   100,
+				"metric":                       100,
+				"active":                       true,
+				"full":                         true,
+				"discard":                      true,
+				"community":                    []interface{}{"no-advertise"},
+				"policy":                       []interface{}{"testacc_aggregateRoute"},
+				"as_path_aggregator_as_number": "65000",
+				"as_path_aggregator_address":   "192.0.2.1",
+				"as_path_atomic_aggregate":     true,
+				"as_path_origin":               "igp",
+				"as_path_path":                 "65000 65000",
+			},
+		},
+		{
+			resource: "junos_aggregate_route",
+			name:     "basic2",
+			preset:   []string{"set routing-instances testacc_aggregateRoute instance-type virtual-router"},
+			config: map[string]interface{}{
+				"destination":                  "2001:db8:85a3::/48",
+				"routing_instance":             "testacc_aggregateRoute",
+				"preference":                   100,
+				"metric":                       100,
+				"active":                       true,
+				"full":                         true,
+				"discard":                      true,
+				"community":                    []interface{}{"no-advertise"},
+				"policy":                       []interface{}{"testacc_aggregateRoute"},
+				"as_path_aggregator_as_number": "65000",
+				"as_path_aggregator_address":   "192.0.2.1",
+				"as_path_atomic_aggregate":     true,
+				"as_path_origin":               "igp",
+				"as_path_path":                 "65000 65000",
+			},
+		},
+		{
+			resource: "junos_application_set",
+			name:     "basic",
+			config: map[string]interface{}{
+				"name":         "testacc_app_set",
+				"applications": []interface{}{"junos-ssh"},
+			},
+		},
+		{
+			resource: "junos_application",
+			name:     "basic",
+			config: map[string]interface{}{
+				"name":             "testacc_app",
+				"protocol":         "tcp",
+				"destination_port": 22,
+			},
+		},
+		{
+			resource: "junos_bgp_group",
+			name:     "basic",
+			preset:   []string{"set routing-instances testacc_bgpgroup instance-type virtual-router"},
+			config: map[string]interface{}{
+				"name":               "testacc_bgpgroup",
+				"routing_instance":   "testacc_bgpgroup",
+				"advertise_inactive": true,
+				"advertise_peer_as":  true,
+				"as_override":        true,
+				"bgp_multipath": []interface{}{
+					map[string]interface{}{},
+				},
+				"cluster":                  "192.0.2.3",
+				"damping":                  true,
+				"log_updown":               true,
+				"mtu_discovery":            true,
+				"remove_private":           true,
+				"passive":                  true,
+				"hold_time":                30,
+				"keep_none":                true,
+				"local_as":                 "65001",
+				"local_as_private":         true,
+				"local_as_loops":           1,
+				"local_preference":         100,
+				"metric_out":               100,
+				"out_delay":                30,
+				"peer_as":                  "65002",
+				"preference":               100,
+				"authentication_algorithm": "md5",
+				"local_address":            "192.0.2.3",
+				"export":                   []interface{}{"testacc_bgpgroup"},
+				"import":                   []interface{}{"testacc_bgpgroup"},
+				"bfd_liveness_detection": []interface{}{
+					map[string]interface{}{
+						"detection_time_threshold":           60,
+						"transmit_interval_threshold":        30,
+						"transmit_interval_minimum_interval": 10,
+						"holddown_interval":                  10,
+						"minimum_interval":                   10,
+						"minimum_receive_interval":           10,
+						"multiplier":                         2,
+						"session_mode":                       "automatic",
+					},
+				},
+				"family_inet": []interface{}{
+					map[string]interface{}{
+						"nlri_type": "unicast",
+						"accepted_prefix_limit": []interface{}{
+							map[string]interface{}{
+								"maximum":               2,
+								"teardown":              50,
+								"teardown_idle_timeout": 30,
+							},
+						},
+						"prefix_limit": []interface{}{
+							map[string]interface{}{
+								"maximum":               2,
+								"teardown":              50,
+								"teardown_idle_timeout": 30,
+							},
+						},
+					},
+					map[string]interface{}{
+						"nlri_type": "multicast",
+						"accepted_prefix_limit": []interface{}{
+							map[string]interface{}{
+								"maximum":                       2,
+								"teardown_idle_timeout_forever": true,
+							},
+						},
+						"prefix_limit": []interface{}{
+							map[string]interface{}{
+								"maximum":                       2,
+								"teardown_idle_timeout_forever": true,
+							},
+						},
+					},
+				},
+				"family_inet6": []interface{}{
+					map[string]interface{}{
+						"nlri_type": "unicast",
+						"accepted_prefix_limit": []interface{}{
+							map[string]interface{}{
+								"maximum":               2,
+								"teardown":              50,
+								"teardown_idle_timeout": 30,
+							},
+						},
+						"prefix_limit": []interface{}{
+							map[string]interface{}{
+								"maximum":               2,
+								"teardown":              50,
+								"teardown_idle_timeout": 30,
+							},
+						},
+					},
+					map[string]interface{}{
+						"nlri_type": "multicast",
+					},
+				},
+				"graceful_restart": []interface{}{
+					map[string]interface{}{
+						"disable": true,
+					},
+				},
+			},
+		},
+		{
+			resource: "junos_chassis_cluster",
+			name:     "basic",
+			config: map[string]interface{}{
+				"fab0": []interface{}{
+					map[string]interface{}{
+						"member_interfaces": []interface{}{"ge-0/0/3"},
+					},
+				},
+				"redundancy_group": []interface{}{
+					map[string]interface{}{
+						"node0_priority": 100,
+						"node1_priority": 99,
+					},
+					map[string]interface{}{
+						"node0_priority": 98,
+						"node1_priority": 97,
+						"interface_monitor": []interface{}{
+							map[string]interface{}{
+								"name":   "ge-0/0/4",
+								"weight": 255,
+							},
+						},
+					},
+				},
+				"reth_count": 2,
+			},
+		},
+		{
+			resource: "junos_config",
+			name:     "basic",
+			config: map[string]interface{}{
+				"hierarchy": "snmp community testacc_config",
+				"lines": []interface{}{
+					"authorization read-only",
+					"clients 192.0.2.0/25",
+				},
+			},
+		},
+		{
+			resource: "junos_firewall_filter",
+			name:     "basic",
+			config: map[string]interface{}{
+				"name":               "testacc_fwFilter",
+				"family":             "inet",
+				"interface_specific": true,
+				"term": []interface{}{
+					map[string]interface{}{
+						"name": "testacc_fwFilter_term1",
+						"from": []interface{}{
+							map[string]interface{}{
+								"address":            []interface{}{"192.0.2.0/25"},
+								"address_except":     []interface{}{"192.0.2.128/25"},
+								"port":               []interface{}{"22-23"},
+								"prefix_list":        []interface{}{"testacc_fwFilter"},
+								"prefix_list_except": []interface{}{"testacc_fwFilter2"},
+								"protocol":           []interface{}{"tcp"},
+								"tcp_flags":          "!0x3",
+								"is_fragment":        true,
+							},
+						},
+						"then": []interface{}{
+							map[string]interface{}{
+								"action":             "next term",
+								"syslog":             true,
+								"log":                true,
+								"port_mirror":        true,
+								"service_accounting": true,
+							},
+						},
+					},
+					map[string]interface{}{
+						"name": "testacc_fwFilter_term2",
+						"from": []interface{}{
+							map[string]interface{}{
+								"icmp_code": []interface{}{"network-unreachable"},
+								"icmp_type": []interface{}{"router-advertisement"},
+							},
+						},
+						"then": []interface{}{
+							map[string]interface{}{
+								"action": "accept",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			resource: "junos_firewall_policer",
+			name:     "basic",
+			config: map[string]interface{}{
+				"name":            "testacc_fwPolic",
+				"filter_specific": true,
+				"if_exceeding": []interface{}{
+					map[string]interface{}{
+						"bandwidth_percent": 80,
+						"burst_size_limit":  "50k",
+					},
+				},
+				"then": []interface{}{
+					map[string]interface{}{
+						"discard": true,
+					},
+				},
+			},
+		},
+		{
+			resource: "junos_forwardingoptions_sampling_instance",
+			name:     "basic",
+			config: map[string]interface{}{
+				"name": "testacc_instance@1",
+				"input": []interface{}{
+					map[string]interface{}{
+						"rate": 1,
+					},
+				},
+				"family_inet_output": []interface{}{
+					map[string]interface{}{
+						"flow_server": []interface{}{
+							map[string]interface{}{
+								"hostname": "192.0.2.1",
+								"port":     3000,
+							},
+						},
+						"interface": []interface{}{
+							map[string]interface{}{
+								"name":           "si-0/1/0",
+								"source_address": "192.0.2.2",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			resource: "junos_forwardingoptions_sampling_instance",
+			name:     "basic2",
+			config: map[string]interface{}{
+				"name": "testacc_instance@2",
+				"family_inet_input": []interface{}{
+					map[string]interface{}{
+						"rate": 2,
+					},
+				},
+				"family_inet_output": []interface{}{
+					map[string]interface{}{
+						"inline_jflow_source_address": "192.0.2.2",
+						"flow_server": []interface{}{
+							map[string]interface{}{
+								"hostname":               "192.0.2.1",
+								"port":                   3000,
+								"version_ipfix_template": "testacc_sampInstance@2",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			resource: "junos_forwardingoptions_sampling_instance",
+			name:     "basic3",
+			config: map[string]interface{}{
+				"name": "testacc_instance@3",
+				"family_inet6_input": []interface{}{
+					map[string]interface{}{
+						"rate": 2,
+					},
+				},
+				"family_inet6_output": []interface{}{
+					map[string]interface{}{
+						"inline_jflow_source_address": "192.0.2.2",
+						"flow_server": []interface{}{
+							map[string]interface{}{
+								"hostname":               "192.0.2.1",
+								"port":                   3000,
+								"version_ipfix_template": "testacc_sampInstance@3",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			resource: "junos_forwardingoptions_sampling_instance",
+			name:     "basic4",
+			config: map[string]interface{}{
+				"name": "testacc_instance@4",
+				"family_mpls_input": []interface{}{
+					map[string]interface{}{
+						"rate": 2,
+					},
+				},
+				"family_mpls_output": []interface{}{
+					map[string]interface{}{
+						"inline_jflow_source_address": "192.0.2.2",
+						"flow_server": []interface{}{
+							map[string]interface{}{
+								"hostname":               "192.0.2.1",
+								"port":                   3000,
+								"version_ipfix_template": "testacc_sampInstance@4",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			resource: "junos_generate_route",
+			name:     "basic",
+			preset:   []string{"set routing-instances testacc_generateRoute instance-type virtual-router"},
+			config: map[string]interface{}{
+				"destination":                  "192.0.2.0/24",
+				"routing_instance":             "testacc_generateRoute",
+				"preference":                   100,
+				"metric":                       100,
+				"active":                       true,
+				"full":                         true,
+				"discard":                      true,
+				"community":                    []interface{}{"no-advertise"},
+				"policy":                       []interface{}{"testacc_generateRoute"},
+				"as_path_aggregator_as_number": "65000",
+				"as_path_aggregator_address":   "192.0.2.1",
+				"as_path_atomic_aggregate":     true,
+				"as_path_origin":               "igp",
+				"as_path_path":                 "65000 65000",
+			},
+		},
+		{
+			resource: "junos_generate_route",
+			name:     "basic2",
+			preset:   []string{"set routing-instances testacc_generateRoute instance-type virtual-router"},
+			config: map[string]interface{}{
+				"destination":                  "2001:db8:85a3::/48",
+				"routing_instance":             "testacc_generateRoute",
+				"preference":                   100,
+				"metric":                       100,
+				"active":                       true,
+				"full":                         true,
+				"discard":                      true,
+				"community":                    []interface{}{"no-advertise"},
+				"policy":                       []interface{}{"testacc_generateRoute"},
+				"as_path_aggregator_as_number": "65000",
+				"as_path_aggregator_address":   "192.0.2.1",
+				"as_path_atomic_aggregate":     true,
+				"as_path_origin":               "igp",
+				"as_path_path":                 "65000 65000",
+			},
+		},
+		{
+			resource: "junos_group_dual_system",
+			name:     "basic",
+			config: map[string]interface{}{
+				"name": "node0",
+				"interface_fxp0": []interface{}{
+					map[string]interface{}{
+						"description": "test_",
+						"family_inet_address": []interface{}{
+							map[string]interface{}{
+								"cidr_ip": "192.0.2.193/26",
+							},
+						},
+						"family_inet6_address": []interface{}{
+							map[string]interface{}{
+								"cidr_ip": "fe80::2/64",
+							},
+						},
+					},
+				},
+				"routing_options": []interface{}{
+					map[string]interface{}{
+						"static_route": []interface{}{
+							map[string]interface{}{
+								"destination": "192.0.2.0/26",
+								"next_hop":    []interface{}{"192.0.2.254"},
+							},
+							map[string]interface{}{
+								"destination": "192.0.2.64/26",
+								"next_hop":    []interface{}{"192.0.2.254"},
+							},
+						},
+					},
+				},
+				"security": []interface{}{
+					map[string]interface{}{
+						"log_source_address": "192.0.2.128",
+					},
+				},
+				"system": []interface{}{
+					map[string]interface{}{
+						"host_name":                 "test_node",
+						"backup_router_address":     "192.0.2.254",
+						"backup_router_destination": []interface{}{"192.0.2.0/26"},
+					},
+				},
+			},
+		},
+		{
+			resource: "junos_interface_logical",
+			name:     "basic",
+			ignore:   []string{"vlan_id"},
+			preset: []string{
+				"set routing-instances testacc_interface_logical instance-type virtual-router",
+				"set security zones security-zone testacc_interface_logical",
+			},
+			config: map[string]interface{}{
+				"name":                       "ge-0/0/3.100",
+				"description":                "testacc_interface_ge-0/0/3.100",
+				"security_zone":              "testacc_interface_logical",
+				"security_inbound_protocols": []interface{}{"bgp"},
+				"security_inbound_services":  []interface{}{"ssh"},
+				"routing_instance":           "testacc_interface_logical",
+				"family_inet": []interface{}{
+					map[string]interface{}{
+						"mtu":           1400,
+						"filter_input":  "testacc_intlogicalInet",
+						"filter_output": "testacc_intlogicalInet",
+						"rpf_check": []interface{}{
+							map[string]interface{}{},
+						},
+						"address": []interface{}{
+							map[string]interface{}{
+								"cidr_ip": "192.0.2.1/25",
+								"vrrp_group": []interface{}{
+									map[string]interface{}{
+										"identifier":               100,
+										"virtual_address":          []interface{}{"192.0.2.2"},
+										"accept_data":              true,
+										"advertise_interval":       10,
+										"advertisements_threshold": 3,
+										"authentication_key":       "thePassWord",
+										"authentication_type":      "md5",
+										"preempt":                  true,
+										"priority":                 100,
+										"track_interface": []interface{}{
+											map[string]interface{}{
+												"interface":     "ge-0/0/3",
+												"priority_cost": 20,
+											},
+										},
+										"track_route": []interface{}{
+											map[string]interface{}{
+												"route":            "192.0.2.128/25",
+												"routing_instance": "default",
+												"priority_cost":    20,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				"family_inet6": []interface{}{
+					map[string]interface{}{
+						"mtu":           1400,
+						"filter_input":  "testacc_intlogicalInet6",
+						"filter_output": "testacc_intlogicalInet6",
+						"address": []interface{}{
+							map[string]interface{}{
+								"cidr_ip": "2001:db8::1/64",
+								"vrrp_group": []interface{}{
+									map[string]interface{}{
+										"identifier":                 100,
+										"virtual_address":            []interface{}{"2001:db8::2"},
+										"virtual_link_local_address": "fe80::2",
+										"accept_data":                true,
+										"advertise_interval":         100,
+										"advertisements_threshold":   3,
+										"preempt":                    true,
+										"priority":                   100,
+										"track_interface": []interface{}{
+											map[string]interface{}{
+												"interface":     "ge-0/0/3",
+												"priority_cost": 20,
+											},
+										},
+										"track_route": []interface{}{
+											map[string]interface{}{
+												"route":            "192.0.2.128/25",
+												"routing_instance": "default",
+												"priority_cost":    20,
+											},
+										},
+									},
+								},
+							},
+							map[string]interface{}{
+								"cidr_ip": "fe80::1/64",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			resource: "junos_interface_st0_unit",
+			name:     "basic",
+			config:   map[string]interface{}{},
+		},
+		{
+			resource: "junos_interface",
+			name:     "basic",
+			afterDelete: []string{
+				"set interfaces ge-0/0/3 disable",
+				"set interfaces ge-0/0/3 description NC",
+			},
+			config: map[string]interface{}{
+				"name":         "ge-0/0/3",
+				"description":  "testacc_interface",
+				"trunk":        true,
+				"vlan_native":  100,
+				"vlan_members": []interface{}{"100-110"},
+			},
+		},
+		{
+			resource: "junos_ospf_area",
+			name:     "basic",
+			config: map[string]interface{}{
+				"area_id": "0.0.0.0",
+				"interface": []interface{}{
+					map[string]interface{}{
+						"name":                "all",
+						"disable":             true,
+						"passive":             true,
+						"metric":              100,
+						"retransmit_interval": 10,
+						"hello_interval":      10,
+						"dead_interval":       10,
+					},
+				},
+			},
+		},
+		{
+			resource: "junos_rib_group",
+			name:     "basic",
+			preset:   []string{"set routing-instances testacc_ribGroup1 instance-type virtual-router"},
+			config: map[string]interface{}{
+				"name":          "testacc_ribGroup-test",
+				"import_policy": []interface{}{"testacc_ribGroup"},
+				"import_rib":    []interface{}{"testacc_ribGroup1.inet.0"},
+				"export_rib":    "testacc_ribGroup1.inet.0",
+			},
+		},
+		{
+			resource: "junos_routing_instance",
+			name:     "basic",
+			config: map[string]interface{}{
+				"name": "testacc_routingInst",
+				"as":   "65000",
+			},
+		},
+		{
+			resource:     "junos_routing_options",
+			name:         "basic",
+			keepOnDelete: true,
+			config: map[string]interface{}{
+				"autonomous_system": []interface{}{
+					map[string]interface{}{
+						"number":         "65000",
+						"asdot_notation": true,
+						"loops":          5,
+					},
+				},
+				"graceful_restart": []interface{}{
+					map[string]interface{}{
+						"restart_duration": 120,
+						"disable":          true,
+					},
+				},
+			},
+		},
+		{
+			resource:     "junos_security",
+			name:         "basic",
+			keepOnDelete: true,
+			preset:       []string{"set interfaces ge-0/0/3 unit 0 description testacc_security"},
+			config: map[string]interface{}{
+				"alg": []interface{}{
+					map[string]interface{}{
+						"dns_disable":    true,
+						"ftp_disable":    true,
+						"h323_disable":   true,
+						"mgcp_disable":   true,
+						"msrpc_disable":  true,
+						"pptp_disable":   true,
+						"rsh_disable":    true,
+						"rtsp_disable":   true,
+						"sccp_disable":   true,
+						"sip_disable":    true,
+						"sql_disable":    true,
+						"sunrpc_disable": true,
+						"talk_disable":   true,
+						"tftp_disable":   true,
+					},
+				},
+				"flow": []interface{}{
+					map[string]interface{}{
+						"advanced_options": []interface{}{
+							map[string]interface{}{
+								"drop_matching_reserved_ip_address": true,
+								"drop_matching_link_local_address":  true,
+								"reverse_route_packet_mode_vr":      true,
+							},
+						},
+						"aging": []interface{}{
+							map[string]interface{}{
+								"early_ageout":   10,
+								"high_watermark": 90,
+								"low_watermark":  80,
+							},
+						},
+						"allow_dns_reply":                       true,
+						"allow_embedded_icmp":                   true,
+						"allow_reverse_ecmp":                    true,
+						"enable_reroute_uniform_link_check_nat": true,
+						"force_ip_reassembly":                   true,
+						"ipsec_performance_acceleration":        true,
+						"mcast_buffer_enhance":                  true,
+						"pending_sess_queue_length":             "normal",
+						"preserve_incoming_fragment_size":       true,
+						"route_change_timeout":                  10,
+						"syn_flood_protection_mode":             "syn-proxy",
+						"sync_icmp_session":                     true,
+						"tcp_mss": []interface{}{
+							map[string]interface{}{
+								"all_tcp_mss": 1499,
+								"ipsec_vpn": []interface{}{
+									map[string]interface{}{
+										"mss": 1400,
+									},
+								},
+							},
+						},
+						"tcp_session": []interface{}{
+							map[string]interface{}{
+								"fin_invalidate_session": true,
+								"maximum_window":         "512K",
+								"no_sequence_check":      true,
+								"rst_invalidate_session": true,
+								"rst_sequence_check":     true,
+								"strict_syn_check":       true,
+								"tcp_initial_timeout":    10,
+							},
+						},
+					},
+				},
+				"forwarding_options": []interface{}{
+					map[string]interface{}{
+						"inet6_mode":            "flow-based",
+						"mpls_mode":             "flow-based",
+						"iso_mode_packet_based": true,
+					},
+				},
+				"forwarding_process": []interface{}{
+					map[string]interface{}{
+						"enhanced_services_mode": true,
+					},
+				},
+				"ike_traceoptions": []interface{}{
+					map[string]interface{}{
+						"file": []interface{}{
+							map[string]interface{}{
+								"name":           "ike.log",
+								"files":          5,
+								"match":          "test",
+								"size":           100000,
+								"world_readable": true,
+							},
+						},
+						"flag":            []interface{}{"all"},
+						"rate_limit":      100,
+						"no_remote_trace": true,
+					},
+				},
+				"log": []interface{}{
+					map[string]interface{}{
+						"disable":           true,
+						"facility_override": "local7",
+						"file": []interface{}{
+							map[string]interface{}{
+								"files": 10,
+								"name":  "security.log",
+								"path":  "/",
+								"size":  10,
+							},
+						},
+						"format":           "syslog",
+						"mode":             "event",
+						"report":           true,
+						"source_interface": "ge-0/0/3.0",
+						"transport": []interface{}{
+							map[string]interface{}{
+								"protocol":        "tcp",
+								"tcp_connections": 5,
+								"tls_profile":     "testacc",
+							},
+						},
+						"utc_timestamp": true,
+					},
+				},
+				"policies": []interface{}{
+					map[string]interface{}{
+						"policy_rematch": true,
+					},
+				},
+				"utm": []interface{}{
+					map[string]interface{}{
+						"feature_profile_web_filtering_type": "juniper-enhanced",
+						"feature_profile_web_filtering_juniper_enhanced_server": []interface{}{
+							map[string]interface{}{
+								"host": "192.0.2.1",
+								"port": 1500,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			resource: "junos_security_address_book",
+			name:     "basic",
+			config: map[string]interface{}{
+				"description": "testacc global description",
+				"network_address": []interface{}{
+					map[string]interface{}{
+						"name":        "testacc_network",
+						"description": "testacc_network description",
+						"value":       "10.0.0.0/24",
+					},
+					map[string]interface{}{
+						"name":        "testacc_network2",
+						"description": "testacc_network description2",
+						"value":       "10.1.0.0/24",
+					},
+				},
+				"wildcard_address": []interface{}{
+					map[string]interface{}{
+						"name":  "testacc_wildcard",
+						"value": "10.0.0.0/255.255.0.255",
+					},
+				},
+				"range_address": []interface{}{
+					map[string]interface{}{
+						"name": "testacc_range",
+						"from": "10.1.1.1",
+						"to":   "10.1.1.5",
+					},
+				},
+				"dns_name": []interface{}{
+					map[string]interface{}{
+						"name":  "testacc_dns",
+						"value": "google.com",
+					},
+				},
+				"address_set": []interface{}{
+					map[string]interface{}{
+						"name":    "testacc_addressSet",
+						"address": []interface{}{"testacc_network", "testacc_wildcard", "testacc_network2"},
+					},
+				},
+			},
+		},
+		{
+			resource: "junos_security_address_book",
+			name:     "basic2",
+			config: map[string]interface{}{
+				"name":        "testacc_secAddrBook",
+				"attach_zone": []interface{}{"testacc_secZoneAddr1", "testacc_secZoneAddr2"},
+				"network_address": []interface{}{
+					map[string]interface{}{
+						"name":  "testacc_network",
+						"value": "10.1.2.3/32",
+					},
+				},
+			},
+		},
+		{
+			resource: "junos_security_global_policy",
+			name:     "basic",
+			config: map[string]interface{}{
+				"policy": []interface{}{
+					map[string]interface{}{
+						"name":                               "test",
+						"match_source_address":               []interface{}{"blue"},
+						"match_destination_address":          []interface{}{"green"},
+						"match_destination_address_excluded": true,
+						"match_application":                  []interface{}{"any"},
+						"match_dynamic_application":          []interface{}{"junos:web:wiki", "junos:web:infrastructure"},
+						"match_source_end_user_profile":      "testacc_secglobpolicy",
+						"match_from_zone":                    []interface{}{"testacc_secglobpolicy1"},
+						"match_to_zone":                      []interface{}{"testacc_secglobpolicy2"},
+					},
+				},
+			},
+		},
+		{
+			resource: "junos_security_nat_destination",
+			name:     "basic",
+			preset:   []string{"set routing-instances testacc_securityDNAT instance-type virtual-router"},
+			config: map[string]interface{}{
+				"name": "testacc_securityDNAT",
+				"from": []interface{}{
+					map[string]interface{}{
+						"type":  "zone",
+						"value": []interface{}{"testacc_securityDNAT"},
+					},
+				},
+				"rule": []interface{}{
+					map[string]interface{}{
+						"name":                "testacc_securityDNATRule",
+						"destination_address": "192.0.2.1/32",
+						"then": []interface{}{
+							map[string]interface{}{
+								"type": "pool",
+								"pool": "testacc_securityDNATPool",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			resource: "junos_security_nat_source",
+			name:     "basic",
+			preset:   []string{"set routing-instances testacc_securitySNAT instance-type virtual-router"},
+			config: map[string]interface{}{
+				"name": "testacc_securitySNAT",
+				"from": []interface{}{
+					map[string]interface{}{
+						"type":  "zone",
+						"value": []interface{}{"testacc_securitySNAT"},
+					},
+				},
+				"to": []interface{}{
+					map[string]interface{}{
+						"type":  "zone",
+						"value": []interface{}{"testacc_securitySNAT"},
+					},
+				},
+				"rule": []interface{}{
+					map[string]interface{}{
+						"name": "testacc_securitySNATRule",
+						"match": []interface{}{
+							map[string]interface{}{
+								"source_address":      []interface{}{"192.0.2.0/25"},
+								"destination_address": []interface{}{"192.0.2.128/25"},
+								"protocol":            []interface{}{"tcp"},
+							},
+						},
+						"then": []interface{}{
+							map[string]interface{}{
+								"type": "pool",
+								"pool": "testacc_securitySNATPool",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			resource: "junos_security_nat_static",
+			name:     "basic",
+			preset:   []string{"set routing-instances testacc_securityNATStt instance-type virtual-router"},
+			config: map[string]interface{}{
+				"name": "testacc_securityNATStt",
+				"from": []interface{}{
+					map[string]interface{}{
+						"type":  "zone",
+						"value": []interface{}{"testacc_securityNATStt"},
+					},
+				},
+				"rule": []interface{}{
+					map[string]interface{}{
+						"name":                "testacc_securityNATSttRule",
+						"destination_address": "192.0.2.0/25",
+						"then": []interface{}{
+							map[string]interface{}{
+								"type":             "prefix",
+								"routing_instance": "testacc_securityNATStt",
+								"prefix":           "192.0.2.128/25",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			resource: "junos_security_policy",
+			name:     "basic",
+			config: map[string]interface{}{
+				"from_zone": "testacc_seczonePolicy1",
+				"to_zone":   "testacc_seczonePolicy1",
+				"policy": []interface{}{
+					map[string]interface{}{
+						"name":                          "testacc_Policy_1",
+						"match_source_address":          []interface{}{"testacc_address1"},
+						"match_destination_address":     []interface{}{"any"},
+						"match_application":             []interface{}{"junos-ssh"},
+						"match_dynamic_application":     []interface{}{"junos:web:wiki", "junos:web:infrastructure"},
+						"match_source_end_user_profile": "testacc_securityPolicy",
+						"log_init":                      true,
+						"log_close":                     true,
+						"count":                         true,
+					},
+				},
+			},
+		},
+		{
+			resource: "junos_security_screen",
+			name:     "basic",
+			config: map[string]interface{}{
+				"name":               "testacc 1",
+				"alarm_without_drop": true,
+				"description":        "desc testacc 1",
+				"icmp": []interface{}{
+					map[string]interface{}{
+						"flood": []interface{}{
+							map[string]interface{}{},
+						},
+						"fragment":         true,
+						"icmpv6_malformed": true,
+						"large":            true,
+						"ping_death":       true,
+						"sweep": []interface{}{
+							map[string]interface{}{},
+						},
+					},
+				},
+				"ip": []interface{}{
+					map[string]interface{}{
+						"bad_option": true,
+						"block_frag": true,
+						"ipv6_extension_header": []interface{}{
+							map[string]interface{}{
+								"ah_header":  true,
+								"esp_header": true,
+								"hip_header": true,
+								"destination_header": []interface{}{
+									map[string]interface{}{},
+								},
+								"fragment_header": true,
+								"hop_by_hop_header": []interface{}{
+									map[string]interface{}{},
+								},
+								"mobility_header":          true,
+								"no_next_header":           true,
+								"routing_header":           true,
+								"shim6_header":             true,
+								"user_defined_header_type": []interface{}{"10 to 20", "2 to 5", "1"},
+							},
+						},
+						"ipv6_extension_header_limit": 32,
+						"ipv6_malformed_header":       true,
+						"loose_source_route_option":   true,
+						"record_route_option":         true,
+						"security_option":             true,
+						"source_route_option":         true,
+						"spoofing":                    true,
+						"stream_option":               true,
+						"strict_source_route_option":  true,
+						"tear_drop":                   true,
+						"timestamp_option":            true,
+						"tunnel": []interface{}{
+							map[string]interface{}{
+								"bad_inner_header": true,
+								"gre": []interface{}{
+									map[string]interface{}{
+										"gre_4in4": true,
+										"gre_4in6": true,
+										"gre_6in4": true,
+										"gre_6in6": true,
+									},
+								},
+								"ip_in_udp_teredo": true,
+								"ipip": []interface{}{
+									map[string]interface{}{
+										"ipip_4in4":      true,
+										"ipip_4in6":      true,
+										"ipip_6in4":      true,
+										"ipip_6in6":      true,
+										"ipip_6over4":    true,
+										"ipip_6to4relay": true,
+										"dslite":         true,
+										"isatap":         true,
+									},
+								},
+							},
+						},
+						"unknown_protocol": true,
+					},
+				},
+				"limit_session": []interface{}{
+					map[string]interface{}{
+						"destination_ip_based": 2000,
+						"source_ip_based":      3000,
+					},
+				},
+				"tcp": []interface{}{
+					map[string]interface{}{
+						"fin_no_ack": true,
+						"land":       true,
+						"no_flag":    true,
+						"port_scan": []interface{}{
+							map[string]interface{}{},
+						},
+						"syn_ack_ack_proxy": []interface{}{
+							map[string]interface{}{},
+						},
+						"syn_fin": true,
+						"syn_flood": []interface{}{
+							map[string]interface{}{
+								"alarm_threshold":       10011,
+								"attack_threshold":      10012,
+								"destination_threshold": 10013,
+								"source_threshold":      10014,
+								"timeout":               10,
+								"whitelist": []interface{}{
+									map[string]interface{}{
+										"name":                "test3",
+										"source_address":      []interface{}{"192.0.2.0/26"},
+										"destination_address": []interface{}{"192.0.2.64/26"},
+									},
+								},
+							},
+						},
+						"syn_frag": true,
+						"sweep": []interface{}{
+							map[string]interface{}{},
+						},
+						"winnuke": true,
+					},
+				},
+				"udp": []interface{}{
+					map[string]interface{}{
+						"flood": []interface{}{
+							map[string]interface{}{},
+						},
+						"port_scan": []interface{}{
+							map[string]interface{}{},
+						},
+						"sweep": []interface{}{
+							map[string]interface{}{},
+						},
+					},
+				},
+			},
+		},
+		{
+			resource: "junos_security_utm_custom_url_category",
+			name:     "basic",
+			config: map[string]interface{}{
+				"name":  "testacc_URLCategory",
+				"value": []interface{}{"testacc-custom-pattern1"},
+			},
+		},
+		{
+			resource: "junos_security_utm_custom_url_pattern",
+			name:     "basic",
+			config: map[string]interface{}{
+				"name":  "testacc_UrlPattern",
+				"value": []interface{}{"*.google.com"},
+			},
+		},
+		{
+			resource: "junos_security_utm_policy",
+			name:     "basic",
+			config: map[string]interface{}{
+				"name": "testacc Policy",
+				"anti_virus": []interface{}{
+					map[string]interface{}{
+						"http_profile": "junos-sophos-av-defaults",
+					},
+				},
+				"traffic_sessions_per_client": []interface{}{
+					map[string]interface{}{
+						"over_limit": "log-and-permit",
+					},
+				},
+				"web_filtering_profile": "junos-wf-local-default",
+			},
+		},
+		{
+			resource: "junos_security_utm_profile_web_filtering_juniper_enhanced",
+			name:     "basic",
+			config: map[string]interface{}{
+				"name": "testacc ProfileWebFE",
+				"block_message": []interface{}{
+					map[string]interface{}{
+						"url":                      "block.local",
+						"type_custom_redirect_url": true,
+					},
+				},
+				"category": []interface{}{
+					map[string]interface{}{
+						"name":   "Enhanced_Network_Errors",
+						"action": "block",
+					},
+					map[string]interface{}{
+						"name":   "Enhanced_Suspicious_Content",
+						"action": "quarantine",
+						"reputation_action": []interface{}{
+							map[string]interface{}{
+								"site_reputation": "very-safe",
+								"action":          "log-and-permit",
+							},
+							map[string]interface{}{
+								"site_reputation": "moderately-safe",
+								"action":          "log-and-permit",
+							},
+						},
+					},
+				},
+				"custom_block_message": "Blocked by Juniper",
+				"default_action":       "log-and-permit",
+				"fallback_settings": []interface{}{
+					map[string]interface{}{
+						"default":             "log-and-permit",
+						"server_connectivity": "log-and-permit",
+						"timeout":             "log-and-permit",
+					},
+				},
+			},
+		},
+		{
+			resource: "junos_security_utm_profile_web_filtering_juniper_local",
+			name:     "basic",
+			config: map[string]interface{}{
+				"name":                 "testacc ProfileWebFL",
+				"custom_block_message": "Blocked by Juniper",
+				"default_action":       "log-and-permit",
+				"fallback_settings": []interface{}{
+					map[string]interface{}{
+						"default":             "log-and-permit",
+						"server_connectivity": "log-and-permit",
+						"timeout":             "log-and-permit",
+					},
+				},
+			},
+		},
+		{
+			resource: "junos_security_utm_profile_web_filtering_websense_redirect",
+			name:     "basic",
+			config: map[string]interface{}{
+				"name":                 "testacc ProfileWebFWebS",
+				"custom_block_message": "Blocked by Juniper",
+				"fallback_settings": []interface{}{
+					map[string]interface{}{
+						"default":             "log-and-permit",
+						"server_connectivity": "log-and-permit",
+						"timeout":             "log-and-permit",
+					},
+				},
+			},
+		},
+		{
+			resource: "junos_security_zone_book_address_set",
+			name:     "basic",
+			preset:   []string{"set security zones security-zone testacc_szone_bookaddressset"},
+			config: map[string]interface{}{
+				"name":        "testacc_szone_bookaddress_set",
+				"zone":        "testacc_szone_bookaddressset",
+				"address":     []interface{}{"testacc_szone_bookaddress_set1"},
+				"description": "testacc szone bookaddress set",
+			},
+		},
+		{
+			resource: "junos_security_zone_book_address",
+			name:     "basic",
+			preset:   []string{"set security zones security-zone testacc_szone_bookaddress"},
+			config: map[string]interface{}{
+				"name":        "testacc_szone_bookaddress1",
+				"zone":        "testacc_szone_bookaddress",
+				"cidr":        "192.0.2.0/25",
+				"description": "testacc szone bookaddress1",
+			},
+		},
+		{
+			resource: "junos_security_zone_book_address",
+			name:     "basic2",
+			preset:   []string{"set security zones security-zone testacc_szone_bookaddress"},
+			config: map[string]interface{}{
+				"name":        "testacc_szone_bookaddress2",
+				"zone":        "testacc_szone_bookaddress",
+				"dns_name":    "test.com",
+				"description": "testacc szone bookaddress2",
+			},
+		},
+		{
+			resource: "junos_security_zone_book_address",
+			name:     "basic3",
+			preset:   []string{"set security zones security-zone testacc_szone_bookaddress"},
+			config: map[string]interface{}{
+				"name":          "testacc_szone_bookaddress3",
+				"zone":          "testacc_szone_bookaddress",
+				"dns_name":      "test.com",
+				"description":   "testacc szone bookaddress3",
+				"dns_ipv4_only": true,
+			},
+		},
+		{
+			resource: "junos_security_zone_book_address",
+			name:     "basic4",
+			preset:   []string{"set security zones security-zone testacc_szone_bookaddress"},
+			config: map[string]interface{}{
+				"name":          "testacc_szone_bookaddress4",
+				"zone":          "testacc_szone_bookaddress",
+				"dns_name":      "test.com",
+				"description":   "testacc szone bookaddress4",
+				"dns_ipv6_only": true,
+			},
+		},
+		{
+			resource: "junos_security_zone_book_address",
+			name:     "basic5",
+			preset:   []string{"set security zones security-zone testacc_szone_bookaddress"},
+			config: map[string]interface{}{
+				"name":        "testacc_szone_bookaddress5",
+				"zone":        "testacc_szone_bookaddress",
+				"range_from":  "192.0.2.10",
+				"range_to":    "192.0.2.12",
+				"description": "testacc szone bookaddress5",
+			},
+		},
+		{
+			resource: "junos_security_zone_book_address",
+			name:     "basic6",
+			preset:   []string{"set security zones security-zone testacc_szone_bookaddress"},
+			config: map[string]interface{}{
+				"name":        "testacc_szone_bookaddress6",
+				"zone":        "testacc_szone_bookaddress",
+				"wildcard":    "192.0.2.0/255.0.255.255",
+				"description": "testacc szone bookaddress6",
+			},
+		},
+		{
+			resource: "junos_security_zone",
+			name:     "basic",
+			config: map[string]interface{}{
+				"name": "testacc_securityZone",
+				"address_book": []interface{}{
+					map[string]interface{}{
+						"name":        "testacc_zone1",
+						"description": "testacc_zone 1",
+						"network":     "192.0.2.0/25",
+					},
+				},
+				"address_book_dns": []interface{}{
+					map[string]interface{}{
+						"name":        "testacc_zone2",
+						"description": "testacc_zone 2",
+						"fqdn":        "test.com",
+					},
+					map[string]interface{}{
+						"name":        "testacc_zone2b",
+						"description": "testacc_zone 2b",
+						"fqdn":        "test.com",
+						"ipv4_only":   true,
+					},
+					map[string]interface{}{
+						"name":        "testacc_zone2c",
+						"description": "testacc_zone 2c",
+						"fqdn":        "test.com",
+						"ipv6_only":   true,
+					},
+				},
+				"address_book_range": []interface{}{
+					map[string]interface{}{
+						"name":        "testacc_zone3",
+						"description": "testacc_zone 3",
+						"from":        "192.0.2.10",
+						"to":          "192.0.2.12",
+					},
+				},
+				"address_book_set": []interface{}{
+					map[string]interface{}{
+						"name":        "testacc_zoneSet",
+						"description": "testacc_zone Set",
+						"address":     []interface{}{"testacc_zone1"},
+					},
+				},
+				"address_book_wildcard": []interface{}{
+					map[string]interface{}{
+						"name":        "testacc_zone4",
+						"description": "testacc_zone 4",
+						"network":     "192.0.2.0/255.0.255.255",
+					},
+				},
+				"application_tracking": true,
+				"inbound_protocols":    []interface{}{"bgp"},
+				"description":          "testacc securityZone",
+				"reverse_reroute":      true,
+				"screen":               "testaccZone",
+				"source_identity_log":  true,
+				"tcp_rst":              true,
+			},
+		},
+		{
+			resource: "junos_services_flowmonitoring_vipfix_template",
+			name:     "basic",
+			config: map[string]interface{}{
+				"name": "testacc_template@1",
+				"type": "ipv4-template",
+			},
+		},
+		{
+			resource: "junos_services_flowmonitoring_vipfix_template",
+			name:     "basic2",
+			config: map[string]interface{}{
+				"name": "testacc_template@3",
+				"type": "ipv6-template",
+			},
+		},
+		{
+			resource: "junos_services_flowmonitoring_vipfix_template",
+			name:     "basic3",
+			config: map[string]interface{}{
+				"name": "testacc_template@2",
+				"type": "mpls-template",
+			},
+		},
+		{
+			resource: "junos_services_security_intelligence_policy",
+			name:     "basic",
+			config: map[string]interface{}{
+				"name": "testacc_svcSecIntelPolicy#1",
+				"category": []interface{}{
+					map[string]interface{}{
+						"name":         "CC",
+						"profile_name": "testacc_svcSecIntelPolicy_CC",
+					},
+				},
+			},
+		},
+		{
+			resource: "junos_services_security_intelligence_profile",
+			name:     "basic",
+			config: map[string]interface{}{
+				"name":     "testacc_svcSecIntelProfile@1",
+				"category": "CC",
+				"rule": []interface{}{
+					map[string]interface{}{
+						"name": "test#2",
+						"match": []interface{}{
+							map[string]interface{}{
+								"threat_level": []interface{}{10},
+								"feed_name":    []interface{}{"CC_IP"},
+							},
+						},
+						"then_action": "block close http redirect-url http://www.test.com/url1.html",
+						"then_log":    true,
+					},
+				},
+			},
+		},
+		{
+			resource:     "junos_services",
+			name:         "basic",
+			keepOnDelete: true,
+			config: map[string]interface{}{
+				"advanced_anti_malware": []interface{}{
+					map[string]interface{}{
+						"connection": []interface{}{
+							map[string]interface{}{
+								"auth_tls_profile": "testacc_services",
+								"proxy_profile":    "testacc_services",
+								"source_address":   "192.0.2.1",
+								"url":              "https://example.com/api/test.xml",
+							},
+						},
+						"default_policy": []interface{}{
+							map[string]interface{}{
+								"blacklist_notification_log":        true,
+								"default_notification_log":          true,
+								"fallback_options_action":           "permit",
+								"fallback_options_notification_log": true,
+								"http_action":                       "block",
+								"http_inspection_profile":           "testacc_services",
+								"http_notification_log":             true,
+								"imap_inspection_profile":           "testacc_services",
+								"imap_notification_log":             true,
+								"smtp_inspection_profile":           "testacc_services",
+								"smtp_notification_log":             true,
+								"verdict_threshold":                 5,
+								"whitelist_notification_log":        true,
+							},
+						},
+					},
+				},
+				"application_identification": []interface{}{
+					map[string]interface{}{
+						"application_system_cache": []interface{}{
+							map[string]interface{}{},
+						},
+						"download": []interface{}{
+							map[string]interface{}{
+								"automatic_start_time": "12-24.22:00",
+							},
+						},
+						"enable_performance_mode": []interface{}{
+							map[string]interface{}{},
+						},
+						"max_transactions": 10,
+					},
+				},
+				"security_intelligence": []interface{}{
+					map[string]interface{}{
+						"authentication_token": "abcdefghijklmnopqrstuvwxyz123456",
+						"category_disable":     []interface{}{"all"},
+						"proxy_profile":        "testacc_services",
+						"url":                  "https://example.com/api/manifest.xml",
+						"url_parameter":        "test_param",
+					},
+				},
+				"user_identification": []interface{}{
+					map[string]interface{}{
+						"device_info_auth_source": "network-access-controller",
+						"identity_management": []interface{}{
+							map[string]interface{}{
+								"connection": []interface{}{
+									map[string]interface{}{
+										"primary_address":          "192.0.2.254",
+										"primary_client_id":        "clientID",
+										"primary_client_secret":    "mySecret",
+										"connect_method":           "https",
+										"port":                     2000,
+										"primary_ca_certificate":   "ca",
+										"query_api":                "user_query/v2",
+										"secondary_address":        "192.0.2.253",
+										"secondary_ca_certificate": "ca2",
+										"secondary_client_id":      "clientID2",
+										"secondary_client_secret":  "mySecret2",
+										"token_api":                "oauth_token/oauth",
+									},
+								},
+								"authentication_entry_timeout":         60,
+								"batch_query_items_per_batch":          100,
+								"batch_query_interval":                 30,
+								"filter_domain":                        []interface{}{"test3", "test2"},
+								"filter_exclude_ip_address_book":       "testacc_services",
+								"filter_exclude_ip_address_set":        "testacc_services",
+								"filter_include_ip_address_book":       "testacc_services",
+								"filter_include_ip_address_set":        "testacc_services",
+								"invalid_authentication_entry_timeout": 60,
+								"ip_query_disable":                     true,
+								"ip_query_delay_time":                  30,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			resource: "junos_snmp_clientlist",
+			name:     "basic",
+			config: map[string]interface{}{
+				"name": "testacc@snmpclientlist",
+			},
+		},
+		{
+			resource: "junos_snmp_community",
+			name:     "basic",
+			preset:   []string{"set routing-instances testacc_snmpcom instance-type virtual-router"},
+			config: map[string]interface{}{
+				"name":                    "testacc_snmpcom@public",
+				"authorization_read_only": true,
+				"client_list_name":        "testacc_snmpcom",
+				"routing_instance": []interface{}{
+					map[string]interface{}{
+						"name": "testacc_snmpcom",
+					},
+				},
+				"view": "testacc_snmpcom",
+			},
+		},
+		{
+			resource:     "junos_snmp",
+			name:         "basic",
+			keepOnDelete: true,
+			preset:       []string{"set routing-instances testacc_snmp instance-type virtual-router"},
+			config: map[string]interface{}{
+				"arp":                        true,
+				"contact":                    "contact@example.com",
+				"description":                "snmp description",
+				"filter_duplicates":          true,
+				"filter_interfaces":          []interface{}{"(ge|xe|ae).*\\.0", "fxp0"},
+				"filter_internal_interfaces": true,
+				"health_monitor": []interface{}{
+					map[string]interface{}{
+						"falling_threshold":     41,
+						"idp":                   true,
+						"idp_falling_threshold": 42,
+						"idp_interval":          43,
+						"idp_rising_threshold":  44,
+						"interval":              45,
+						"rising_threshold":      46,
+					},
+				},
+				"if_count_with_filter_interfaces": true,
+				"interface":                       []interface{}{"fxp0.0"},
+				"location":                        "Paris, France",
+				"routing_instance_access":         true,
+				"routing_instance_access_list":    []interface{}{"testacc_snmp"},
+			},
+		},
+		{
+			resource: "junos_snmp_view",
+			name:     "basic",
+			config: map[string]interface{}{
+				"name":        "testacc_snmpview",
+				"oid_include": []interface{}{".1"},
+			},
+		},
+		{
+			resource: "junos_static_route",
+			name:     "basic",
+			preset:   []string{"set routing-instances testacc_staticRoute instance-type virtual-router"},
+			config: map[string]interface{}{
+				"destination":      "192.0.2.0/24",
+				"routing_instance": "testacc_staticRoute",
+				"preference":       100,
+				"metric":           100,
+				"next_hop":         []interface{}{"st0.0"},
+				"active":           true,
+				"install":          true,
+				"readvertise":      true,
+				"no_resolve":       true,
+				"retain":           true,
+				"qualified_next_hop": []interface{}{
+					map[string]interface{}{
+						"next_hop":   "st0.0",
+						"preference": 101,
+						"metric":     101,
+					},
+					map[string]interface{}{
+						"next_hop":  "192.0.2.250",
+						"interface": "st0.0",
+					},
+				},
+				"community": []interface{}{"no-advertise"},
+			},
+		},
+		{
+			resource: "junos_static_route",
+			name:     "basic2",
+			preset:   []string{"set routing-instances testacc_staticRoute instance-type virtual-router"},
+			config: map[string]interface{}{
+				"destination": "192.0.2.0/24",
+				"preference":  100,
+				"metric":      100,
+				"next_hop":    []interface{}{"st0.0"},
+				"active":      true,
+				"install":     true,
+				"readvertise": true,
+				"no_resolve":  true,
+				"retain":      true,
+				"qualified_next_hop": []interface{}{
+					map[string]interface{}{
+						"next_hop":   "st0.0",
+						"preference": 101,
+						"metric":     101,
+					},
+				},
+				"community":                    []interface{}{"no-advertise"},
+				"as_path_aggregator_as_number": "65000",
+				"as_path_aggregator_address":   "192.0.2.1",
+				"as_path_atomic_aggregate":     true,
+				"as_path_origin":               "igp",
+				"as_path_path":                 "65000 65000",
+			},
+		},
+		{
+			resource: "junos_static_route",
+			name:     "basic3",
+			preset:   []string{"set routing-instances testacc_staticRoute instance-type virtual-router"},
+			config: map[string]interface{}{
+				"destination": "2001:db8:85a3::/48",
+				"preference":  100,
+				"metric":      100,
+				"next_hop":    []interface{}{"st0.0"},
+				"active":      true,
+				"install":     true,
+				"readvertise": true,
+				"no_resolve":  true,
+				"retain":      true,
+				"qualified_next_hop": []interface{}{
+					map[string]interface{}{
+						"next_hop":   "st0.0",
+						"preference": 101,
+						"metric":     101,
+					},
+				},
+				"community":                    []interface{}{"no-advertise"},
+				"as_path_aggregator_as_number": "65000",
+				"as_path_aggregator_address":   "192.0.2.1",
+				"as_path_atomic_aggregate":     true,
+				"as_path_origin":               "igp",
+				"as_path_path":                 "65000 65000",
+			},
+		},
+		{
+			resource: "junos_static_route",
+			name:     "basic4",
+			preset:   []string{"set routing-instances testacc_staticRoute instance-type virtual-router"},
+			config: map[string]interface{}{
+				"destination":      "2001:db8:85a3::/48",
+				"routing_instance": "testacc_staticRoute",
+				"preference":       100,
+				"metric":           100,
+				"next_hop":         []interface{}{"st0.0"},
+				"active":           true,
+				"install":          true,
+				"readvertise":      true,
+				"no_resolve":       true,
+				"retain":           true,
+				"qualified_next_hop": []interface{}{
+					map[string]interface{}{
+						"next_hop":   "st0.0",
+						"preference": 101,
+						"metric":     101,
+					},
+					map[string]interface{}{
+						"next_hop":  "2001:db8:85a4::1",
+						"interface": "st0.0",
+					},
+				},
+				"community": []interface{}{"no-advertise"},
+			},
+		},
+		{
+			resource: "junos_system_login_class",
+			name:     "basic",
+			config: map[string]interface{}{
+				"name":                      "testacc",
+				"access_start":              "08:00:00",
+				"access_end":                "18:00:00",
+				"allow_commands":            ".*",
+				"allow_configuration":       ".*",
+				"allow_hidden_commands":     true,
+				"allowed_days":              []interface{}{"sunday", "monday"},
+				"cli_prompt":                "prompt cli",
+				"configuration_breadcrumbs": true,
+				"confirm_commands":          []interface{}{"confirm commands"},
+				"deny_commands":             "request",
+				"deny_configuration":        "system",
+				"idle_timeout":              120,
+				"login_alarms":              true,
+				"login_tip":                 true,
+				"permissions":               []interface{}{"view", "floppy"},
+				"security_role":             "security-administrator",
+			},
+		},
+		{
+			resource: "junos_system_login_user",
+			name:     "basic",
+			config: map[string]interface{}{
+				"name":       "testacc",
+				"class":      "unauthorized",
+				"cli_prompt": "test cli",
+				"full_name":  "test name",
+				"authentication": []interface{}{
+					map[string]interface{}{
+						"encrypted_password": "test",
+						"no_public_keys":     true,
+					},
+				},
+			},
+		},
+		{
+			resource: "junos_system_ntp_server",
+			name:     "basic",
+			config: map[string]interface{}{
+				"address": "192.0.2.1",
+				"prefer":  true,
+				"version": 4,
+				"key":     1,
+			},
+		},
+		{
+			resource: "junos_system_radius_server",
+			name:     "basic",
+			ignore:   []string{"accouting_timeout"},
+			config: map[string]interface{}{
+				"address": "192.0.2.1",
+				"secret":  "password",
+			},
+		},
+		{
+			resource: "junos_system_syslog_file",
+			name:     "basic",
+			config: map[string]interface{}{
+				"filename":                     "testacc",
+				"allow_duplicates":             true,
+				"explicit_priority":            true,
+				"match":                        "match testacc",
+				"match_strings":                []interface{}{"match testacc"},
+				"any_severity":                 "emergency",
+				"changelog_severity":           "critical",
+				"conflictlog_severity":         "error",
+				"daemon_severity":              "warning",
+				"dfc_severity":                 "alert",
+				"external_severity":            "any",
+				"firewall_severity":            "info",
+				"ftp_severity":                 "none",
+				"interactivecommands_severity": "notice",
+				"kernel_severity":              "emergency",
+				"ntp_severity":                 "emergency",
+				"pfe_severity":                 "emergency",
+				"security_severity":            "emergency",
+				"user_severity":                "emergency",
+			},
+		},
+		{
+			resource: "junos_system_syslog_host",
+			name:     "basic",
+			config: map[string]interface{}{
+				"host": "192.0.2.1",
+				"port": 514,
+			},
+		},
+		{
+			resource:     "junos_system",
+			name:         "basic",
+			keepOnDelete: true,
+			config: map[string]interface{}{
+				"host_name":                 "testacc-terraform",
+				"authentication_order":      []interface{}{"password"},
+				"auto_snapshot":             true,
+				"default_address_selection": true,
+				"domain_name":               "domain.local",
+				"inet6_backup_router": []interface{}{
+					map[string]interface{}{
+						"destination": []interface{}{"::/0"},
+						"address":     "fe80::1",
+					},
+				},
+				"internet_options": []interface{}{
+					map[string]interface{}{
+						"gre_path_mtu_discovery": true,
+						"icmpv4_rate_limit": []interface{}{
+							map[string]interface{}{
+								"bucket_size": 10,
+								"packet_rate": 10,
+							},
+						},
+						"icmpv6_rate_limit": []interface{}{
+							map[string]interface{}{
+								"bucket_size": 10,
+								"packet_rate": 10,
+							},
+						},
+						"ipip_path_mtu_discovery":                 true,
+						"ipv6_duplicate_addr_detection_transmits": 10,
+						"ipv6_path_mtu_discovery":                 true,
+						"ipv6_path_mtu_discovery_timeout":         10,
+						"ipv6_reject_zero_hop_limit":              true,
+						"path_mtu_discovery":                      true,
+						"source_port_upper_limit":                 50000,
+						"source_quench":                           true,
+						"tcp_drop_synfin_set":                     true,
+						"tcp_mss":                                 1400,
+					},
+				},
+				"license": []interface{}{
+					map[string]interface{}{
+						"autoupdate":              true,
+						"autoupdate_password":     "some_password",
+						"autoupdate_url":          "some_url",
+						"renew_interval":          24,
+						"renew_before_expiration": 30,
+					},
+				},
+				"login": []interface{}{
+					map[string]interface{}{
+						"announcement":         "test announce",
+						"deny_sources_address": []interface{}{"127.0.0.1"},
+						"idle_timeout":         60,
+						"message":              "test message",
+						"password": []interface{}{
+							map[string]interface{}{
+								"change_type":               "character-sets",
+								"format":                    "sha512",
+								"maximum_length":            128,
+								"minimum_changes":           1,
+								"minimum_character_changes": 4,
+								"minimum_length":            6,
+								"minimum_lower_cases":       1,
+								"minimum_numerics":          1,
+								"minimum_punctuations":      1,
+								"minimum_reuse":             1,
+								"minimum_upper_cases":       1,
+							},
+						},
+						"retry_options": []interface{}{
+							map[string]interface{}{
+								"backoff_factor":          5,
+								"backoff_threshold":       1,
+								"lockout_period":          1,
+								"maximum_time":            300,
+								"minimum_time":            20,
+								"tries_before_disconnect": 10,
+							},
+						},
+					},
+				},
+				"max_configuration_rollbacks": 49,
+				"max_configurations_on_flash": 49,
+				"name_server":                 []interface{}{"192.0.2.10", "192.0.2.11"},
+				"no_multicast_echo":           true,
+				"no_ping_record_route":        true,
+				"no_ping_time_stamp":          true,
+				"no_redirects":                true,
+				"no_redirects_ipv6":           true,
+				"ntp": []interface{}{
+					map[string]interface{}{
+						"authentication_key": []interface{}{
+							map[string]interface{}{
+								"id":    1,
+								"type":  "md5",
+								"value": "ntp secret",
+							},
+						},
+						"trusted_key": []interface{}{1},
+					},
+				},
+				"services": []interface{}{
+					map[string]interface{}{
+						"ssh": []interface{}{
+							map[string]interface{}{
+								"authentication_order":           []interface{}{"password"},
+								"ciphers":                        []interface{}{"aes256-ctr", "aes256-cbc"},
+								"client_alive_count_max":         10,
+								"client_alive_interval":          30,
+								"connection_limit":               10,
+								"fingerprint_hash":               "md5",
+								"hostkey_algorithm":              []interface{}{"no-ssh-dss"},
+								"key_exchange":                   []interface{}{"ecdh-sha2-nistp256"},
+								"macs":                           []interface{}{"hmac-sha2-256"},
+								"max_pre_authentication_packets": 10000,
+								"max_sessions_per_connection":    100,
+								"port":                           22,
+								"protocol_version":               []interface{}{"v2"},
+								"rate_limit":                     200,
+								"root_login":                     "deny",
+								"tcp_forwarding":                 true,
+							},
+						},
+						"web_management_http": []interface{}{
+							map[string]interface{}{
+								"interface": []interface{}{"fxp0.0"},
+								"port":      80,
+							},
+						},
+						"web_management_https": []interface{}{
+							map[string]interface{}{
+								"interface":                    []interface{}{"fxp0.0"},
+								"system_generated_certificate": true,
+								"port":                         443,
+							},
+						},
+					},
+				},
+				"syslog": []interface{}{
+					map[string]interface{}{
+						"archive": []interface{}{
+							map[string]interface{}{
+								"binary_data":       true,
+								"files":             5,
+								"size":              10000000,
+								"no_world_readable": true,
+							},
+						},
+						"log_rotate_frequency": 30,
+						"source_address":       "192.0.2.1",
+					},
+				},
+				"time_zone":                         "Europe/Paris",
+				"tracing_dest_override_syslog_host": "192.0.2.50",
+			},
+		},
+		{
+			resource: "junos_vlan",
+			name:     "basic",
+			config: map[string]interface{}{
+				"name":                  "testacc_vlansw",
+				"description":           "testacc_vlansw",
+				"vlan_id":               1000,
+				"service_id":            1000,
+				"l3_interface":          "irb.1000",
+				"forward_filter_input":  "testacc_vlansw",
+				"forward_filter_output": "testacc_vlansw",
+				"forward_flood_input":   "testacc_vlansw",
+			},
+		},
+		{
+			resource: "junos_bgp_neighbor",
+			name:     "basic",
+			preset: []string{
+				"set routing-instances testacc_bgpneighbor instance-type virtual-router",
+				"set routing-instances testacc_bgpneighbor protocols bgp group testacc_bgpneighbor type external",
+			},
+			config: map[string]interface{}{
+				"ip":                 "192.0.2.4",
+				"routing_instance":   "testacc_bgpneighbor",
+				"group":              "testacc_bgpneighbor",
+				"advertise_inactive": true,
+				"advertise_peer_as":  true,
+				"as_override":        true,
+				"bgp_multipath": []interface{}{
+					map[string]interface{}{},
+				},
+				"cluster":                  "192.0.2.3",
+				"damping":                  true,
+				"log_updown":               true,
+				"mtu_discovery":            true,
+				"remove_private":           true,
+				"passive":                  true,
+				"hold_time":                30,
+				"keep_all":                 true,
+				"local_as":                 "65001",
+				"local_as_private":         true,
+				"local_as_loops":           1,
+				"local_preference":         100,
+				"metric_out":               100,
+				"out_delay":                30,
+				"peer_as":                  "65002",
+				"preference":               100,
+				"authentication_algorithm": "md5",
+				"local_address":            "192.0.2.3",
+				"export":                   []interface{}{"testacc_bgpneighbor"},
+				"import":                   []interface{}{"testacc_bgpneighbor"},
+				"bfd_liveness_detection": []interface{}{
+					map[string]interface{}{
+						"detection_time_threshold":           60,
+						"transmit_interval_threshold":        30,
+						"transmit_interval_minimum_interval": 10,
+						"holddown_interval":                  10,
+						"minimum_interval":                   10,
+						"minimum_receive_interval":           10,
+						"multiplier":                         2,
+						"session_mode":                       "automatic",
+					},
+				},
+				"family_inet": []interface{}{
+					map[string]interface{}{
+						"nlri_type": "unicast",
+						"accepted_prefix_limit": []interface{}{
+							map[string]interface{}{
+								"maximum":               2,
+								"teardown":              50,
+								"teardown_idle_timeout": 30,
+							},
+						},
+						"prefix_limit": []interface{}{
+							map[string]interface{}{
+								"maximum":               2,
+								"teardown":              50,
+								"teardown_idle_timeout": 30,
+							},
+						},
+					},
+					map[string]interface{}{
+						"nlri_type": "multicast",
+						"accepted_prefix_limit": []interface{}{
+							map[string]interface{}{
+								"maximum":                       2,
+								"teardown_idle_timeout_forever": true,
+							},
+						},
+						"prefix_limit": []interface{}{
+							map[string]interface{}{
+								"maximum":                       2,
+								"teardown_idle_timeout_forever": true,
+							},
+						},
+					},
+				},
+				"family_inet6": []interface{}{
+					map[string]interface{}{
+						"nlri_type": "unicast",
+						"accepted_prefix_limit": []interface{}{
+							map[string]interface{}{
+								"maximum":               2,
+								"teardown":              50,
+								"teardown_idle_timeout": 30,
+							},
+						},
+						"prefix_limit": []interface{}{
+							map[string]interface{}{
+								"maximum":               2,
+								"teardown":              50,
+								"teardown_idle_timeout": 30,
+							},
+						},
+					},
+					map[string]interface{}{
+						"nlri_type": "multicast",
+					},
+				},
+				"graceful_restart": []interface{}{
+					map[string]interface{}{
+						"disable": true,
+					},
+				},
+			},
+		},
+		{
+			resource: "junos_interface_physical",
+			name:     "basic",
+			config: map[string]interface{}{
+				"name":        "` + interFace2 + `",
+				"description": "testacc_cluster_int2",
+				"gigether_opts": []interface{}{
+					map[string]interface{}{
+						"redundant_parent": "reth0",
+					},
+				},
+			},
+		},
+		{
+			resource: "junos_policyoptions_as_path",
+			name:     "basic",
+			config: map[string]interface{}{
+				"name": "testacc_policyOptions",
+				"path": "5|12|18",
+			},
+		},
+		{
+			resource: "junos_policyoptions_as_path_group",
+			name:     "basic",
+			config: map[string]interface{}{
+				"name": "testacc_policyOptions",
+				"as_path": []interface{}{
+					map[string]interface{}{
+						"name": "testacc_policyOptions",
+						"path": "5|12|18",
+					},
+				},
+			},
+		},
+		{
+			resource: "junos_policyoptions_community",
+			name:     "basic",
+			config: map[string]interface{}{
+				"name":    "testacc_policyOptions",
+				"members": []interface{}{"65000:100"},
+			},
+		},
+		{
+			resource: "junos_policyoptions_policy_statement",
+			name:     "basic",
+			config: map[string]interface{}{
+				"name": "testacc_aggregateRoute",
+				"then": []interface{}{
+					map[string]interface{}{
+						"action": "accept",
+					},
+				},
+			},
+		},
+		{
+			resource: "junos_policyoptions_prefix_list",
+			name:     "basic",
+			config: map[string]interface{}{
+				"name":   "testacc_fwFilter",
+				"prefix": []interface{}{"192.0.2.0/25"},
+			},
+		},
+		{
+			resource: "junos_security_ike_gateway",
+			name:     "basic",
+			config: map[string]interface{}{
+				"name":               "testacc_ikegateway",
+				"address":            []interface{}{"192.0.2.3"},
+				"policy":             "testacc_ikepol",
+				"external_interface": "` + interFace + `.0",
+				"general_ike_id":     true,
+				"no_nat_traversal":   true,
+				"dead_peer_detection": []interface{}{
+					map[string]interface{}{
+						"interval":  10,
+						"threshold": 3,
+						"send_mode": "always-send",
+					},
+				},
+				"local_address": "192.0.2.4",
+				"local_identity": []interface{}{
+					map[string]interface{}{
+						"type":  "hostname",
+						"value": "testacc",
+					},
+				},
+				"version": "v2-only",
+			},
+		},
+		{
+			resource: "junos_security_ike_policy",
+			name:     "basic",
+			config: map[string]interface{}{
+				"name":                "testacc_ikepol",
+				"proposals":           []interface{}{"testacc_ikeprop"},
+				"mode":                "main",
+				"pre_shared_key_text": "thePassWord",
+			},
+		},
+		{
+			resource: "junos_security_ike_proposal",
+			name:     "basic",
+			config: map[string]interface{}{
+				"name":                     "testacc_ikeprop",
+				"authentication_algorithm": "sha1",
+				"encryption_algorithm":     "aes-256-cbc",
+				"dh_group":                 "group2",
+				"lifetime_seconds":         3600,
+			},
+		},
+		{
+			resource: "junos_security_ipsec_policy",
+			name:     "basic",
+			config: map[string]interface{}{
+				"name":      "testacc_ipsecpol",
+				"proposals": []interface{}{"testacc_ipsecprop"},
+				"pfs_keys":  "group2",
+			},
+		},
+		{
+			resource: "junos_security_ipsec_proposal",
+			name:     "basic",
+			config: map[string]interface{}{
+				"name":                     "testacc_ipsecprop",
+				"authentication_algorithm": "hmac-sha1-96",
+				"protocol":                 "esp",
+				"encryption_algorithm":     "aes-128-cbc",
+			},
+		},
+		{
+			resource:    "junos_security_ipsec_vpn",
+			name:        "basic",
+			afterDelete: []string{"set interfaces st0 unit 0"},
+			// source-interface is set with bind-interface when source_interface_auto
+			ignore: []string{"vpn_monitor.0.source_interface", "vpn_monitor.0.source_interface_auto"},
+			config: map[string]interface{}{
+				"name":           "testacc_ipsecvpn",
+				"bind_interface": "st0.0",
+				"ike": []interface{}{
+					map[string]interface{}{
+						"gateway":          "testacc_ikegateway",
+						"policy":           "testacc_ipsecpol",
+						"identity_local":   "192.0.2.64/26",
+						"identity_remote":  "192.0.2.128/26",
+						"identity_service": "any",
+					},
+				},
+				"vpn_monitor": []interface{}{
+					map[string]interface{}{
+						"destination_ip":        "192.0.2.129",
+						"optimized":             true,
+						"source_interface_auto": true,
+					},
+				},
+				"establish_tunnels": "on-traffic",
+				"df_bit":            "clear",
+			},
+		},
+		{
+			resource: "junos_security_log_stream",
+			name:     "basic",
+			config: map[string]interface{}{
+				"name":     "testacc_logstream",
+				"category": []interface{}{"idp"},
+				"format":   "syslog",
+				"host": []interface{}{
+					map[string]interface{}{
+						"ip_address":       "192.0.2.1",
+						"port":             514,
+						"routing_instance": "testacclogstream",
+					},
+				},
+				"rate_limit": 50,
+				"severity":   "error",
+			},
+		},
+		{
+			resource: "junos_security_nat_destination_pool",
+			name:     "basic",
+			config: map[string]interface{}{
+				"name":             "testacc_securityDNATPool",
+				"address":          "192.0.2.1/32",
+				"address_to":       "192.0.2.2/32",
+				"routing_instance": "testacc_securityDNAT",
+			},
+		},
+		{
+			resource: "junos_security_nat_source_pool",
+			name:     "basic",
+			config: map[string]interface{}{
+				"name":                                   "testacc_securitySNATPool",
+				"address":                                []interface{}{"192.0.2.1/32", "192.0.2.64/27"},
+				"routing_instance":                       "testacc_securitySNAT",
+				"address_pooling":                        "paired",
+				"port_no_translation":                    true,
+				"pool_utilization_alarm_raise_threshold": 80,
+				"pool_utilization_alarm_clear_threshold": 60,
+			},
+		},
+		{
+			resource: "junos_security_policy_tunnel_pair_policy",
+			name:     "basic",
+			preset: []string{
+				"set security policies from-zone testacc_secIkeIPsec_local to-zone testacc_secIkeIPsec_remote policy testacc_vpn-out then permit",
+				"set security policies from-zone testacc_secIkeIPsec_remote to-zone testacc_secIkeIPsec_local policy testacc_vpn-in then permit",
+			},
+			config: map[string]interface{}{
+				"zone_a":        "testacc_secIkeIPsec_local",
+				"zone_b":        "testacc_secIkeIPsec_remote",
+				"policy_a_to_b": "testacc_vpn-out",
+				"policy_b_to_a": "testacc_vpn-in",
+			},
+		},
+		{
+			resource: "junos_security_screen_whitelist",
+			name:     "basic",
+			config: map[string]interface{}{
+				"name":    "testacc1",
+				"address": []interface{}{"192.0.2.128/26", "192.0.2.64/26"},
+			},
+		},
+		{
+			resource: "junos_services_advanced_anti_malware_policy",
+			name:     "basic",
+			config: map[string]interface{}{
+				"name":                     "testacc_secglobpolicy",
+				"verdict_threshold":        "recommended",
+				"default_notification_log": true,
+			},
+		},
+		{
+			resource: "junos_services_proxy_profile",
+			name:     "basic",
+			config: map[string]interface{}{
+				"name":               "testacc_services",
+				"protocol_http_host": "192.0.2.1",
+				"protocol_http_port": 3128,
+			},
+		},
+		{
+			resource: "junos_services_ssl_initiation_profile",
+			name:     "basic",
+			config: map[string]interface{}{
+				"name": "testacc_sslInitProf.1",
+				"actions": []interface{}{
+					map[string]interface{}{
+						"crl_disable":                      true,
+						"crl_if_not_present":               "allow",
+						"crl_ignore_hold_instruction_code": true,
+						"ignore_server_auth_failure":       true,
+					},
+				},
+				"custom_ciphers":       []interface{}{"rsa-with-aes-128-gcm-sha256"},
+				"enable_flow_tracing":  true,
+				"enable_session_cache": true,
+				"preferred_ciphers":    "medium",
+				"protocol_version":     "all",
+				"trusted_ca":           []interface{}{"all"},
+			},
+		},
+		{
+			resource: "junos_services_user_identification_ad_access_domain",
+			name:     "basic",
+			config: map[string]interface{}{
+				"name":          "testacc_userID_addomain.local",
+				"user_name":     "user_dom",
+				"user_password": "user_pass",
+				"domain_controller": []interface{}{
+					map[string]interface{}{
+						"name":    "server1",
+						"address": "192.0.2.3",
+					},
+				},
+				"ip_user_mapping_discovery_wmi": []interface{}{
+					map[string]interface{}{},
+				},
+				"user_group_mapping_ldap": []interface{}{
+					map[string]interface{}{
+						"base": "CN=xxx",
+					},
+				},
+			},
+		},
+		{
+			resource: "junos_services_user_identification_device_identity_profile",
+			name:     "basic",
+			config: map[string]interface{}{
+				"name":   "testacc_secglobpolicy",
+				"domain": "testacc_secglobpolicy",
+				"attribute": []interface{}{
+					map[string]interface{}{
+						"name":  "device-identity",
+						"value": []interface{}{"testacc_secglobpolicy"},
+					},
+				},
+			},
+		},
+		{
+			resource:     "junos_system_root_authentication",
+			name:         "basic",
+			keepOnDelete: true,
+			config: map[string]interface{}{
+				"encrypted_password": "$6$XXXX",
+				"ssh_public_keys":    []interface{}{"ssh-rsa XXXX"},
+			},
+		},
+	}
+}
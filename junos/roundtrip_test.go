@@ -0,0 +1,181 @@
+package junos
+
+import (
+	"context"
+	"flag"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// updateGolden : run 'go test ./junos -run TestRoundTrip -update' to rewrite golden files with the generated set lines.
+var updateGolden = flag.Bool("update", false, "update golden files of round-trip tests") // nolint: gochecknoglobals
+
+const roundTripGoldenDir = "testdata/golden"
+
+// roundTripCase : a configuration of resource to create on a fake device.
+// The set lines committed by the resource are compared with the golden file
+// testdata/golden/<resource>_<name>.set and the state read back from device
+// must be equal to the state of configuration.
+type roundTripCase struct {
+	resource string
+	name     string
+	// preset : set lines already in configuration before create (dependencies of resource).
+	preset []string
+	config map[string]interface{}
+	// ignore : attributes not compared after read (computed or modified by device).
+	ignore []string
+	// keepOnDelete : delete of resource only remove it from state.
+	keepOnDelete bool
+	// afterDelete : set lines added by delete of resource (like disable of physical interface).
+	afterDelete []string
+}
+
+func TestRoundTrip(t *testing.T) {
+	fake, err := StartFakeDevice("vsrx", "20.2R1.10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fake.Close()
+	sess := newFakeDeviceSession(t, fake)
+	resources := Provider().ResourcesMap
+	for _, c := range roundTripCases() {
+		c := c
+		t.Run(c.resource+"_"+c.name, func(t *testing.T) {
+			r, ok := resources[c.resource]
+			if !ok {
+				t.Fatalf("resource %s not in provider", c.resource)
+			}
+			testRoundTrip(t, fake, sess, r, c)
+		})
+	}
+}
+
+func testRoundTrip(t *testing.T, fake *FakeDevice, sess *Session, r *schema.Resource, c roundTripCase) {
+	ctx := context.Background()
+	fake.SetCommitted(c.preset)
+	d := schema.TestResourceDataRaw(t, r.Schema, c.config)
+	if diags := r.CreateContext(ctx, d, sess); diags.HasError() {
+		t.Fatalf("create error = %v", diags)
+	}
+	if d.Id() == "" {
+		t.Fatal("create without id")
+	}
+	lines := make([]string, 0)
+	for _, line := range fake.Committed() {
+		if !stringInSlice(line, c.preset) {
+			lines = append(lines, line)
+		}
+	}
+	compareRoundTripGolden(t, path.Join(roundTripGoldenDir, c.resource+"_"+c.name+".set"), lines)
+
+	want := roundTripAttributes(t, r, d.Id(), c)
+	dRead := r.Data(d.State())
+	if diags := r.ReadContext(ctx, dRead, sess); diags.HasError() {
+		t.Fatalf("read error = %v", diags)
+	}
+	if dRead.Id() == "" {
+		t.Fatal("resource not found after create")
+	}
+	compareRoundTripAttributes(t, "read", roundTripFilter(dRead.State().Attributes, c.ignore), want)
+
+	if r.Importer != nil && r.Importer.State != nil {
+		dImport := r.Data(nil)
+		dImport.SetId(d.Id())
+		imported, err := r.Importer.State(dImport, sess)
+		if err != nil {
+			t.Fatalf("import error = %v", err)
+		}
+		if len(imported) != 1 {
+			t.Fatalf("import return %d resources", len(imported))
+		}
+		compareRoundTripAttributes(t, "import", roundTripFilter(imported[0].State().Attributes, c.ignore), want)
+	}
+
+	if diags := r.DeleteContext(ctx, d, sess); diags.HasError() {
+		t.Fatalf("delete error = %v", diags)
+	}
+	if c.keepOnDelete {
+		return
+	}
+	for _, line := range fake.Committed() {
+		if !stringInSlice(line, c.preset) && !stringInSlice(line, c.afterDelete) {
+			t.Errorf("line '%s' not removed by delete", line)
+		}
+	}
+}
+
+// roundTripAttributes generate the attributes expected in state from the configuration.
+func roundTripAttributes(t *testing.T, r *schema.Resource, id string, c roundTripCase) map[string]string {
+	t.Helper()
+	d := schema.TestResourceDataRaw(t, r.Schema, c.config)
+	d.SetId(id)
+
+	return roundTripFilter(d.State().Attributes, c.ignore)
+}
+
+// roundTripFilter remove the ignored attributes and the empty values
+// (an empty optional value is not in state of configuration but can be set after read).
+func roundTripFilter(attributes map[string]string, ignore []string) map[string]string {
+	filtered := make(map[string]string)
+	for k, v := range attributes {
+		if v == "" || v == "0" || v == "false" {
+			continue
+		}
+		ignored := false
+		for _, i := range ignore {
+			if k == i || strings.HasPrefix(k, i+".") {
+				ignored = true
+
+				break
+			}
+		}
+		if !ignored {
+			filtered[k] = v
+		}
+	}
+
+	return filtered
+}
+
+func compareRoundTripAttributes(t *testing.T, step string, got, want map[string]string) {
+	t.Helper()
+	keys := make([]string, 0)
+	for k := range want {
+		keys = append(keys, k)
+	}
+	for k := range got {
+		if _, ok := want[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if got[k] != want[k] {
+			t.Errorf("%s: attribute %s = %q, want %q", step, k, got[k], want[k])
+		}
+	}
+}
+
+func compareRoundTripGolden(t *testing.T, goldenFile string, lines []string) {
+	t.Helper()
+	generated := strings.Join(lines, "\n") + "\n"
+	if *updateGolden {
+		if err := ioutil.WriteFile(goldenFile, []byte(generated), 0644); err != nil { // nolint: gosec
+			t.Fatal(err)
+		}
+
+		return
+	}
+	golden, err := ioutil.ReadFile(goldenFile)
+	if err != nil {
+		t.Fatalf("failed to read golden file (run with -update to create it) : %v", err)
+	}
+	if string(golden) != generated {
+		t.Errorf("set lines differ from golden file %s\ngot:\n%swant:\n%s", goldenFile, generated, golden)
+	}
+}
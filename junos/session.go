@@ -6,6 +6,7 @@ import (
 	"os"
 	"path"
 	"strconv"
+	"sync"
 	"time"
 )
 
@@ -13,23 +14,60 @@ const directoryPermission = 0755
 
 // Session information to connect on Junos Device and more.
 type Session struct {
-	junosPort              int
-	junosSleepLock         int
-	junosSleepShort        int
-	junosSleepSSHClosed    int
-	junosFilePermission    int64
-	junosIP                string
-	junosUserName          string
-	junosPassword          string
-	junosSSHKeyPEM         string
-	junosSSHKeyFile        string
-	junosKeyPass           string
-	junosGroupIntDel       string
-	junosLogFile           string
-	junosFakeCreateSetFile string
+	junosPort                int
+	junosSleepLock           int
+	junosSleepShort          int
+	junosSleepSSHClosed      int
+	junosFilePermission      int64
+	junosIP                  string
+	junosUserName            string
+	junosPassword            string
+	junosSSHKeyPEM           string
+	junosSSHKeyFile          string
+	junosKeyPass             string
+	junosGroupIntDel         string
+	junosLogFile             string
+	junosFakeCreateSetFile   string
+	junosSchemaCacheDir      string
+	junosTransport           string
+	junosOutboundSSHDeviceID string
+	junosOutboundSSHSecret   string
+	junosOutboundSSHListener *outboundSSHListener
+	junosTLSCAFile           string
+	junosTLSCertFile         string
+	junosTLSKeyFile          string
+	junosSysInfo             *sysInfoCache
+	junosSchemaCache         *configSchemaCache
+	junosReadStatements      *readStatements
+	junosConfigSetRecord     *[]string
+	junosOwnershipMarker     bool
+	junosOwnershipWorkspace  string
+	junosOwnershipImport     string
+	junosOwnershipWrite      *ownershipWrite
+	junosStrict              bool
+	junosCommandPrefixes     []string
+	junosCommitLogSuffix     string
+	junosConfigFile          *NetconfObject
+}
+
+// sysInfoCache : system information read on first session to avoid a new session for each check.
+type sysInfoCache struct {
+	mutex sync.Mutex
+	info  *sysInfo
 }
 
 func (sess *Session) startNewSession() (*NetconfObject, error) {
+	switch sess.junosTransport {
+	case transportREST:
+		return sess.startNewRESTSession()
+	case transportGNMI:
+		return sess.startNewGNMISession()
+	case transportConfigFile:
+		jnpr := *sess.junosConfigFile
+		sess.cacheSystemInformation(&jnpr)
+
+		return &jnpr, nil
+	}
 	var auth netconfAuthMethod
 	auth.Username = sess.junosUserName
 	if sess.junosSSHKeyPEM != "" {
@@ -47,7 +85,14 @@ func (sess *Session) startNewSession() (*NetconfObject, error) {
 	if sess.junosPassword != "" {
 		auth.Password = sess.junosPassword
 	}
-	jnpr, err := netconfNewSession(sess.junosIP+":"+strconv.Itoa(sess.junosPort), &auth)
+	var jnpr *NetconfObject
+	var err error
+	if sess.junosOutboundSSHListener != nil {
+		jnpr, err = netconfNewSessionOutboundSSH(sess.junosOutboundSSHListener,
+			sess.junosOutboundSSHDeviceID, sess.junosOutboundSSHSecret, &auth)
+	} else {
+		jnpr, err = netconfNewSession(sess.junosIP+":"+strconv.Itoa(sess.junosPort), &auth)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -55,10 +100,83 @@ func (sess *Session) startNewSession() (*NetconfObject, error) {
 		return jnpr, fmt.Errorf("can't read model of device with <get-system-information/> netconf command")
 	}
 	sess.logFile("[startNewSession] started")
+	sess.cacheSystemInformation(jnpr)
+
+	return jnpr, nil
+}
+
+func (sess *Session) startNewRESTSession() (*NetconfObject, error) {
+	auth := restAuthMethod{
+		Username: sess.junosUserName,
+		Password: sess.junosPassword,
+		CAFile:   sess.junosTLSCAFile,
+		CertFile: sess.junosTLSCertFile,
+		KeyFile:  sess.junosTLSKeyFile,
+	}
+	jnpr, err := restNewSession(sess.junosIP+":"+strconv.Itoa(sess.junosPort), &auth)
+	if err != nil {
+		return nil, err
+	}
+	if jnpr.SystemInformation.HardwareModel == "" {
+		return jnpr, fmt.Errorf("can't read model of device with <get-system-information/> rest command")
+	}
+	sess.logFile("[startNewRESTSession] started")
+	sess.cacheSystemInformation(jnpr)
+
+	return jnpr, nil
+}
+
+func (sess *Session) startNewGNMISession() (*NetconfObject, error) {
+	auth := gnmiAuthMethod{
+		Username: sess.junosUserName,
+		Password: sess.junosPassword,
+		CAFile:   sess.junosTLSCAFile,
+		CertFile: sess.junosTLSCertFile,
+		KeyFile:  sess.junosTLSKeyFile,
+	}
+	jnpr, err := gnmiNewSession(sess.junosIP+":"+strconv.Itoa(sess.junosPort), &auth)
+	if err != nil {
+		return nil, err
+	}
+	if jnpr.SystemInformation.HardwareModel == "" {
+		return jnpr, fmt.Errorf("can't read model of device with description of Chassis component in gnmi")
+	}
+	sess.logFile("[startNewGNMISession] started")
+	sess.cacheSystemInformation(jnpr)
 
 	return jnpr, nil
 }
 
+func (sess *Session) cacheSystemInformation(jnpr *NetconfObject) {
+	if sess.junosSysInfo != nil {
+		sess.junosSysInfo.mutex.Lock()
+		if sess.junosSysInfo.info == nil {
+			info := jnpr.SystemInformation
+			sess.junosSysInfo.info = &info
+		}
+		sess.junosSysInfo.mutex.Unlock()
+	}
+}
+
+// systemInformation return information of device read on a previous session or start a new session to read it.
+func (sess *Session) systemInformation() (sysInfo, error) {
+	if sess.junosSysInfo != nil {
+		sess.junosSysInfo.mutex.Lock()
+		info := sess.junosSysInfo.info
+		sess.junosSysInfo.mutex.Unlock()
+		if info != nil {
+			return *info, nil
+		}
+	}
+	jnpr, err := sess.startNewSession()
+	if err != nil {
+		return sysInfo{}, err
+	}
+	defer sess.closeSession(jnpr)
+
+	return jnpr.SystemInformation, nil
+}
+
 func (sess *Session) closeSession(jnpr *NetconfObject) {
 	err := jnpr.close(sess.junosSleepSSHClosed)
 	if err != nil {
@@ -78,6 +196,12 @@ func (sess *Session) command(cmd string, jnpr *NetconfObject) (string, error) {
 
 		return "", err
 	}
+	if sess.junosReadStatements != nil {
+		sess.junosReadStatements.record(cmd, read)
+	}
+	if sess.junosOwnershipWrite != nil {
+		sess.junosOwnershipWrite.recordCommand(cmd)
+	}
 
 	return read, nil
 }
@@ -97,6 +221,14 @@ func (sess *Session) commandXML(cmd string, jnpr *NetconfObject) (string, error)
 }
 
 func (sess *Session) configSet(cmd []string, jnpr *NetconfObject) error {
+	if sess.junosOwnershipWrite != nil {
+		sess.junosOwnershipWrite.recordLines(cmd)
+	}
+	if sess.junosConfigSetRecord != nil {
+		*sess.junosConfigSetRecord = append(*sess.junosConfigSetRecord, cmd...)
+
+		return nil
+	}
 	if jnpr != nil {
 		message, err := jnpr.netconfConfigSet(cmd)
 		sleepShort(sess.junosSleepShort)
@@ -139,6 +271,19 @@ func (sess *Session) appendFakeCreateSetFile(lines []string) error {
 }
 
 func (sess *Session) commitConf(logMessage string, jnpr *NetconfObject) (_warnings []error, _err error) {
+	if sess.junosOwnershipWrite != nil && jnpr != nil {
+		if lines := sess.ownershipMarkerLines(); len(lines) > 0 {
+			sessMarker := *sess
+			sessMarker.junosOwnershipWrite = nil
+			if err := sessMarker.configSet(lines, jnpr); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if sess.junosCommitLogSuffix != "" {
+		// identify the commits of a run (like a CI job) in commit history
+		logMessage += " " + sess.junosCommitLogSuffix
+	}
 	sess.logFile(fmt.Sprintf("[commitConf] commit %q", logMessage))
 	warns, err := jnpr.netconfCommit(logMessage)
 	sleepShort(sess.junosSleepShort)
@@ -0,0 +1,117 @@
+package junos
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+const transportConfigFile = "config_file"
+
+// configFileTransport : read-only transport on a configuration of device in 'display set' format
+// (like a dump of 'show configuration | display set'), used to read objects without device.
+type configFileTransport struct {
+	lines []string
+}
+
+// readConfigFileLines read the 'set', 'deactivate' and 'protect' lines of a configuration in 'display set' format
+// and the version of Junos in 'set version' line.
+func readConfigFileLines(config io.Reader) (lines []string, version string, _ error) {
+	scanner := bufio.NewScanner(config)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "set version "):
+			version = strings.Trim(strings.TrimPrefix(line, "set version "), "\"")
+			lines = append(lines, line)
+		case strings.HasPrefix(line, setLineStart),
+			strings.HasPrefix(line, deactivateLineStart),
+			strings.HasPrefix(line, protectLineStart):
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, "", fmt.Errorf("failed to read configuration : %w", err)
+	}
+
+	return lines, version, nil
+}
+
+// configFileNewSession open a session on lines of configuration with the model and version of device.
+func configFileNewSession(lines []string, model, version string) *NetconfObject {
+	return &NetconfObject{
+		SystemInformation: sysInfo{
+			HardwareModel: model,
+			OsName:        "junos",
+			OsVersion:     version,
+		},
+		transport: &configFileTransport{lines: lines},
+	}
+}
+
+func (t *configFileTransport) command(cmd string) (string, error) {
+	filter, err := parseShowConfigurationCommand(cmd)
+	if err != nil {
+		return "", err
+	}
+	// short name of logical interface like the device (interfaces ge-0/0/3.100 => interfaces ge-0/0/3 unit 100)
+	if len(filter.hierarchy) > 1 && filter.hierarchy[0] == "interfaces" && strings.Contains(filter.hierarchy[1], ".") {
+		nameSplit := strings.SplitN(filter.hierarchy[1], ".", 2)
+		filter.hierarchy = append([]string{"interfaces", nameSplit[0], "unit", nameSplit[1]}, filter.hierarchy[2:]...)
+	}
+	output := filter.apply(t.lines)
+	if output == emptyWord {
+		return emptyWord, errors.New("no output available - please check the syntax of your command")
+	}
+
+	// same format as output of command with netconf
+	return "<configuration-output>" + output + "</configuration-output>", nil
+}
+
+// commandXML only answer to get-interface-information with the interfaces in configuration.
+func (t *configFileTransport) commandXML(cmd string) (string, error) {
+	match := regexp.MustCompile(`^<get-interface-information><interface-name>(.+)</interface-name>` +
+		`</get-interface-information>$`).FindStringSubmatch(cmd)
+	if len(match) != 2 {
+		return "", fmt.Errorf("xml command '%s' not supported with a configuration file", cmd)
+	}
+	// a logical interface is only checked with its physical interface
+	physical := strings.Split(match[1], ".")[0]
+	for _, line := range t.lines {
+		words := splitSetLineWords(line)
+		if len(words) > 2 && words[1] == "interfaces" && words[2] == physical {
+			return "<interface-information><physical-interface><name>" + match[1] +
+				"</name></physical-interface></interface-information>", nil
+		}
+	}
+
+	return "\nerror: device " + match[1] + " not found\n", nil
+}
+
+func (t *configFileTransport) configSet(cmd []string) (string, error) {
+	return "", errors.New("configuration file is read-only")
+}
+
+func (t *configFileTransport) configLock() bool {
+	return false
+}
+
+func (t *configFileTransport) configUnlock() []error {
+	return []error{}
+}
+
+func (t *configFileTransport) configClear() []error {
+	return []error{}
+}
+
+func (t *configFileTransport) commit(_ string) ([]error, error) {
+	return []error{}, errors.New("configuration file is read-only")
+}
+
+func (t *configFileTransport) close() error {
+	return nil
+}
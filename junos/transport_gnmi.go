@@ -0,0 +1,546 @@
+package junos
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	gnmiTimeout             = 10 * time.Minute
+	gnmiPathConfiguration   = "configuration"
+	gnmiPathComponents      = "components"
+	gnmiPathSystem          = "system"
+	gnmiChassisComponent    = "Chassis"
+	gnmiJunosOrganization   = "Juniper Networks"
+	gnmiJSONAttributePrefix = "@"
+)
+
+// gnmiTransport : Get and Set the configuration of Junos device with gNMI.
+// Native Junos configuration is read in JSON and converted to set lines, set/delete lines
+// are kept in memory and sent in a single Set request when commit (a Set request is committed by the device).
+type gnmiTransport struct {
+	conn     *grpc.ClientConn
+	client   gnmi.GNMIClient
+	username string
+	password string
+	setLines []string
+}
+
+type gnmiAuthMethod struct {
+	Username string
+	Password string
+	CAFile   string
+	CertFile string
+	KeyFile  string
+}
+
+// gnmiNewSession connect to gNMI service of Junos device and gather facts with Capabilities and Get requests.
+func gnmiNewSession(host string, auth *gnmiAuthMethod) (*NetconfObject, error) {
+	tlsConfig, err := genTLSConfig(auth.CAFile, auth.CertFile, auth.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), gnmiTimeout)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, host, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to %s - %w", host, err)
+	}
+	transport := &gnmiTransport{
+		conn:     conn,
+		client:   gnmi.NewGNMIClient(conn),
+		username: auth.Username,
+		password: auth.Password,
+	}
+	n := &NetconfObject{
+		transport: transport,
+	}
+	if err := transport.gatherFacts(&n.SystemInformation); err != nil {
+		_ = conn.Close()
+
+		return nil, err
+	}
+
+	return n, nil
+}
+
+func (t *gnmiTransport) context() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(context.Background(), gnmiTimeout)
+	if t.username != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "username", t.username, "password", t.password)
+	}
+
+	return ctx, cancel
+}
+
+// gatherFacts read Junos version in models of Capabilities and
+// the hardware model and host name with OpenConfig paths.
+func (t *gnmiTransport) gatherFacts(info *sysInfo) error {
+	ctx, cancel := t.context()
+	defer cancel()
+	capabilities, err := t.client.Capabilities(ctx, &gnmi.CapabilityRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to gnmi capabilities : %w", err)
+	}
+	for _, model := range capabilities.GetSupportedModels() {
+		if !strings.HasPrefix(model.GetOrganization(), gnmiJunosOrganization) {
+			continue
+		}
+		if _, err := parseJunosVersion(model.GetVersion()); err == nil {
+			info.OsName = "junos"
+			info.OsVersion = model.GetVersion()
+
+			break
+		}
+	}
+	if info.OsVersion == "" {
+		return errors.New("can't read Junos version in models of gnmi capabilities")
+	}
+	description, err := t.getString(&gnmi.Path{Elem: []*gnmi.PathElem{
+		{Name: gnmiPathComponents},
+		{Name: "component", Key: map[string]string{"name": gnmiChassisComponent}},
+		{Name: "state"},
+		{Name: "description"},
+	}})
+	if err != nil {
+		return err
+	}
+	info.HardwareModel = strings.ToLower(strings.TrimSpace(description))
+	hostName, err := t.getString(&gnmi.Path{Elem: []*gnmi.PathElem{
+		{Name: gnmiPathSystem},
+		{Name: "state"},
+		{Name: "hostname"},
+	}})
+	if err != nil {
+		return err
+	}
+	info.HostName = hostName
+
+	return nil
+}
+
+// get send a Get request and return the JSON of first update.
+func (t *gnmiTransport) get(path *gnmi.Path, dataType gnmi.GetRequest_DataType) ([]byte, error) {
+	ctx, cancel := t.context()
+	defer cancel()
+	resp, err := t.client.Get(ctx, &gnmi.GetRequest{
+		Path:     []*gnmi.Path{path},
+		Type:     dataType,
+		Encoding: gnmi.Encoding_JSON_IETF,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to gnmi get : %w", err)
+	}
+	for _, notification := range resp.GetNotification() {
+		for _, update := range notification.GetUpdate() {
+			switch {
+			case update.GetVal().GetJsonIetfVal() != nil:
+				return update.GetVal().GetJsonIetfVal(), nil
+			case update.GetVal().GetJsonVal() != nil:
+				return update.GetVal().GetJsonVal(), nil
+			default:
+				return []byte(update.GetVal().GetStringVal()), nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func (t *gnmiTransport) getString(path *gnmi.Path) (string, error) {
+	value, err := t.get(path, gnmi.GetRequest_STATE)
+	if err != nil {
+		return "", err
+	}
+	output := strings.Trim(string(value), "\"")
+	var outputJSON string
+	if json.Unmarshal(value, &outputJSON) == nil {
+		output = outputJSON
+	}
+
+	return output, nil
+}
+
+// command support only 'show configuration ... | display set' with a Get of native configuration.
+func (t *gnmiTransport) command(cmd string) (string, error) {
+	filter, err := parseShowConfigurationCommand(cmd)
+	if err != nil {
+		return "", err
+	}
+	path := &gnmi.Path{Elem: []*gnmi.PathElem{{Name: gnmiPathConfiguration}}}
+	if len(filter.hierarchy) > 0 {
+		// only the first level, the rest of hierarchy can contain keys of lists
+		path.Elem = append(path.Elem, &gnmi.PathElem{Name: filter.hierarchy[0]})
+	}
+	value, err := t.get(path, gnmi.GetRequest_CONFIG)
+	if err != nil {
+		return "", err
+	}
+	lines := make([]string, 0)
+	if len(bytes.TrimSpace(value)) > 0 {
+		prefix := setLineStart
+		if len(filter.hierarchy) > 0 {
+			prefix += filter.hierarchy[0] + " "
+		}
+		lines, err = junosJSONToSetLines(value, prefix)
+		if err != nil {
+			return "", err
+		}
+	}
+	output := filter.apply(lines)
+	if output == emptyWord {
+		return emptyWord, errors.New("no output available - please check the syntax of your command")
+	}
+
+	// same format as output of command with netconf
+	return "<configuration-output>" + output + "</configuration-output>", nil
+}
+
+func (t *gnmiTransport) commandXML(cmd string) (string, error) {
+	return "", fmt.Errorf("xml command '%s' not supported with gnmi transport", cmd)
+}
+
+// configSet keep lines in memory until commit.
+func (t *gnmiTransport) configSet(cmd []string) (string, error) {
+	t.setLines = append(t.setLines, cmd...)
+
+	return "", nil
+}
+
+// configLock has nothing to lock because each Set request is applied atomically.
+func (t *gnmiTransport) configLock() bool {
+	return true
+}
+
+func (t *gnmiTransport) configUnlock() []error {
+	return []error{}
+}
+
+func (t *gnmiTransport) configClear() []error {
+	t.setLines = nil
+
+	return []error{}
+}
+
+// commit send the set/delete lines in a single Set request with an update in ASCII encoding
+// on configuration path (the log message is not supported by Set request).
+// The delete lines of the hierarchies of resource followed by the set lines replace
+// the configuration of resource in one transaction; a replace of Set request isn't used
+// because it's on a path of configuration and would remove all the other statements under it.
+func (t *gnmiTransport) commit(_ string) ([]error, error) {
+	lines := t.setLines
+	t.setLines = nil
+	if len(lines) == 0 {
+		return []error{}, nil
+	}
+	req := &gnmi.SetRequest{Update: []*gnmi.Update{gnmiASCIIUpdate(lines)}}
+	ctx, cancel := t.context()
+	defer cancel()
+	if _, err := t.client.Set(ctx, req); err != nil {
+		return []error{}, fmt.Errorf("failed to gnmi set : %w", err)
+	}
+
+	return []error{}, nil
+}
+
+// gnmiASCIIUpdate generate an update with lines in ASCII encoding on configuration path.
+func gnmiASCIIUpdate(lines []string) *gnmi.Update {
+	return &gnmi.Update{
+		Path: &gnmi.Path{Elem: []*gnmi.PathElem{{Name: gnmiPathConfiguration}}},
+		Val:  &gnmi.TypedValue{Value: &gnmi.TypedValue_AsciiVal{AsciiVal: strings.Join(lines, "\n")}},
+	}
+}
+
+func (t *gnmiTransport) close() error {
+	if err := t.conn.Close(); err != nil {
+		return fmt.Errorf("failed to close gnmi connection : %w", err)
+	}
+
+	return nil
+}
+
+// junosJSONToSetLines convert native Junos configuration in JSON to set lines.
+// Order of keys is kept. Lists are arrays of objects with a 'name' key,
+// flags (statements without value) are [null].
+func junosJSONToSetLines(value []byte, prefix string) ([]string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(value))
+	decoder.UseNumber()
+	root, err := readJSONOrdered(decoder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode json configuration : %w", err)
+	}
+	rootObject, ok := root.(jsonOrderedObject)
+	if !ok {
+		return nil, errors.New("json configuration is not an object")
+	}
+	// unwrap when the value contains the element of path
+	words := strings.Fields(prefix)
+	if len(rootObject) == 1 && len(words) > 1 && stripJSONModule(rootObject[0].key) == words[len(words)-1] {
+		if inner, ok := rootObject[0].value.(jsonOrderedObject); ok {
+			rootObject = inner
+		}
+	}
+	lines := make([]string, 0)
+	appendJSONObjectSetLines(&lines, strings.TrimSuffix(prefix, " "), rootObject)
+
+	return lines, nil
+}
+
+type jsonKeyValue struct {
+	key   string
+	value interface{}
+}
+
+type jsonOrderedObject []jsonKeyValue
+
+func (o jsonOrderedObject) get(key string) (interface{}, bool) {
+	for _, kv := range o {
+		if kv.key == key {
+			return kv.value, true
+		}
+	}
+
+	return nil, false
+}
+
+// readJSONOrdered decode next value with objects as jsonOrderedObject to keep order of keys.
+func readJSONOrdered(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok := token.(type) {
+	case json.Delim:
+		switch tok {
+		case '{':
+			object := make(jsonOrderedObject, 0)
+			for decoder.More() {
+				keyToken, err := decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+				key, ok := keyToken.(string)
+				if !ok {
+					return nil, fmt.Errorf("unexpected key %v in object", keyToken)
+				}
+				value, err := readJSONOrdered(decoder)
+				if err != nil {
+					return nil, err
+				}
+				object = append(object, jsonKeyValue{key: key, value: value})
+			}
+			if _, err := decoder.Token(); err != nil {
+				return nil, err
+			}
+
+			return object, nil
+		case '[':
+			array := make([]interface{}, 0)
+			for decoder.More() {
+				value, err := readJSONOrdered(decoder)
+				if err != nil {
+					return nil, err
+				}
+				array = append(array, value)
+			}
+			if _, err := decoder.Token(); err != nil {
+				return nil, err
+			}
+
+			return array, nil
+		}
+
+		return nil, fmt.Errorf("unexpected delimiter %v", tok)
+	default:
+		return tok, nil
+	}
+}
+
+func stripJSONModule(key string) string {
+	if i := strings.Index(key, ":"); i != -1 {
+		return key[i+1:]
+	}
+
+	return key
+}
+
+func appendJSONObjectSetLines(lines *[]string, prefix string, object jsonOrderedObject) {
+	for _, kv := range object {
+		if strings.HasPrefix(kv.key, gnmiJSONAttributePrefix) {
+			appendJSONAttributeLines(lines, prefix, strings.TrimPrefix(kv.key, gnmiJSONAttributePrefix), kv.value)
+
+			continue
+		}
+		key := stripJSONModule(kv.key)
+		appendJSONValueSetLines(lines, prefix+" "+key, kv.value)
+	}
+}
+
+func appendJSONValueSetLines(lines *[]string, prefix string, value interface{}) {
+	switch v := value.(type) {
+	case jsonOrderedObject:
+		if len(v) == 0 {
+			*lines = append(*lines, prefix)
+
+			return
+		}
+		appendJSONObjectSetLines(lines, prefix, v)
+	case []interface{}:
+		for _, item := range v {
+			switch itemValue := item.(type) {
+			case nil:
+				*lines = append(*lines, prefix)
+			case jsonOrderedObject:
+				name, ok := itemValue.get("name")
+				if !ok {
+					appendJSONObjectSetLines(lines, prefix, itemValue)
+
+					continue
+				}
+				prefixItem := prefix + " " + quoteSetLineValue(fmt.Sprintf("%v", name))
+				others := make(jsonOrderedObject, 0, len(itemValue))
+				for _, kv := range itemValue {
+					switch {
+					case strings.HasPrefix(kv.key, gnmiJSONAttributePrefix):
+						appendJSONAttributeLines(lines, prefixItem,
+							strings.TrimPrefix(kv.key, gnmiJSONAttributePrefix), kv.value)
+					case kv.key != "name":
+						others = append(others, kv)
+					}
+				}
+				if len(others) == 0 {
+					*lines = append(*lines, prefixItem)
+
+					continue
+				}
+				appendJSONObjectSetLines(lines, prefixItem, others)
+			default:
+				*lines = append(*lines, prefix+" "+quoteSetLineValue(fmt.Sprintf("%v", itemValue)))
+			}
+		}
+	case nil:
+		*lines = append(*lines, prefix)
+	default:
+		*lines = append(*lines, prefix+" "+quoteSetLineValue(fmt.Sprintf("%v", v)))
+	}
+}
+
+// appendJSONAttributeLines add deactivate and protect lines from the attributes of a statement
+// ('@' for the statement of prefix, '@leaf' for a leaf).
+func appendJSONAttributeLines(lines *[]string, prefix, leaf string, value interface{}) {
+	attributes, ok := value.(jsonOrderedObject)
+	if !ok {
+		return
+	}
+	statement := strings.TrimPrefix(prefix, setLineStart)
+	if leaf != "" {
+		statement += " " + stripJSONModule(leaf)
+	}
+	if inactive, _ := attributes.get("inactive"); inactive == true {
+		*lines = append(*lines, deactivateLineStart+statement)
+	}
+	if protect, _ := attributes.get("protect"); protect == true {
+		*lines = append(*lines, protectLineStart+statement)
+	}
+}
+
+// quoteSetLineValue add double quotes around value like display set when necessary.
+func quoteSetLineValue(value string) string {
+	if value == "" || strings.ContainsAny(value, " \t;{}#[]\"") {
+		return "\"" + strings.ReplaceAll(value, "\"", "\\\"") + "\""
+	}
+
+	return value
+}
+
+// listOfResourcesNotSupportedByGNMI : resources which need xml rpc or operational commands of device
+// (not available with gNMI).
+func listOfResourcesNotSupportedByGNMI() []string {
+	return []string{
+		"junos_config_export",
+		"junos_interface",
+		"junos_interface_logical",
+		"junos_interface_physical",
+		"junos_interface_st0_unit",
+		"junos_request",
+		"junos_rollback",
+		"junos_system_rescue_configuration",
+	}
+}
+
+// listOfDataSourcesNotSupportedByGNMI : data sources which need xml rpc or operational commands of device
+// (not available with gNMI).
+func listOfDataSourcesNotSupportedByGNMI() []string {
+	return []string{
+		"junos_command",
+		"junos_commit_history",
+		"junos_rpc",
+	}
+}
+
+func errorNotSupportedByGNMI(kind, name string) error {
+	return fmt.Errorf("%s %s not supported with gnmi transport "+
+		"(need rpc of Junos not available with gNMI), use netconf or rest transport", kind, name)
+}
+
+// addGNMITransportCheck reject at plan time the resources and data sources not supported by gnmi transport,
+// instead of failing during apply on the first xml rpc.
+func addGNMITransportCheck(resources, dataSources map[string]*schema.Resource) {
+	for _, resourceName := range listOfResourcesNotSupportedByGNMI() {
+		resource, ok := resources[resourceName]
+		if !ok {
+			continue
+		}
+		name := resourceName
+		check := func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+			if m.(*Session).junosTransport == transportGNMI {
+				return errorNotSupportedByGNMI("resource", name)
+			}
+
+			return nil
+		}
+		if resource.CustomizeDiff != nil {
+			resource.CustomizeDiff = customdiff.Sequence(check, resource.CustomizeDiff)
+		} else {
+			resource.CustomizeDiff = check
+		}
+		if resource.Importer != nil && resource.Importer.State != nil {
+			importState := resource.Importer.State
+			resource.Importer.State = func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				if m.(*Session).junosTransport == transportGNMI {
+					return nil, errorNotSupportedByGNMI("resource", name)
+				}
+
+				return importState(d, m)
+			}
+		}
+	}
+	for _, dataSourceName := range listOfDataSourcesNotSupportedByGNMI() {
+		dataSource, ok := dataSources[dataSourceName]
+		if !ok || dataSource.ReadContext == nil {
+			continue
+		}
+		name := dataSourceName
+		read := dataSource.ReadContext
+		dataSource.ReadContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			if m.(*Session).junosTransport == transportGNMI {
+				return diag.FromErr(errorNotSupportedByGNMI("data source", name))
+			}
+
+			return read(ctx, d, m)
+		}
+	}
+}
@@ -0,0 +1,301 @@
+package junos
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// gnmiStandIn : minimal stand-in of gNMI service of Junos device.
+type gnmiStandIn struct {
+	gnmi.UnimplementedGNMIServer
+	config   string
+	lastSet  *gnmi.SetRequest
+	username string
+}
+
+func (g *gnmiStandIn) authenticate(ctx context.Context) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if len(md.Get("username")) != 1 || md.Get("username")[0] != "netconf" ||
+		len(md.Get("password")) != 1 || md.Get("password")[0] != "secret" {
+		return status.Error(codes.Unauthenticated, "bad credentials")
+	}
+	g.username = md.Get("username")[0]
+
+	return nil
+}
+
+func (g *gnmiStandIn) Capabilities(ctx context.Context, _ *gnmi.CapabilityRequest) (*gnmi.CapabilityResponse, error) {
+	if err := g.authenticate(ctx); err != nil {
+		return nil, err
+	}
+
+	return &gnmi.CapabilityResponse{
+		SupportedModels: []*gnmi.ModelData{
+			{Name: "openconfig-interfaces", Organization: "OpenConfig working group", Version: "2.0.0"},
+			{Name: "junos-conf-root", Organization: "Juniper Networks, Inc.", Version: "20.4R1.12"},
+		},
+		SupportedEncodings: []gnmi.Encoding{gnmi.Encoding_JSON_IETF, gnmi.Encoding_ASCII},
+		GNMIVersion:        "0.7.0",
+	}, nil
+}
+
+func (g *gnmiStandIn) Get(ctx context.Context, req *gnmi.GetRequest) (*gnmi.GetResponse, error) {
+	if err := g.authenticate(ctx); err != nil {
+		return nil, err
+	}
+	elems := make([]string, 0)
+	for _, elem := range req.GetPath()[0].GetElem() {
+		elems = append(elems, elem.GetName())
+	}
+	var value string
+	switch strings.Join(elems, "/") {
+	case "components/component/state/description":
+		value = `"QFX5120-48Y"`
+	case "system/state/hostname":
+		value = `"standin"`
+	case "configuration/routing-options":
+		value = g.config
+	default:
+		return nil, status.Error(codes.NotFound, "path not found")
+	}
+
+	return &gnmi.GetResponse{Notification: []*gnmi.Notification{{
+		Update: []*gnmi.Update{{
+			Path: req.GetPath()[0],
+			Val:  &gnmi.TypedValue{Value: &gnmi.TypedValue_JsonIetfVal{JsonIetfVal: []byte(value)}},
+		}},
+	}}}, nil
+}
+
+func (g *gnmiStandIn) Set(ctx context.Context, req *gnmi.SetRequest) (*gnmi.SetResponse, error) {
+	if err := g.authenticate(ctx); err != nil {
+		return nil, err
+	}
+	g.lastSet = req
+	for _, update := range req.GetUpdate() {
+		if strings.Contains(update.GetVal().GetAsciiVal(), "set bad") {
+			return nil, status.Error(codes.InvalidArgument, "syntax error")
+		}
+	}
+
+	return &gnmi.SetResponse{}, nil
+}
+
+// testGenTLSCertificate generate a self-signed certificate for localhost and write it in a PEM file.
+func testGenTLSCertificate(t *testing.T) (tls.Certificate, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "standin"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caFile := path.Join(t.TempDir(), "ca.pem")
+	if err := ioutil.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, caFile
+}
+
+func TestGNMITransport(t *testing.T) {
+	cert, caFile := testGenTLSCertificate(t)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	standIn := &gnmiStandIn{config: `{"junos-conf-routing-options:routing-options":{"static":{"route":[` +
+		`{"name":"192.0.2.0/24","discard":[null],"preference":{"metric-value":100}},` +
+		`{"name":"198.51.100.0/24","next-hop":["192.0.2.254","192.0.2.253"]}]}}}`}
+	srv := grpc.NewServer(grpc.Creds(credentials.NewServerTLSFromCert(&cert)))
+	gnmi.RegisterGNMIServer(srv, standIn)
+	go func() {
+		_ = srv.Serve(listener)
+	}()
+	defer srv.Stop()
+	host := listener.Addr().String()
+
+	if _, err := gnmiNewSession(host, &gnmiAuthMethod{Username: "netconf", Password: "secret"}); err == nil {
+		t.Fatal("gnmiNewSession() without CA of server must fail")
+	}
+	if _, err := gnmiNewSession(host, &gnmiAuthMethod{Username: "netconf", Password: "bad", CAFile: caFile}); err == nil {
+		t.Fatal("gnmiNewSession() with bad credentials must fail")
+	}
+	jnpr, err := gnmiNewSession(host, &gnmiAuthMethod{
+		Username: "netconf",
+		Password: "secret",
+		CAFile:   caFile,
+	})
+	if err != nil {
+		t.Fatalf("gnmiNewSession() error = %v", err)
+	}
+	if jnpr.SystemInformation.HardwareModel != "qfx5120-48y" || jnpr.SystemInformation.OsVersion != "20.4R1.12" ||
+		jnpr.SystemInformation.HostName != "standin" {
+		t.Errorf("gnmiNewSession() SystemInformation = %+v", jnpr.SystemInformation)
+	}
+	out, err := jnpr.netconfCommand("show configuration routing-options static route 192.0.2.0/24 | display set relative")
+	if err != nil {
+		t.Fatalf("netconfCommand() error = %v", err)
+	}
+	if out != "<configuration-output>\nset discard\nset preference metric-value 100\n</configuration-output>" {
+		t.Errorf("netconfCommand() relative = %q", out)
+	}
+	out, err = jnpr.netconfCommand("show configuration routing-options | display set")
+	if err != nil {
+		t.Fatalf("netconfCommand() error = %v", err)
+	}
+	if !strings.Contains(out, "set routing-options static route 198.51.100.0/24 next-hop 192.0.2.253\n") {
+		t.Errorf("netconfCommand() = %q", out)
+	}
+	if out, err := jnpr.netconfCommand("show configuration routing-options static route 203.0.113.0/24 | display set"); err == nil ||
+		out != emptyWord {
+		t.Errorf("netconfCommand() on missing config = %q, %v", out, err)
+	}
+	if _, err := jnpr.netconfCommand("show version"); err == nil {
+		t.Error("netconfCommand() with command other than show configuration must fail")
+	}
+	if _, err := jnpr.netconfConfigSet([]string{
+		"delete routing-options static route 192.0.2.0/24",
+		"set routing-options static route 192.0.2.0/24 discard",
+	}); err != nil {
+		t.Fatalf("netconfConfigSet() error = %v", err)
+	}
+	if _, err := jnpr.netconfCommit("test"); err != nil {
+		t.Fatalf("netconfCommit() error = %v", err)
+	}
+	if len(standIn.lastSet.GetUpdate()) != 1 || len(standIn.lastSet.GetReplace()) != 0 ||
+		standIn.lastSet.GetUpdate()[0].GetVal().GetAsciiVal() !=
+			"delete routing-options static route 192.0.2.0/24\nset routing-options static route 192.0.2.0/24 discard" {
+		t.Errorf("netconfCommit() set request = %v", standIn.lastSet)
+	}
+	if _, err := jnpr.netconfConfigSet([]string{"set bad"}); err != nil {
+		t.Fatalf("netconfConfigSet() error = %v", err)
+	}
+	if _, err := jnpr.netconfCommit("test"); err == nil || !strings.Contains(err.Error(), "syntax error") {
+		t.Errorf("netconfCommit() with bad line error = %v", err)
+	}
+	if err := jnpr.close(0); err != nil {
+		t.Errorf("close() error = %v", err)
+	}
+}
+
+func TestJunosJSONToSetLines(t *testing.T) {
+	lines, err := junosJSONToSetLines([]byte(`{"configuration":{"@":{"junos:changed-seconds":"1"},`+
+		`"system":{"host-name":"test","name-server":[{"name":"192.0.2.1"}],`+
+		`"login":{"message":"hello world"}},"security":{"zones":{"security-zone":[{"name":"trust",`+
+		`"interfaces":[{"name":"ge-0/0/0.0","host-inbound-traffic":{"system-services":[{"name":"ping"}]}}]}]}}}}`),
+		"set ")
+	if err != nil {
+		t.Fatalf("junosJSONToSetLines() error = %v", err)
+	}
+	want := []string{
+		"set configuration system host-name test",
+		"set configuration system name-server 192.0.2.1",
+		"set configuration system login message \"hello world\"",
+		"set configuration security zones security-zone trust interfaces ge-0/0/0.0 " +
+			"host-inbound-traffic system-services ping",
+	}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("junosJSONToSetLines() = %q, want %q", lines, want)
+	}
+	lines, err = junosJSONToSetLines([]byte(`{"routing-options":{"static":{"route":[`+
+		`{"name":"192.0.2.0/24","@":{"inactive":true},"discard":[null]},`+
+		`{"name":"198.51.100.0/24","preference":{"@":{"protect":true},"metric-value":5,"@metric-value":{"inactive":true}}}]}}}`),
+		"set ")
+	if err != nil {
+		t.Fatalf("junosJSONToSetLines() error = %v", err)
+	}
+	want = []string{
+		"deactivate routing-options static route 192.0.2.0/24",
+		"set routing-options static route 192.0.2.0/24 discard",
+		"protect routing-options static route 198.51.100.0/24 preference",
+		"set routing-options static route 198.51.100.0/24 preference metric-value 5",
+		"deactivate routing-options static route 198.51.100.0/24 preference metric-value",
+	}
+	if strings.Join(lines, "\n") != strings.Join(want, "\n") {
+		t.Errorf("junosJSONToSetLines() with attributes = %q, want %q", lines, want)
+	}
+	if _, err := junosJSONToSetLines([]byte(`{"system":`), "set "); err == nil {
+		t.Error("junosJSONToSetLines() with truncated json must fail")
+	}
+}
+
+func TestGNMITransportCheck(t *testing.T) {
+	ctx := context.Background()
+	provider := Provider()
+	sessGNMI := &Session{junosTransport: transportGNMI}
+	for _, name := range listOfResourcesNotSupportedByGNMI() {
+		r, ok := provider.ResourcesMap[name]
+		if !ok {
+			t.Fatalf("resource %s not in provider", name)
+		}
+		if r.CustomizeDiff == nil {
+			t.Fatalf("resource %s without check of transport", name)
+		}
+		config := map[string]interface{}{}
+		_, err := r.Diff(ctx, nil, terraform.NewResourceConfigRaw(config), sessGNMI)
+		if err == nil || !strings.Contains(err.Error(), "not supported with gnmi transport") {
+			t.Errorf("plan of resource %s with gnmi error = %v", name, err)
+		}
+		if r.Importer != nil {
+			d := r.Data(nil)
+			d.SetId("xxx")
+			if _, err := r.Importer.State(d, sessGNMI); err == nil {
+				t.Errorf("import of resource %s with gnmi without error", name)
+			}
+		}
+	}
+	for _, name := range listOfDataSourcesNotSupportedByGNMI() {
+		ds, ok := provider.DataSourcesMap[name]
+		if !ok {
+			t.Fatalf("data source %s not in provider", name)
+		}
+		diags := ds.ReadContext(ctx, ds.Data(nil), sessGNMI)
+		if !diags.HasError() || !strings.Contains(diags[0].Summary, "not supported with gnmi transport") {
+			t.Errorf("read of data source %s with gnmi = %v", name, diags)
+		}
+	}
+	// resources which only generate configuration are planned
+	r := provider.ResourcesMap["junos_static_route"]
+	config := map[string]interface{}{"destination": "192.0.2.0/24", "discard": true}
+	if _, err := r.Diff(ctx, nil, terraform.NewResourceConfigRaw(config), &Session{
+		junosTransport:         transportGNMI,
+		junosFakeCreateSetFile: "/dev/null",
+	}); err != nil {
+		t.Errorf("plan of junos_static_route with gnmi error = %v", err)
+	}
+}
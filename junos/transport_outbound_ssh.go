@@ -0,0 +1,280 @@
+package junos
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1" // nolint: gosec
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jeremmfr/go-netconf/netconf"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	outboundSSHTimeout          = 5 * time.Minute
+	outboundSSHHandshakeTimeout = 30 * time.Second
+	outboundSSHMaxLineLength    = 8192
+
+	outboundSSHMsgID    = "MSG-ID"
+	outboundSSHDeviceID = "DEVICE-ID"
+	outboundSSHHostKey  = "HOST-KEY"
+	outboundSSHHMAC     = "HMAC"
+)
+
+// outboundSSHListener : listen for connections initiated by Junos devices with 'system services outbound-ssh'.
+// The listener is opened on first session and shared by all sessions of provider.
+// Connections are accepted in background and given, after the handshake, to a session waiting for the device
+// (or kept until a session wait for it), so the mutex is only held to update the waiting sessions and connections.
+type outboundSSHListener struct {
+	mutex    sync.Mutex
+	address  string
+	listener *net.TCPListener
+	waiting  map[string][]*outboundSSHWaiter
+	pending  map[string]*outboundSSHConn
+}
+
+// outboundSSHWaiter : a session waiting a connection from a device.
+type outboundSSHWaiter struct {
+	secret string
+	conn   chan *outboundSSHConn
+}
+
+// outboundSSHConn : a connection with its handshake already read.
+type outboundSSHConn struct {
+	conn      net.Conn
+	handshake outboundSSHHandshake
+}
+
+// outboundSSHHandshake : information announced by device at the beginning of connection.
+type outboundSSHHandshake struct {
+	deviceID string
+	hostKey  string
+	hmac     string
+}
+
+// netconfNewSessionOutboundSSH wait a connection from device with the deviceID
+// and establishes a netconf session on it with the host key announced (and authenticated with secret) by device.
+func netconfNewSessionOutboundSSH(
+	listener *outboundSSHListener, deviceID, secret string, auth *netconfAuthMethod,
+) (*NetconfObject, error) {
+	clientConfig, err := genSSHClientConfig(auth)
+	if err != nil {
+		return nil, err
+	}
+	conn, handshake, err := listener.accept(deviceID, secret, outboundSSHTimeout)
+	if err != nil {
+		return nil, err
+	}
+	hostKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(handshake.hostKey))
+	if err != nil {
+		conn.Close()
+
+		return nil, fmt.Errorf("failed to parse host key announced by device %s : %w", deviceID, err)
+	}
+	clientConfig.HostKeyCallback = ssh.FixedHostKey(hostKey)
+	s, err := netconf.NewSSHSession(conn, clientConfig)
+	if err != nil {
+		conn.Close()
+
+		return nil, fmt.Errorf("error connecting to device %s with outbound-ssh - %w", deviceID, err)
+	}
+
+	return newSessionFromNetconf(s)
+}
+
+// accept wait a connection with a handshake from deviceID and a valid HMAC with secret.
+// Connections from other devices or with a bad HMAC are closed without stopping the wait.
+func (l *outboundSSHListener) accept(
+	deviceID, secret string, timeout time.Duration,
+) (net.Conn, outboundSSHHandshake, error) {
+	if secret == "" {
+		return nil, outboundSSHHandshake{},
+			errors.New("secret of outbound-ssh needed to authenticate the host key of device " + deviceID)
+	}
+	waiter := &outboundSSHWaiter{secret: secret, conn: make(chan *outboundSSHConn, 1)}
+	l.mutex.Lock()
+	if err := l.listen(); err != nil {
+		l.mutex.Unlock()
+
+		return nil, outboundSSHHandshake{}, err
+	}
+	if pending, ok := l.pending[deviceID]; ok && pending.handshake.validHMAC(secret) {
+		delete(l.pending, deviceID)
+		l.mutex.Unlock()
+
+		return pending.conn, pending.handshake, nil
+	}
+	l.waiting[deviceID] = append(l.waiting[deviceID], waiter)
+	l.mutex.Unlock()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case c := <-waiter.conn:
+		return c.conn, c.handshake, nil
+	case <-timer.C:
+		l.mutex.Lock()
+		for i, w := range l.waiting[deviceID] {
+			if w == waiter {
+				l.waiting[deviceID] = append(l.waiting[deviceID][:i], l.waiting[deviceID][i+1:]...)
+
+				break
+			}
+		}
+		l.mutex.Unlock()
+		// a connection can be given between the timeout and the remove of waiter
+		select {
+		case c := <-waiter.conn:
+			return c.conn, c.handshake, nil
+		default:
+		}
+
+		return nil, outboundSSHHandshake{},
+			fmt.Errorf("no outbound-ssh connection with valid handshake from device %s on %s after %s",
+				deviceID, l.address, timeout)
+	}
+}
+
+// listen open the listener and start to accept connections in background if not already done.
+// l.mutex need to be locked by caller.
+func (l *outboundSSHListener) listen() error {
+	if l.waiting == nil {
+		l.waiting = make(map[string][]*outboundSSHWaiter)
+		l.pending = make(map[string]*outboundSSHConn)
+	}
+	if l.listener != nil {
+		return nil
+	}
+	tcpAddr, err := net.ResolveTCPAddr("tcp", l.address)
+	if err != nil {
+		return fmt.Errorf("failed to resolve address %s : %w", l.address, err)
+	}
+	listener, err := net.ListenTCP("tcp", tcpAddr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s : %w", l.address, err)
+	}
+	l.listener = listener
+	go l.serve(listener)
+
+	return nil
+}
+
+// serve accept connections until listener is closed and read their handshake in parallel.
+func (l *outboundSSHListener) serve(listener *net.TCPListener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Temporary() {
+				continue
+			}
+
+			return
+		}
+		go l.dispatch(conn)
+	}
+}
+
+// dispatch give the connection to the first session waiting for the device with a secret matching the HMAC,
+// or keep it for the next session (replacing a previous connection of device not yet used).
+func (l *outboundSSHListener) dispatch(conn net.Conn) {
+	handshake, err := readOutboundSSHHandshake(conn)
+	if err != nil {
+		conn.Close()
+
+		return
+	}
+	c := &outboundSSHConn{conn: conn, handshake: handshake}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	for i, w := range l.waiting[handshake.deviceID] {
+		if !handshake.validHMAC(w.secret) {
+			continue
+		}
+		l.waiting[handshake.deviceID] = append(l.waiting[handshake.deviceID][:i], l.waiting[handshake.deviceID][i+1:]...)
+		w.conn <- c
+
+		return
+	}
+	if previous, ok := l.pending[handshake.deviceID]; ok {
+		previous.conn.Close()
+	}
+	l.pending[handshake.deviceID] = c
+}
+
+// readOutboundSSHHandshake read the lines of handshake one byte at a time
+// to leave the beginning of ssh protocol in connection.
+// The handshake need to end with the HMAC line (sent only when a secret is configured on device).
+func readOutboundSSHHandshake(conn net.Conn) (outboundSSHHandshake, error) {
+	var handshake outboundSSHHandshake
+	if err := conn.SetReadDeadline(time.Now().Add(outboundSSHHandshakeTimeout)); err != nil {
+		return handshake, fmt.Errorf("failed to set deadline on connection : %w", err)
+	}
+	defer func() {
+		_ = conn.SetReadDeadline(time.Time{})
+	}()
+	var line bytes.Buffer
+	b := make([]byte, 1)
+	for {
+		if _, err := conn.Read(b); err != nil {
+			return handshake, fmt.Errorf("failed to read outbound-ssh handshake : %w", err)
+		}
+		if b[0] != '\n' {
+			if line.Len() >= outboundSSHMaxLineLength {
+				return handshake, errors.New("line too long in outbound-ssh handshake")
+			}
+			line.WriteByte(b[0])
+
+			continue
+		}
+		if strings.HasPrefix(line.String(), "SSH-") {
+			return handshake, errors.New("outbound-ssh handshake without HMAC (secret not configured on device)")
+		}
+		key, value, ok := splitOutboundSSHLine(line.String())
+		line.Reset()
+		if !ok {
+			continue
+		}
+		switch key {
+		case outboundSSHMsgID:
+			if value != "DEVICE-CONN-INFO" {
+				return handshake, fmt.Errorf("unexpected %s '%s' in outbound-ssh handshake", outboundSSHMsgID, value)
+			}
+		case outboundSSHDeviceID:
+			handshake.deviceID = value
+		case outboundSSHHostKey:
+			handshake.hostKey = value
+		case outboundSSHHMAC:
+			handshake.hmac = value
+
+			return handshake, nil
+		}
+	}
+}
+
+func splitOutboundSSHLine(line string) (string, string, bool) {
+	lineSplit := strings.SplitN(strings.TrimSuffix(line, "\r"), ":", 2)
+	if len(lineSplit) != 2 {
+		return "", "", false
+	}
+
+	return strings.TrimSpace(lineSplit[0]), strings.TrimSpace(lineSplit[1]), true
+}
+
+// validHMAC check HMAC-SHA1 of host key with the secret.
+func (h outboundSSHHandshake) validHMAC(secret string) bool {
+	received, err := hex.DecodeString(h.hmac)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha1.New, []byte(secret))
+	mac.Write([]byte(h.hostKey))
+
+	return hmac.Equal(received, mac.Sum(nil))
+}
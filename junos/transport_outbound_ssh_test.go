@@ -0,0 +1,112 @@
+package junos
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" // nolint: gosec
+	"encoding/hex"
+	"net"
+	"testing"
+	"time"
+)
+
+func testDialOutboundSSH(t *testing.T, address, handshake string) net.Conn {
+	t.Helper()
+	conn, err := net.Dial("tcp", address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := conn.Write([]byte(handshake + "SSH-2.0-standin\r\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	return conn
+}
+
+func TestOutboundSSHListenerAccept(t *testing.T) {
+	listener := &outboundSSHListener{address: "127.0.0.1:0"}
+	if err := listener.listen(); err != nil {
+		t.Fatal(err)
+	}
+	defer listener.listener.Close()
+	address := listener.listener.Addr().String()
+
+	hostKey := "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIOMqqnkVzrm0SdG6UOoqKLsabgH5C9okWi0dh2l9GKJl"
+	mac := hmac.New(sha1.New, []byte("secret"))
+	mac.Write([]byte(hostKey))
+	handshakeBranch1 := "MSG-ID: DEVICE-CONN-INFO\r\nMSG-VER: V1\r\nDEVICE-ID: branch1\r\n" +
+		"HOST-KEY: " + hostKey + "\r\nHMAC: " + hex.EncodeToString(mac.Sum(nil)) + "\r\n"
+	devices := []string{
+		"MSG-ID: DEVICE-CONN-INFO\r\nMSG-VER: V1\r\nDEVICE-ID: other\r\n" +
+			"HOST-KEY: " + hostKey + "\r\nHMAC: " + hex.EncodeToString(mac.Sum(nil)) + "\r\n",
+		// bad HMAC is closed without stopping the wait
+		"MSG-ID: DEVICE-CONN-INFO\r\nMSG-VER: V1\r\nDEVICE-ID: branch1\r\nHOST-KEY: " + hostKey + "\r\nHMAC: 00\r\n",
+		// without secret on device
+		"MSG-ID: DEVICE-CONN-INFO\r\nMSG-VER: V1\r\nDEVICE-ID: branch1\r\n",
+	}
+	for _, handshake := range devices {
+		defer testDialOutboundSSH(t, address, handshake).Close()
+	}
+	// connection arrived when the session wait for it
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		conn, err := net.Dial("tcp", address)
+		if err != nil {
+			t.Error(err)
+
+			return
+		}
+		defer conn.Close()
+		_, _ = conn.Write([]byte(handshakeBranch1 + "SSH-2.0-standin\r\n"))
+		time.Sleep(time.Second)
+	}()
+	conn, handshake, err := listener.accept("branch1", "secret", 10*time.Second)
+	if err != nil {
+		t.Fatalf("accept() error = %v", err)
+	}
+	defer conn.Close()
+	if handshake.deviceID != "branch1" || handshake.hostKey != hostKey {
+		t.Errorf("accept() handshake = %+v", handshake)
+	}
+	// the ssh version string must be left in connection
+	version := make([]byte, len("SSH-2.0-"))
+	if _, err := conn.Read(version); err != nil || string(version) != "SSH-2.0-" {
+		t.Errorf("read after handshake = %q, %v", version, err)
+	}
+
+	if _, _, err := listener.accept("branch1", "", 10*time.Second); err == nil {
+		t.Error("accept() without secret must fail")
+	}
+	if _, _, err := listener.accept("branch1", "secret", 100*time.Millisecond); err == nil {
+		t.Error("accept() without connection must fail after timeout")
+	}
+
+	// connection arrived before the session is kept for it
+	defer testDialOutboundSSH(t, address, handshakeBranch1).Close()
+	time.Sleep(200 * time.Millisecond)
+	conn2, _, err := listener.accept("branch1", "secret", time.Second)
+	if err != nil {
+		t.Fatalf("accept() of pending connection error = %v", err)
+	}
+	conn2.Close()
+
+	// sessions wait in parallel without blocking each other
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			c, _, err := listener.accept("branch1", "secret", 10*time.Second)
+			if err == nil {
+				c.Close()
+			}
+			errs <- err
+		}()
+	}
+	time.Sleep(100 * time.Millisecond)
+	for i := 0; i < 2; i++ {
+		defer testDialOutboundSSH(t, address, handshakeBranch1).Close()
+	}
+	for i := 0; i < 2; i++ {
+		if err := <-errs; err != nil {
+			t.Errorf("accept() in parallel error = %v", err)
+		}
+	}
+}
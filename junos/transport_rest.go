@@ -0,0 +1,334 @@
+package junos
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	transportNetconf = "netconf"
+	transportREST    = "rest"
+	transportGNMI    = "gnmi"
+
+	rpcOpenPrivateConfig  = "<open-configuration><private/></open-configuration>"
+	rpcClosePrivateConfig = "<close-configuration/>"
+
+	restTimeout = 10 * time.Minute
+)
+
+// restTransport : send RPCs to the REST API of Junos device (/rpc over https).
+// Each HTTP request is a separate session on device, so the RPCs which load configuration
+// (set lines and rollback) are kept in memory and sent with the commit in a single request
+// on a private candidate configuration.
+type restTransport struct {
+	client     *http.Client
+	url        string
+	username   string
+	password   string
+	configRPCs []string
+}
+
+type restAuthMethod struct {
+	Username string
+	Password string
+	CAFile   string
+	CertFile string
+	KeyFile  string
+}
+
+// restError : error or warning in reply of REST API (<rpc-error> or <xnm:error>/<xnm:warning>).
+type restError struct {
+	Severity string
+	Path     string
+	Element  string
+	Message  string
+}
+
+// restNewSession create a REST client for Junos device and gather facts.
+func restNewSession(host string, auth *restAuthMethod) (*NetconfObject, error) {
+	tlsConfig, err := genTLSConfig(auth.CAFile, auth.CertFile, auth.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	if auth.Password == "" && len(tlsConfig.Certificates) == 0 {
+		return nil, errors.New("no credentials/certificate available for REST API")
+	}
+	transport := &restTransport{
+		client: &http.Client{
+			Timeout:   restTimeout,
+			Transport: &http.Transport{TLSClientConfig: tlsConfig},
+		},
+		url:      "https://" + host + "/rpc",
+		username: auth.Username,
+		password: auth.Password,
+	}
+	n := &NetconfObject{
+		transport: transport,
+	}
+	reply, err := transport.post(rpcSystemInfo, false)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to %s - %w", host, err)
+	}
+	if len(reply) != 1 {
+		return nil, fmt.Errorf("failed to read get-system-information reply : %d part(s) received", len(reply))
+	}
+	if err := xml.Unmarshal([]byte(reply[0]), &n.SystemInformation); err != nil {
+		return nil, fmt.Errorf("failed to xml unmarshal reply : %w", err)
+	}
+
+	return n, nil
+}
+
+// genTLSConfig generate TLS configuration with the CA file to verify certificate of device
+// and the client certificate to authenticate (REST API and gNMI).
+func genTLSConfig(caFile, certFile, keyFile string) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile != "" {
+		caPEM, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not read file `%s` : %w", caFile, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no certificate found in file `%s`", caFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate `%s` and key `%s` : %w",
+				certFile, keyFile, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// post send RPCs in body of request and return the reply of each RPC.
+func (t *restTransport) post(rpcs string, stopOnError bool) ([]string, error) {
+	url := t.url
+	if stopOnError {
+		url += "?stop-on-error=1"
+	}
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(rpcs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create http request : %w", err)
+	}
+	req.Header.Set("Content-Type", "application/xml")
+	req.Header.Set("Accept", "application/xml")
+	if t.password != "" {
+		req.SetBasicAuth(t.username, t.password)
+	}
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send http request : %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read http response : %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		if errs := readRESTErrors(string(body)); len(errs) > 0 {
+			return nil, errors.New(errs[0].Message)
+		}
+
+		return nil, fmt.Errorf("http response with status %s : %s", resp.Status, string(body))
+	}
+	mediaType, params, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil || !strings.HasPrefix(mediaType, "multipart/") {
+		return []string{string(body)}, nil
+	}
+	parts := make([]string, 0)
+	reader := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read multipart http response : %w", err)
+		}
+		partBody, err := ioutil.ReadAll(part)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read multipart http response : %w", err)
+		}
+		parts = append(parts, string(partBody))
+	}
+	if len(parts) == 0 {
+		return nil, errors.New("no part in multipart http response")
+	}
+
+	return parts, nil
+}
+
+// readRESTErrors read the errors and warnings in a reply.
+func readRESTErrors(reply string) []restError {
+	errs := make([]restError, 0)
+	decoder := xml.NewDecoder(strings.NewReader(reply))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch start.Name.Local {
+		case "rpc-error":
+			var e struct {
+				Path     string `xml:"error-path"`
+				Element  string `xml:"error-info>bad-element"`
+				Message  string `xml:"error-message"`
+				Severity string `xml:"error-severity"`
+			}
+			if err := decoder.DecodeElement(&e, &start); err == nil {
+				errs = append(errs, restError{
+					Severity: strings.TrimSpace(e.Severity),
+					Path:     strings.TrimSpace(e.Path),
+					Element:  strings.TrimSpace(e.Element),
+					Message:  strings.TrimSpace(e.Message),
+				})
+			}
+		case "error", "warning":
+			var e struct {
+				Path    string `xml:"edit-path"`
+				Element string `xml:"statement"`
+				Message string `xml:"message"`
+			}
+			if err := decoder.DecodeElement(&e, &start); err == nil {
+				severity := "error"
+				if start.Name.Local == warningSeverity {
+					severity = warningSeverity
+				}
+				errs = append(errs, restError{
+					Severity: severity,
+					Path:     strings.TrimSpace(e.Path),
+					Element:  strings.TrimSpace(e.Element),
+					Message:  strings.TrimSpace(e.Message),
+				})
+			}
+		}
+	}
+
+	return errs
+}
+
+func (t *restTransport) command(cmd string) (string, error) {
+	reply, err := t.post(fmt.Sprintf(rpcCommand, html.EscapeString(cmd)), false)
+	if err != nil {
+		return "", fmt.Errorf("failed to rest command exec : %w", err)
+	}
+	for _, e := range readRESTErrors(reply[0]) {
+		if e.Severity != warningSeverity {
+			return "", errors.New(e.Message)
+		}
+	}
+	output := reply[0]
+	var outputXML commandXMLConfig
+	if xml.Unmarshal([]byte(reply[0]), &outputXML) == nil {
+		output = html.UnescapeString(outputXML.Config)
+	}
+	if strings.Count(output, "") <= 2 {
+		return emptyWord, errors.New("no output available - please check the syntax of your command")
+	}
+
+	return output, nil
+}
+
+// commandXML keep the load-configuration RPC in memory until commit (like set lines).
+func (t *restTransport) commandXML(cmd string) (string, error) {
+	if strings.HasPrefix(cmd, "<load-configuration") {
+		t.configRPCs = append(t.configRPCs, cmd)
+
+		return "", nil
+	}
+	reply, err := t.post(cmd, false)
+	if err != nil {
+		return "", fmt.Errorf("failed to rest xml command exec : %w", err)
+	}
+	for _, e := range readRESTErrors(reply[0]) {
+		if e.Severity != warningSeverity {
+			return "", errors.New(e.Message)
+		}
+	}
+
+	return reply[0], nil
+}
+
+// configSet keep lines in memory until commit.
+func (t *restTransport) configSet(cmd []string) (string, error) {
+	t.configRPCs = append(t.configRPCs, fmt.Sprintf(rpcConfigStringSet, html.EscapeString(strings.Join(cmd, "\n"))))
+
+	return "", nil
+}
+
+// configLock has nothing to lock: a lock is released by device at the end of each HTTP request (session)
+// and the configuration is loaded and committed in a single request on a private candidate configuration,
+// which contains only the changes of this request and can't be committed when another user has
+// an exclusive lock or uncommitted changes in the shared candidate configuration.
+// The configuration read before the commit isn't locked, other changes can happen between the read and the commit.
+func (t *restTransport) configLock() bool {
+	return true
+}
+
+func (t *restTransport) configUnlock() []error {
+	return []error{}
+}
+
+func (t *restTransport) configClear() []error {
+	t.configRPCs = nil
+
+	return []error{}
+}
+
+// commit send RPCs kept in memory and commit in a single request on a private candidate configuration.
+func (t *restTransport) commit(logMessage string) ([]error, error) {
+	rpcs := rpcOpenPrivateConfig +
+		strings.Join(t.configRPCs, "") +
+		fmt.Sprintf(rpcCommit, html.EscapeString(logMessage)) +
+		rpcClosePrivateConfig
+	t.configRPCs = nil
+	reply, err := t.post(rpcs, true)
+	if err != nil {
+		return []error{}, fmt.Errorf("failed to rest commit : %w", err)
+	}
+	warnings := make([]error, 0)
+	for _, part := range reply {
+		for _, e := range readRESTErrors(part) {
+			if e.Severity == warningSeverity {
+				warnings = append(warnings, errors.New(e.Message))
+
+				continue
+			}
+			if e.Path != "" || e.Element != "" {
+				return warnings, fmt.Errorf("[%s]\n    %s\nError: %s", e.Path, e.Element, e.Message)
+			}
+
+			return warnings, errors.New(e.Message)
+		}
+	}
+
+	return warnings, nil
+}
+
+func (t *restTransport) close() error {
+	t.client.CloseIdleConnections()
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package junos
+
+import (
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"testing"
+)
+
+// restStandIn : minimal stand-in of Junos REST API.
+type restStandIn struct {
+	committed []string
+	lastQuery string
+	lastRPCs  string
+}
+
+func (r *restStandIn) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if user, pass, ok := req.BasicAuth(); !ok || user != "netconf" || pass != "secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+
+		return
+	}
+	if req.Method != http.MethodPost || req.URL.Path != "/rpc" {
+		w.WriteHeader(http.StatusNotFound)
+
+		return
+	}
+	body, _ := ioutil.ReadAll(req.Body)
+	r.lastQuery = req.URL.RawQuery
+	rpcs := string(body)
+	r.lastRPCs = rpcs
+	switch {
+	case rpcs == rpcSystemInfo:
+		w.Header().Set("Content-Type", "application/xml")
+		fmt.Fprint(w, "<system-information>\n<hardware-model>vsrx</hardware-model>\n"+
+			"<os-name>junos</os-name>\n<os-version>20.2R1.10</os-version>\n<host-name>standin</host-name>\n"+
+			"</system-information>\n")
+	case strings.HasPrefix(rpcs, "<command format=\"text\">show configuration"):
+		w.Header().Set("Content-Type", "application/xml")
+		if len(r.committed) == 0 {
+			fmt.Fprint(w, "<configuration-output>\n</configuration-output>\n")
+
+			return
+		}
+		fmt.Fprint(w, "<configuration-output>\n"+strings.Join(r.committed, "\n")+"\n</configuration-output>\n")
+	case strings.HasPrefix(rpcs, rpcOpenPrivateConfig):
+		w.Header().Set("Content-Type", "multipart/mixed; boundary=standin")
+		parts := []string{"<ok/>", "<load-configuration-results><ok/></load-configuration-results>"}
+		if strings.Contains(rpcs, "set bad") {
+			parts = append(parts, "<xnm:error xmlns:xnm=\"http://xml.juniper.net/xnm/1.1/xnm\">"+
+				"<edit-path>[edit]</edit-path><statement>bad</statement>"+
+				"<message>syntax error</message></xnm:error>")
+		} else {
+			for _, line := range strings.Split(rpcs, "\n") {
+				line = strings.TrimPrefix(line, rpcOpenPrivateConfig+
+					"<load-configuration action=\"set\" format=\"text\"><configuration-set>")
+				if strings.HasPrefix(line, "set ") {
+					r.committed = append(r.committed, strings.Split(line, "<")[0])
+				}
+			}
+			parts = append(parts, "<commit-results><xnm:warning xmlns:xnm=\"http://xml.juniper.net/xnm/1.1/xnm\">"+
+				"<message>test warning</message></xnm:warning></commit-results>", "<ok/>")
+		}
+		for _, part := range parts {
+			fmt.Fprint(w, "--standin\r\nContent-Type: application/xml; charset=utf-8\r\n\r\n"+part+"\r\n")
+		}
+		fmt.Fprint(w, "--standin--\r\n")
+	default:
+		w.Header().Set("Content-Type", "application/xml")
+		fmt.Fprint(w, "<rpc-error><error-severity>error</error-severity>"+
+			"<error-message>syntax error</error-message></rpc-error>")
+	}
+}
+
+func TestRESTTransport(t *testing.T) {
+	standIn := &restStandIn{}
+	srv := httptest.NewTLSServer(standIn)
+	defer srv.Close()
+	caFile := path.Join(t.TempDir(), "ca.pem")
+	if err := ioutil.WriteFile(caFile,
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	host := strings.TrimPrefix(srv.URL, "https://")
+
+	if _, err := restNewSession(host, &restAuthMethod{Username: "netconf", Password: "secret"}); err == nil {
+		t.Fatal("restNewSession() without CA of server must fail")
+	}
+	jnpr, err := restNewSession(host, &restAuthMethod{Username: "netconf", Password: "secret", CAFile: caFile})
+	if err != nil {
+		t.Fatalf("restNewSession() error = %v", err)
+	}
+	if jnpr.SystemInformation.HardwareModel != "vsrx" || jnpr.SystemInformation.OsVersion != "20.2R1.10" {
+		t.Errorf("restNewSession() SystemInformation = %+v", jnpr.SystemInformation)
+	}
+	if out, err := jnpr.netconfCommand("show configuration routing-options | display set"); err == nil ||
+		out != emptyWord {
+		t.Errorf("netconfCommand() on empty config = %q, %v", out, err)
+	}
+	if !jnpr.netconfConfigLock() {
+		t.Fatal("netconfConfigLock() = false")
+	}
+	if _, err := jnpr.netconfConfigSet([]string{"set routing-options static route 192.0.2.0/24 discard"}); err != nil {
+		t.Fatalf("netconfConfigSet() error = %v", err)
+	}
+	warns, err := jnpr.netconfCommit("test")
+	if err != nil {
+		t.Fatalf("netconfCommit() error = %v", err)
+	}
+	if len(warns) != 1 || warns[0].Error() != "test warning" {
+		t.Errorf("netconfCommit() warnings = %v", warns)
+	}
+	if standIn.lastQuery != "stop-on-error=1" {
+		t.Errorf("commit query = %q", standIn.lastQuery)
+	}
+	out, err := jnpr.netconfCommand("show configuration routing-options | display set")
+	if err != nil {
+		t.Fatalf("netconfCommand() error = %v", err)
+	}
+	if !strings.Contains(out, "set routing-options static route 192.0.2.0/24 discard") {
+		t.Errorf("netconfCommand() = %q", out)
+	}
+	if _, err := jnpr.netconfConfigSet([]string{"set bad"}); err != nil {
+		t.Fatalf("netconfConfigSet() error = %v", err)
+	}
+	if _, err := jnpr.netconfCommit("test"); err == nil || !strings.Contains(err.Error(), "syntax error") {
+		t.Errorf("netconfCommit() with bad line error = %v", err)
+	}
+	// rollback is loaded with commit in the same request on private candidate
+	if _, err := jnpr.netconfCommandXML(fmt.Sprintf(rpcLoadRollback, 1)); err != nil {
+		t.Fatalf("netconfCommandXML() load rollback error = %v", err)
+	}
+	if _, err := jnpr.netconfCommit("rollback & test"); err != nil {
+		t.Fatalf("netconfCommit() with rollback error = %v", err)
+	}
+	if want := rpcOpenPrivateConfig + "<load-configuration rollback=\"1\"/>" +
+		"<commit-configuration><log>rollback &amp; test</log></commit-configuration>" +
+		rpcClosePrivateConfig; standIn.lastRPCs != want {
+		t.Errorf("commit with rollback rpcs = %q, want %q", standIn.lastRPCs, want)
+	}
+	if _, err := jnpr.netconfCommand("show configuration | match \"a&b\""); err != nil {
+		t.Fatalf("netconfCommand() error = %v", err)
+	}
+	if want := "<command format=\"text\">show configuration | match &#34;a&amp;b&#34;</command>"; standIn.lastRPCs != want {
+		t.Errorf("command rpc = %q, want %q", standIn.lastRPCs, want)
+	}
+	if _, err := jnpr.netconfCommandXML("<get-unknown/>"); err == nil {
+		t.Error("netconfCommandXML() with unknown rpc must fail")
+	}
+	if err := jnpr.close(0); err != nil {
+		t.Errorf("close() error = %v", err)
+	}
+}
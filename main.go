@@ -1,13 +1,122 @@
 package main
 
 import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
 	"terraform-provider-junos/junos"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "generate" {
+		os.Exit(generate(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(migrate(os.Args[2:]))
+	}
 	plugin.Serve(&plugin.ServeOpts{
 		ProviderFunc: junos.Provider,
 	})
 }
+
+// generate write the HCL of resources (with import blocks) for objects in a configuration file of device.
+func generate(args []string) int {
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+	configFile := flags.String("config", "", "configuration of device in 'display set' format (required)")
+	outputFile := flags.String("output", "", "file to write HCL (default to stdout)")
+	reportFile := flags.String("report", "", "file to write the report of lines not covered (default to stderr)")
+	model := flags.String("model", "", "hardware model of device (default to srx with security configuration)")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *configFile == "" {
+		fmt.Fprintln(os.Stderr, "generate: missing -config argument")
+		flags.Usage()
+
+		return 2
+	}
+	config, err := os.Open(*configFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "generate: %s\n", err)
+
+		return 1
+	}
+	defer config.Close()
+	output := os.Stdout
+	if *outputFile != "" {
+		if output, err = os.Create(*outputFile); err != nil {
+			fmt.Fprintf(os.Stderr, "generate: %s\n", err)
+
+			return 1
+		}
+		defer output.Close()
+	}
+	report := os.Stderr
+	if *reportFile != "" {
+		if report, err = os.Create(*reportFile); err != nil {
+			fmt.Fprintf(os.Stderr, "generate: %s\n", err)
+
+			return 1
+		}
+		defer report.Close()
+	}
+	if err := junos.GenerateFromConfig(config, *model, output, report); err != nil {
+		fmt.Fprintf(os.Stderr, "generate: %s\n", err)
+
+		return 1
+	}
+
+	return 0
+}
+
+// migrate rewrite the deprecated junos_interface resources and data sources in Terraform files of a directory.
+func migrate(args []string) int {
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	dir := flags.String("dir", ".", "directory with Terraform files (*.tf) to migrate")
+	dryRun := flags.Bool("dry-run", false, "only write the report without change on files")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	paths, err := filepath.Glob(filepath.Join(*dir, "*.tf"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "migrate: %s\n", err)
+
+		return 1
+	}
+	if len(paths) == 0 {
+		fmt.Fprintf(os.Stderr, "migrate: no .tf file found in %s\n", *dir)
+
+		return 1
+	}
+	files := make(map[string][]byte)
+	for _, path := range paths {
+		if files[path], err = ioutil.ReadFile(path); err != nil {
+			fmt.Fprintf(os.Stderr, "migrate: %s\n", err)
+
+			return 1
+		}
+	}
+	changed, err := junos.MigrateInterface(files, os.Stderr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "migrate: %s\n", err)
+
+		return 1
+	}
+	if *dryRun {
+		return 0
+	}
+	for path, src := range changed {
+		if err := ioutil.WriteFile(path, src, 0644); err != nil { // nolint: gosec
+			fmt.Fprintf(os.Stderr, "migrate: %s\n", err)
+
+			return 1
+		}
+	}
+
+	return 0
+}